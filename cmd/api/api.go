@@ -1,21 +1,30 @@
 package api
 
 import (
+	"context"
 	"database/sql"
 	"log"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/jwtauth"
 
+	"github.com/happYness-Project/taskManagementGolang/internal/activitylevel"
+	"github.com/happYness-Project/taskManagementGolang/internal/archive"
 	chatRepo "github.com/happYness-Project/taskManagementGolang/internal/chat/repository"
+	notificationRepo "github.com/happYness-Project/taskManagementGolang/internal/notification/repository"
+	"github.com/happYness-Project/taskManagementGolang/internal/reminder"
+	"github.com/happYness-Project/taskManagementGolang/internal/rotation"
+	taskModel "github.com/happYness-Project/taskManagementGolang/internal/task/model"
 	taskRepo "github.com/happYness-Project/taskManagementGolang/internal/task/repository"
 	containerRepo "github.com/happYness-Project/taskManagementGolang/internal/taskcontainer/repository"
 	userRepo "github.com/happYness-Project/taskManagementGolang/internal/user/repository"
 	usergroupRepo "github.com/happYness-Project/taskManagementGolang/internal/usergroup/repository"
 
 	chatRoute "github.com/happYness-Project/taskManagementGolang/internal/chat/route"
+	notificationRoute "github.com/happYness-Project/taskManagementGolang/internal/notification/route"
 	taskRoute "github.com/happYness-Project/taskManagementGolang/internal/task/route"
 	containerRoute "github.com/happYness-Project/taskManagementGolang/internal/taskcontainer/route"
 	userRoute "github.com/happYness-Project/taskManagementGolang/internal/user/route"
@@ -23,6 +32,7 @@ import (
 	"github.com/happYness-Project/taskManagementGolang/pkg/loggers"
 	"github.com/happYness-Project/taskManagementGolang/pkg/middlewares"
 	"github.com/happYness-Project/taskManagementGolang/pkg/response"
+	"github.com/happYness-Project/taskManagementGolang/pkg/utils"
 )
 
 type ApiServer struct {
@@ -56,15 +66,22 @@ func (s *ApiServer) Setup() *chi.Mux {
 
 	userRepo := userRepo.NewUserRepository(s.db)
 	usergroupRepo := usergroupRepo.NewUserGroupRepository(s.db)
+	reminderRepo := taskRepo.NewReminderRepository(s.db)
+	rotationRepo := taskRepo.NewRotationRepository(s.db)
+	pointsRepo := taskRepo.NewPointsRepository(s.db)
+	habitRepo := taskRepo.NewHabitRepository(s.db)
 	taskRepo := taskRepo.NewTaskRepository(s.db)
+	sectionRepo := containerRepo.NewSectionRepository(s.db)
 	containerRepo := containerRepo.NewContainerRepository(s.db)
 	chatRepo := chatRepo.NewChatRepository(s.db)
+	notificationRepo := notificationRepo.NewNotificationRepository(s.db)
 
 	userHandler := userRoute.NewHandler(s.logger, userRepo, usergroupRepo)
-	usergroupHandler := usergroupRoute.NewHandler(s.logger, usergroupRepo, userRepo)
-	taskHandler := taskRoute.NewHandler(s.logger, taskRepo, containerRepo, usergroupRepo)
-	containerHandler := containerRoute.NewHandler(s.logger, containerRepo, userRepo)
-	chatHandler := chatRoute.NewHandler(s.logger, chatRepo, usergroupRepo)
+	usergroupHandler := usergroupRoute.NewHandler(s.logger, usergroupRepo, userRepo, rotation.NewMembershipSync(s.logger, rotationRepo))
+	taskHandler := taskRoute.NewHandler(s.logger, taskRepo, containerRepo, usergroupRepo, userRepo, notificationRepo, reminderRepo, rotationRepo, pointsRepo, habitRepo, sectionRepo)
+	containerHandler := containerRoute.NewHandler(s.logger, containerRepo, userRepo, sectionRepo, usergroupRepo)
+	chatHandler := chatRoute.NewHandler(s.logger, chatRepo, usergroupRepo, containerRepo, userRepo)
+	notificationHandler := notificationRoute.NewHandler(s.logger, notificationRepo, userRepo)
 
 	mux.Group(func(r chi.Router) {
 		r.Use(jwtauth.Verifier(s.tokenAuth))
@@ -74,11 +91,40 @@ func (s *ApiServer) Setup() *chi.Mux {
 		taskHandler.RegisterRoutes(r)
 		containerHandler.RegisterRoutes(r)
 		chatHandler.RegisterRoutes(r)
+		notificationHandler.RegisterRoutes(r)
 	})
 
 	return mux
 }
 
+// StartReminderScheduler fires task reminders in the background until ctx is
+// cancelled. Email reminders are only delivered when an SMTP host is configured.
+func (s *ApiServer) StartReminderScheduler(ctx context.Context, email reminder.EmailConfig) {
+	notifiers := map[string]reminder.Notifier{
+		taskModel.ReminderChannelInApp:   reminder.NewInAppNotifier(notificationRepo.NewNotificationRepository(s.db)),
+		taskModel.ReminderChannelWebhook: reminder.NewWebhookNotifier(nil),
+	}
+	if email.Host != "" {
+		notifiers[taskModel.ReminderChannelEmail] = reminder.NewEmailNotifier(email)
+	}
+	scheduler := reminder.NewScheduler(s.logger, taskRepo.NewReminderRepository(s.db), taskRepo.NewTaskRepository(s.db),
+		notifiers, utils.SystemClock{}, time.Minute)
+	scheduler.Start(ctx)
+}
+
+// StartTaskArchiver archives completed tasks in the background until ctx is cancelled.
+func (s *ApiServer) StartTaskArchiver(ctx context.Context) {
+	archiver := archive.NewArchiver(s.logger, taskRepo.NewTaskRepository(s.db), utils.SystemClock{}, time.Hour)
+	archiver.Start(ctx)
+}
+
+// StartActivityRefresher recomputes container activity levels in the background
+// until ctx is cancelled.
+func (s *ApiServer) StartActivityRefresher(ctx context.Context) {
+	refresher := activitylevel.NewRefresher(s.logger, containerRepo.NewContainerRepository(s.db), utils.SystemClock{}, 15*time.Minute)
+	refresher.Start(ctx)
+}
+
 func (s *ApiServer) Run(mux *chi.Mux) error {
 	log.Println("Listening on ", s.addr)
 	return http.ListenAndServe(s.addr, mux)
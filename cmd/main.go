@@ -1,10 +1,12 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 
 	"github.com/happYness-Project/taskManagementGolang/cmd/api"
+	"github.com/happYness-Project/taskManagementGolang/internal/reminder"
 	"github.com/happYness-Project/taskManagementGolang/pkg/configs"
 	"github.com/happYness-Project/taskManagementGolang/pkg/dbs"
 	"github.com/happYness-Project/taskManagementGolang/pkg/loggers"
@@ -34,6 +36,15 @@ func main() {
 
 	server := api.NewApiServer(fmt.Sprintf("%s:%s", env.Host, env.Port), env.AccessTokenSecret, database, logger)
 	r := server.Setup()
+	server.StartTaskArchiver(context.Background())
+	server.StartActivityRefresher(context.Background())
+	server.StartReminderScheduler(context.Background(), reminder.EmailConfig{
+		Host:     env.SMTPHost,
+		Port:     env.SMTPPort,
+		User:     env.SMTPUser,
+		Password: env.SMTPPwd,
+		From:     env.SMTPFrom,
+	})
 	if err := server.Run(r); err != nil {
 		logger.Error().Err(err).Msg("Unable to set up the server.")
 		return
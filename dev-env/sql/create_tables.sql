@@ -52,6 +52,7 @@ CREATE TABLE IF NOT EXISTS public.user (
     created_at timestamp without time zone,
     updated_at timestamp without time zone,
     default_group_id bigint,
+    timezone character varying(64) NOT NULL DEFAULT 'UTC',
     CONSTRAINT pk_user PRIMARY KEY (id)
 );
 -- SELECT pg_catalog.setval('public.users_id_seq', 1, true);
@@ -62,6 +63,7 @@ CREATE TABLE IF NOT EXISTS public.usergroup (
     type CHARACTER VARYING(30),
     thumbnailUrl CHARACTER VARYING(255),
     is_active boolean,
+    archive_completed_after_days integer CHECK (archive_completed_after_days BETWEEN 1 AND 365),
     CONSTRAINT pk_usergroup PRIMARY KEY (id)
 );
 
@@ -73,11 +75,41 @@ CREATE TABLE IF NOT EXISTS public.task (
     created_at timestamp with time zone,
     updated_at timestamp with time zone,
     target_date timestamp with time zone,
-    priority character varying(50),
+    due_date date,
+    timezone character varying(64) NOT NULL DEFAULT 'UTC',
+    priority character varying(50) NOT NULL DEFAULT 'normal' CHECK (priority IN ('low', 'normal', 'high', 'urgent')),
     category character varying(20),
+    quantity numeric(10, 2) CHECK (quantity > 0),
+    unit character varying(20),
+    aisle character varying(50),
     is_completed boolean NOT NULL,
     is_important boolean NOT NULL,
-    CONSTRAINT pk_task PRIMARY KEY (id)
+    completed_at timestamp with time zone,
+    snoozed_until timestamp with time zone,
+    archived_at timestamp with time zone,
+    created_by bigint,
+    assignee_id bigint,
+    recurrence character varying(20) CHECK (recurrence IN ('daily', 'weekly', 'monthly')),
+    series_id uuid,
+    points integer CHECK (points BETWEEN 0 AND 1000),
+    frequency character varying(10) CHECK (frequency IN ('daily', 'weekly')),
+    target_count smallint CHECK (target_count BETWEEN 1 AND 7),
+    CONSTRAINT pk_task PRIMARY KEY (id),
+    CONSTRAINT fk_task_created_by FOREIGN KEY (created_by) REFERENCES public.user(id) ON DELETE SET NULL,
+    CONSTRAINT fk_task_assignee_id FOREIGN KEY (assignee_id) REFERENCES public.user(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_task_series_id ON public.task (series_id) WHERE series_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS public.task_activity (
+    id uuid NOT NULL DEFAULT public.uuid_generate_v7(),
+    task_id uuid NOT NULL,
+    user_id bigint,
+    action CHARACTER VARYING(30) NOT NULL,
+    detail CHARACTER VARYING(255),
+    created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT pk_task_activity PRIMARY KEY (id),
+    CONSTRAINT fk_task_activity_task_id FOREIGN KEY (task_id) REFERENCES public.task(id) ON DELETE CASCADE,
+    CONSTRAINT fk_task_activity_user_id FOREIGN KEY (user_id) REFERENCES public.user(id) ON DELETE SET NULL
 );
 
 CREATE TABLE IF NOT EXISTS public.taskcontainer (
@@ -88,27 +120,194 @@ CREATE TABLE IF NOT EXISTS public.taskcontainer (
     activity_level INT,
     type CHARACTER (50),
     usergroup_id bigint,
+    visibility CHARACTER VARYING(10) NOT NULL DEFAULT 'group' CHECK (visibility IN ('group', 'members')),
     CONSTRAINT pk_taskcontainer PRIMARY KEY (id),
     CONSTRAINT fk_usergroup_id_taskcontainer_usergroupId FOREIGN KEY (usergroup_id)
         REFERENCES public.usergroup ON DELETE CASCADE
 );
 
+CREATE TABLE IF NOT EXISTS public.container_section (
+  id uuid NOT NULL,
+  container_id uuid NOT NULL,
+  name CHARACTER VARYING(50) NOT NULL,
+  position integer NOT NULL DEFAULT 0,
+  status CHARACTER VARYING(10) CHECK (status IN ('open', 'done')),
+  created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT pk_container_section PRIMARY KEY (id),
+  CONSTRAINT fk_container_section_container_id FOREIGN KEY(container_id) REFERENCES public.taskcontainer(id) ON DELETE CASCADE
+);
+
 CREATE TABLE IF NOT EXISTS public.taskcontainer_task (
   taskcontainer_id uuid NOT NULL,
   task_id uuid NOT NULL,
+  section_id uuid,
+  position integer NOT NULL DEFAULT 0,
   PRIMARY KEY (taskcontainer_id, task_id),
+  CONSTRAINT fk_taskcontainer_task_section_id FOREIGN KEY(section_id) REFERENCES public.container_section(id) ON DELETE SET NULL,
   CONSTRAINT fk_taskcontainer_task_taskcontainer_id FOREIGN KEY(taskcontainer_id) REFERENCES public.taskcontainer(id) ON DELETE CASCADE,
   CONSTRAINT fk_taskcontainer_task_task_id FOREIGN KEY(task_id) REFERENCES public.task(id) ON DELETE CASCADE
 );
 
+CREATE TABLE IF NOT EXISTS public.taskcontainer_member (
+  container_id uuid NOT NULL,
+  user_id bigint NOT NULL,
+  PRIMARY KEY (container_id, user_id),
+  CONSTRAINT fk_taskcontainer_member_container_id FOREIGN KEY(container_id) REFERENCES public.taskcontainer(id) ON DELETE CASCADE,
+  CONSTRAINT fk_taskcontainer_member_user_id FOREIGN KEY(user_id) REFERENCES public.user(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS public.user_container_order (
+  user_id bigint NOT NULL,
+  container_id uuid NOT NULL,
+  position integer NOT NULL,
+  is_pinned boolean NOT NULL DEFAULT false,
+  PRIMARY KEY (user_id, container_id),
+  CONSTRAINT fk_user_container_order_user_id FOREIGN KEY(user_id) REFERENCES public.user(id) ON DELETE CASCADE,
+  CONSTRAINT fk_user_container_order_container_id FOREIGN KEY(container_id) REFERENCES public.taskcontainer(id) ON DELETE CASCADE
+);
+
 CREATE TABLE IF NOT EXISTS public.usergroup_user (
   usergroup_id bigint NOT NULL,
   user_id bigint NOT NULL,
+  role CHARACTER VARYING(10) NOT NULL DEFAULT 'member' CHECK (role IN ('owner', 'admin', 'member')),
   PRIMARY KEY (usergroup_id, user_id),
   CONSTRAINT fk_usergroup_user_usergroup_id FOREIGN KEY(usergroup_id) REFERENCES public.usergroup(id) ON DELETE CASCADE,
   CONSTRAINT fk_usergroup_user_user_id FOREIGN KEY(user_id) REFERENCES public.user(id) ON DELETE CASCADE
 );
 
+CREATE TABLE IF NOT EXISTS public.usergroup_activity (
+    id uuid NOT NULL DEFAULT public.uuid_generate_v7(),
+    usergroup_id bigint NOT NULL,
+    user_id bigint,
+    action CHARACTER VARYING(30) NOT NULL,
+    detail CHARACTER VARYING(255),
+    created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT pk_usergroup_activity PRIMARY KEY (id),
+    CONSTRAINT fk_usergroup_activity_usergroup_id FOREIGN KEY (usergroup_id) REFERENCES public.usergroup(id) ON DELETE CASCADE,
+    CONSTRAINT fk_usergroup_activity_user_id FOREIGN KEY (user_id) REFERENCES public.user(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_usergroup_activity_group ON public.usergroup_activity (usergroup_id, created_at);
+
+CREATE TABLE IF NOT EXISTS public.usergroup_invitation (
+    id uuid NOT NULL DEFAULT public.uuid_generate_v7(),
+    usergroup_id bigint NOT NULL,
+    token CHARACTER VARYING(64) NOT NULL,
+    created_by bigint,
+    max_uses integer NOT NULL CHECK (max_uses > 0),
+    uses integer NOT NULL DEFAULT 0,
+    expires_at timestamp with time zone NOT NULL,
+    revoked_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT pk_usergroup_invitation PRIMARY KEY (id),
+    CONSTRAINT uq_usergroup_invitation_token UNIQUE (token),
+    CONSTRAINT fk_usergroup_invitation_usergroup_id FOREIGN KEY (usergroup_id) REFERENCES public.usergroup(id) ON DELETE CASCADE,
+    CONSTRAINT fk_usergroup_invitation_created_by FOREIGN KEY (created_by) REFERENCES public.user(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_usergroup_invitation_group ON public.usergroup_invitation (usergroup_id, created_at);
+
+CREATE TABLE IF NOT EXISTS public.task_watcher (
+  task_id uuid NOT NULL,
+  user_id bigint NOT NULL,
+  created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (task_id, user_id),
+  CONSTRAINT fk_task_watcher_task_id FOREIGN KEY(task_id) REFERENCES public.task(id) ON DELETE CASCADE,
+  CONSTRAINT fk_task_watcher_user_id FOREIGN KEY(user_id) REFERENCES public.user(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS public.task_revision (
+  task_id uuid NOT NULL,
+  number integer NOT NULL,
+  snapshot jsonb NOT NULL,
+  edited_by bigint,
+  created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (task_id, number),
+  CONSTRAINT fk_task_revision_task_id FOREIGN KEY(task_id) REFERENCES public.task(id) ON DELETE CASCADE,
+  CONSTRAINT fk_task_revision_edited_by FOREIGN KEY(edited_by) REFERENCES public.user(id) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS public.task_reminder (
+  id uuid NOT NULL DEFAULT public.uuid_generate_v7(),
+  task_id uuid NOT NULL,
+  user_id bigint NOT NULL,
+  channel CHARACTER VARYING(20) NOT NULL DEFAULT 'in_app' CHECK (channel IN ('in_app', 'webhook', 'email')),
+  target CHARACTER VARYING(255),
+  offset_minutes integer,
+  remind_at timestamp with time zone NOT NULL,
+  fired_at timestamp with time zone,
+  attempts integer NOT NULL DEFAULT 0,
+  next_attempt_at timestamp with time zone,
+  failed_at timestamp with time zone,
+  created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT pk_task_reminder PRIMARY KEY (id),
+  CONSTRAINT fk_task_reminder_task_id FOREIGN KEY(task_id) REFERENCES public.task(id) ON DELETE CASCADE,
+  CONSTRAINT fk_task_reminder_user_id FOREIGN KEY(user_id) REFERENCES public.user(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_task_reminder_pending ON public.task_reminder (COALESCE(next_attempt_at, remind_at)) WHERE fired_at IS NULL AND failed_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS public.task_mention (
+  task_id uuid NOT NULL,
+  user_id bigint NOT NULL,
+  position int NOT NULL DEFAULT 0,
+  PRIMARY KEY (task_id, user_id),
+  CONSTRAINT fk_task_mention_task_id FOREIGN KEY(task_id) REFERENCES public.task(id) ON DELETE CASCADE,
+  CONSTRAINT fk_task_mention_user_id FOREIGN KEY(user_id) REFERENCES public.user(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS public.chore_rotation (
+  series_id uuid NOT NULL,
+  usergroup_id bigint NOT NULL,
+  strategy CHARACTER VARYING(20) NOT NULL DEFAULT 'round_robin' CHECK (strategy IN ('round_robin', 'least_loaded')),
+  current_position integer NOT NULL DEFAULT 0,
+  CONSTRAINT pk_chore_rotation PRIMARY KEY (series_id),
+  CONSTRAINT fk_chore_rotation_usergroup_id FOREIGN KEY(usergroup_id) REFERENCES public.usergroup(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS public.chore_rotation_member (
+  series_id uuid NOT NULL,
+  user_id bigint NOT NULL,
+  position integer NOT NULL,
+  PRIMARY KEY (series_id, user_id),
+  CONSTRAINT fk_chore_rotation_member_series_id FOREIGN KEY(series_id) REFERENCES public.chore_rotation(series_id) ON DELETE CASCADE,
+  CONSTRAINT fk_chore_rotation_member_user_id FOREIGN KEY(user_id) REFERENCES public.user(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS public.habit_check_in (
+  task_id uuid NOT NULL,
+  check_in_date date NOT NULL,
+  user_id bigint,
+  created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (task_id, check_in_date),
+  CONSTRAINT fk_habit_check_in_task_id FOREIGN KEY(task_id) REFERENCES public.task(id) ON DELETE CASCADE,
+  CONSTRAINT fk_habit_check_in_user_id FOREIGN KEY(user_id) REFERENCES public.user(id) ON DELETE SET NULL
+);
+
+-- Awards are kept when the task is deleted; reopening the task revokes them.
+CREATE TABLE IF NOT EXISTS public.task_point_award (
+  task_id uuid NOT NULL,
+  user_id bigint NOT NULL,
+  usergroup_id bigint NOT NULL,
+  points integer NOT NULL,
+  awarded_at timestamp with time zone NOT NULL,
+  awarded_on date NOT NULL,
+  CONSTRAINT pk_task_point_award PRIMARY KEY (task_id),
+  CONSTRAINT fk_task_point_award_user_id FOREIGN KEY(user_id) REFERENCES public.user(id) ON DELETE CASCADE,
+  CONSTRAINT fk_task_point_award_usergroup_id FOREIGN KEY(usergroup_id) REFERENCES public.usergroup(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_task_point_award_group ON public.task_point_award (usergroup_id, awarded_at);
+
+CREATE TABLE IF NOT EXISTS public.notification (
+    id uuid NOT NULL DEFAULT public.uuid_generate_v7(),
+    user_id bigint NOT NULL,
+    type CHARACTER VARYING(30) NOT NULL,
+    message CHARACTER VARYING(255),
+    task_id uuid,
+    is_read boolean NOT NULL DEFAULT false,
+    created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT pk_notification PRIMARY KEY (id),
+    CONSTRAINT fk_notification_user_id FOREIGN KEY (user_id) REFERENCES public.user(id) ON DELETE CASCADE,
+    CONSTRAINT fk_notification_task_id FOREIGN KEY (task_id) REFERENCES public.task(id) ON DELETE CASCADE
+);
+
 CREATE TABLE IF NOT EXISTS public.chat (
     id uuid NOT NULL,
     type CHARACTER VARYING(20) NOT NULL CHECK (type IN ('private', 'group', 'container')),
@@ -142,24 +341,22 @@ INSERT INTO public."user"(user_id, username, first_name, last_name, email, is_ac
 INSERT INTO public."user"(user_id, username, first_name, last_name, email, is_active, created_at, updated_at, default_group_id) VALUES ('01959b3a-405b-7591-86dd-87174e2453fd', 'testing1',     'test',     'check',    'testing1@hproject.com',		true, '2024-08-05 00:00:00', 	'2024-08-05 00:00:00',2);
 INSERT INTO public."user"(user_id, username, first_name, last_name, email, is_active, created_at, updated_at, default_group_id) VALUES ('0195c388-d0f4-77d5-be90-971d38344c74', 'testing2',     'test',     'check',    'testing2@hproject.com', 		true, '2024-08-05 00:00:00', 	'2024-08-05 00:00:00',2);
 
-INSERT INTO public.usergroup_user(usergroup_id, user_id) VALUES (1, 1);
+INSERT INTO public.usergroup_user(usergroup_id, user_id, role) VALUES (1, 1, 'owner');
 INSERT INTO public.usergroup_user(usergroup_id, user_id) VALUES (1, 2);
 INSERT INTO public.usergroup_user(usergroup_id, user_id) VALUES (1, 3);
-INSERT INTO public.usergroup_user(usergroup_id, user_id) VALUES (2, 4);
-INSERT INTO public.usergroup_user(usergroup_id, user_id) VALUES (3, 1);
-INSERT INTO public.usergroup_user(usergroup_id, user_id) VALUES (4, 2);
-INSERT INTO public.usergroup_user(usergroup_id, user_id) VALUES (5, 1);
-INSERT INTO public.taskcontainer(id, name, description, is_active, activity_level, type, usergroup_id) VALUES ('5951f639-c8ce-4462-8b72-c57458c448fd', 'grocery', 'grocery container for my family', true, 0, 'normal', 1);
-INSERT INTO public.taskcontainer(id, name, description, is_active, activity_level, type, usergroup_id) VALUES ('22095f67-168a-47f4-9d77-90cf27d77c89', 'chores', 'chores container for my family', true, 0, 'normal', 1);
-INSERT INTO public.taskcontainer(id, name, description, is_active, activity_level, type, usergroup_id) VALUES ('9ccba4b5-4745-4d5c-8901-46b159c71516', 'grocery', 'grocery container for my family', true, 0, 'normal', 2);
-INSERT INTO public.task(id, name, description, type, created_at, updated_at, target_date, priority, category, is_completed, is_important) VALUES ('94d277a0-245a-4155-aea3-29f6cbabd849', 'Apple', 'need this for apple pie', '', CURRENT_DATE,CURRENT_DATE,CURRENT_DATE + INTERVAL  '6 days', 'normal', 'grocery', false, false);
-INSERT INTO public.task(id, name, description, type, created_at, updated_at, target_date, priority, category, is_completed, is_important) VALUES ('06e1840f-b5a9-4008-9add-7170272291d1', 'Banana', 'need this for breakfast', '', CURRENT_DATE,CURRENT_DATE,CURRENT_DATE + INTERVAL  '3 days', 'high', 'grocery', false, false);
-INSERT INTO public.task(id, name, description, type, created_at, updated_at, target_date, priority, category, is_completed, is_important) VALUES ('5d89f6e6-59e7-4232-9292-4f0031c43254', 'Banana', 'need this for breakfast', '', CURRENT_DATE,CURRENT_DATE,CURRENT_DATE + INTERVAL  '4 days', 'normal', 'grocery', false, false);
-INSERT INTO public.task(id, name, description, type, created_at, updated_at, target_date, priority, category, is_completed, is_important) VALUES ('85b6e084-6995-4e49-b128-2e5700b19b67', 'Green onion', 'need for kimchi', '', CURRENT_DATE,CURRENT_DATE,CURRENT_DATE + INTERVAL  '4 days', 'normal', 'grocery', false, false);
-INSERT INTO public.task(id, name, description, type, created_at, updated_at, target_date, priority, category, is_completed, is_important) VALUES ('2ce3fc41-d1c6-45b3-9111-bcb979aa943b', 'Dish Wash', '', '', CURRENT_DATE,CURRENT_DATE,CURRENT_DATE + INTERVAL  '1 days', 'urgent', 'chores', false, false);
+INSERT INTO public.usergroup_user(usergroup_id, user_id, role) VALUES (2, 4, 'owner');
+INSERT INTO public.usergroup_user(usergroup_id, user_id, role) VALUES (3, 1, 'owner');
+INSERT INTO public.usergroup_user(usergroup_id, user_id, role) VALUES (4, 2, 'owner');
+INSERT INTO public.usergroup_user(usergroup_id, user_id, role) VALUES (5, 1, 'owner');
+INSERT INTO public.taskcontainer(id, name, description, is_active, activity_level, type, usergroup_id) VALUES ('5951f639-c8ce-4462-8b72-c57458c448fd', 'grocery', 'grocery container for my family', true, 0, 'grocery', 1);
+INSERT INTO public.taskcontainer(id, name, description, is_active, activity_level, type, usergroup_id) VALUES ('22095f67-168a-47f4-9d77-90cf27d77c89', 'chores', 'chores container for my family', true, 0, 'chores', 1);
+INSERT INTO public.taskcontainer(id, name, description, is_active, activity_level, type, usergroup_id) VALUES ('9ccba4b5-4745-4d5c-8901-46b159c71516', 'grocery', 'grocery container for my family', true, 0, 'grocery', 2);
+INSERT INTO public.task(id, name, description, type, created_at, updated_at, due_date, timezone, priority, category, is_completed, is_important) VALUES ('94d277a0-245a-4155-aea3-29f6cbabd849', 'Apple', 'need this for apple pie', '', CURRENT_DATE,CURRENT_DATE,CURRENT_DATE + 6, 'America/Vancouver', 'normal', 'grocery', false, false);
+INSERT INTO public.task(id, name, description, type, created_at, updated_at, due_date, timezone, priority, category, quantity, unit, aisle, is_completed, is_important) VALUES ('06e1840f-b5a9-4008-9add-7170272291d1', 'Banana', 'need this for breakfast', '', CURRENT_DATE,CURRENT_DATE,CURRENT_DATE + 3, 'America/Vancouver', 'high', 'grocery', 2, NULL, 'Produce', false, false);
+INSERT INTO public.task(id, name, description, type, created_at, updated_at, due_date, timezone, priority, category, is_completed, is_important) VALUES ('85b6e084-6995-4e49-b128-2e5700b19b67', 'Green onion', 'need for kimchi', '', CURRENT_DATE,CURRENT_DATE,CURRENT_DATE + 4, 'America/Vancouver', 'normal', 'grocery', false, false);
+INSERT INTO public.task(id, name, description, type, created_at, updated_at, due_date, timezone, priority, category, is_completed, is_important) VALUES ('2ce3fc41-d1c6-45b3-9111-bcb979aa943b', 'Dish Wash', '', '', CURRENT_DATE,CURRENT_DATE,CURRENT_DATE + 1, 'America/Vancouver', 'urgent', 'chores', false, false);
 INSERT INTO public.taskcontainer_task(taskcontainer_id, task_id) VALUES ('5951f639-c8ce-4462-8b72-c57458c448fd', '94d277a0-245a-4155-aea3-29f6cbabd849');
 INSERT INTO public.taskcontainer_task(taskcontainer_id, task_id) VALUES ('5951f639-c8ce-4462-8b72-c57458c448fd', '06e1840f-b5a9-4008-9add-7170272291d1');
-INSERT INTO public.taskcontainer_task(taskcontainer_id, task_id) VALUES ('5951f639-c8ce-4462-8b72-c57458c448fd', '5d89f6e6-59e7-4232-9292-4f0031c43254');
 INSERT INTO public.taskcontainer_task(taskcontainer_id, task_id) VALUES ('9ccba4b5-4745-4d5c-8901-46b159c71516', '85b6e084-6995-4e49-b128-2e5700b19b67');
 INSERT INTO public.taskcontainer_task(taskcontainer_id, task_id) VALUES ('22095f67-168a-47f4-9d77-90cf27d77c89', '2ce3fc41-d1c6-45b3-9111-bcb979aa943b');
 
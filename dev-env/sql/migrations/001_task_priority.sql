@@ -0,0 +1,15 @@
+-- Normalizes free-form task priorities into the fixed set defined by
+-- internal/task/model/priority.go and enforces it from now on.
+-- create_tables.sql already contains the constraint for new databases.
+BEGIN;
+
+UPDATE public.task SET priority = lower(trim(priority)) WHERE priority IS NOT NULL;
+UPDATE public.task SET priority = 'normal'
+    WHERE priority IS NULL OR priority NOT IN ('low', 'normal', 'high', 'urgent');
+
+ALTER TABLE public.task ALTER COLUMN priority SET DEFAULT 'normal';
+ALTER TABLE public.task ALTER COLUMN priority SET NOT NULL;
+ALTER TABLE public.task DROP CONSTRAINT IF EXISTS task_priority_check;
+ALTER TABLE public.task ADD CONSTRAINT task_priority_check CHECK (priority IN ('low', 'normal', 'high', 'urgent'));
+
+COMMIT;
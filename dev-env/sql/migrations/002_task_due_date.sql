@@ -0,0 +1,16 @@
+-- Splits task due dates into an all-day calendar date (due_date) and a timed
+-- instant (target_date), and records the time zone the task was planned in.
+-- Existing target dates at exactly midnight UTC were written as plain dates,
+-- so they are converted to all-day due dates.
+-- create_tables.sql already contains these columns for new databases.
+BEGIN;
+
+ALTER TABLE public.task ADD COLUMN IF NOT EXISTS due_date date;
+ALTER TABLE public.task ADD COLUMN IF NOT EXISTS timezone character varying(64) NOT NULL DEFAULT 'UTC';
+
+UPDATE public.task
+    SET due_date = (target_date AT TIME ZONE 'UTC')::date, target_date = NULL
+    WHERE target_date IS NOT NULL
+      AND (target_date AT TIME ZONE 'UTC')::time = time '00:00';
+
+COMMIT;
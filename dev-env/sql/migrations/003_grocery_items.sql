@@ -0,0 +1,15 @@
+-- Adds grocery item fields to tasks and marks the existing grocery containers,
+-- which were created with the default 'normal' type, as grocery lists.
+-- Duplicate open items that already exist are not merged; new items are merged
+-- by the API from now on.
+-- create_tables.sql already contains these columns for new databases.
+BEGIN;
+
+ALTER TABLE public.task ADD COLUMN IF NOT EXISTS quantity numeric(10, 2) CHECK (quantity > 0);
+ALTER TABLE public.task ADD COLUMN IF NOT EXISTS unit character varying(20);
+ALTER TABLE public.task ADD COLUMN IF NOT EXISTS aisle character varying(50);
+
+UPDATE public.taskcontainer SET type = 'grocery'
+    WHERE lower(trim(name)) = 'grocery' AND trim(type) = 'normal';
+
+COMMIT;
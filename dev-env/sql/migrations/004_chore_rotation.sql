@@ -0,0 +1,35 @@
+-- Adds recurring tasks, assignees and chore rotations, and marks the existing
+-- chores containers, which were created with the default 'normal' type, as
+-- chores containers.
+-- create_tables.sql already contains these changes for new databases.
+BEGIN;
+
+ALTER TABLE public.task ADD COLUMN IF NOT EXISTS assignee_id bigint
+    REFERENCES public.user(id) ON DELETE SET NULL;
+ALTER TABLE public.task ADD COLUMN IF NOT EXISTS recurrence character varying(20)
+    CHECK (recurrence IN ('daily', 'weekly', 'monthly'));
+ALTER TABLE public.task ADD COLUMN IF NOT EXISTS series_id uuid;
+CREATE INDEX IF NOT EXISTS idx_task_series_id ON public.task (series_id) WHERE series_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS public.chore_rotation (
+  series_id uuid NOT NULL,
+  usergroup_id bigint NOT NULL,
+  strategy CHARACTER VARYING(20) NOT NULL DEFAULT 'round_robin' CHECK (strategy IN ('round_robin', 'least_loaded')),
+  current_position integer NOT NULL DEFAULT 0,
+  CONSTRAINT pk_chore_rotation PRIMARY KEY (series_id),
+  CONSTRAINT fk_chore_rotation_usergroup_id FOREIGN KEY(usergroup_id) REFERENCES public.usergroup(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS public.chore_rotation_member (
+  series_id uuid NOT NULL,
+  user_id bigint NOT NULL,
+  position integer NOT NULL,
+  PRIMARY KEY (series_id, user_id),
+  CONSTRAINT fk_chore_rotation_member_series_id FOREIGN KEY(series_id) REFERENCES public.chore_rotation(series_id) ON DELETE CASCADE,
+  CONSTRAINT fk_chore_rotation_member_user_id FOREIGN KEY(user_id) REFERENCES public.user(id) ON DELETE CASCADE
+);
+
+UPDATE public.taskcontainer SET type = 'chores'
+    WHERE lower(trim(name)) = 'chores' AND trim(type) = 'normal';
+
+COMMIT;
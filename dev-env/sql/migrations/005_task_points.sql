@@ -0,0 +1,21 @@
+-- Adds explicit task points and the point awards behind streaks and the
+-- leaderboard. Tasks completed before this migration earn no points.
+-- create_tables.sql already contains these changes for new databases.
+BEGIN;
+
+ALTER TABLE public.task ADD COLUMN IF NOT EXISTS points integer CHECK (points BETWEEN 0 AND 1000);
+
+CREATE TABLE IF NOT EXISTS public.task_point_award (
+  task_id uuid NOT NULL,
+  user_id bigint NOT NULL,
+  usergroup_id bigint NOT NULL,
+  points integer NOT NULL,
+  awarded_at timestamp with time zone NOT NULL,
+  awarded_on date NOT NULL,
+  CONSTRAINT pk_task_point_award PRIMARY KEY (task_id),
+  CONSTRAINT fk_task_point_award_user_id FOREIGN KEY(user_id) REFERENCES public.user(id) ON DELETE CASCADE,
+  CONSTRAINT fk_task_point_award_usergroup_id FOREIGN KEY(usergroup_id) REFERENCES public.usergroup(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_task_point_award_group ON public.task_point_award (usergroup_id, awarded_at);
+
+COMMIT;
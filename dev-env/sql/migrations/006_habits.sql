@@ -0,0 +1,21 @@
+-- Adds habit targets to tasks and per-date habit check-ins. Habits live in
+-- containers of type 'habit'; no existing container is converted.
+-- create_tables.sql already contains these changes for new databases.
+BEGIN;
+
+ALTER TABLE public.task ADD COLUMN IF NOT EXISTS frequency character varying(10)
+    CHECK (frequency IN ('daily', 'weekly'));
+ALTER TABLE public.task ADD COLUMN IF NOT EXISTS target_count smallint
+    CHECK (target_count BETWEEN 1 AND 7);
+
+CREATE TABLE IF NOT EXISTS public.habit_check_in (
+  task_id uuid NOT NULL,
+  check_in_date date NOT NULL,
+  user_id bigint,
+  created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (task_id, check_in_date),
+  CONSTRAINT fk_habit_check_in_task_id FOREIGN KEY(task_id) REFERENCES public.task(id) ON DELETE CASCADE,
+  CONSTRAINT fk_habit_check_in_user_id FOREIGN KEY(user_id) REFERENCES public.user(id) ON DELETE SET NULL
+);
+
+COMMIT;
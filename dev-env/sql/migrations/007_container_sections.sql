@@ -0,0 +1,21 @@
+-- Adds board sections to containers and the section and position of each task
+-- within a container. Existing tasks start without a section.
+-- create_tables.sql already contains these changes for new databases.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS public.container_section (
+  id uuid NOT NULL,
+  container_id uuid NOT NULL,
+  name CHARACTER VARYING(50) NOT NULL,
+  position integer NOT NULL DEFAULT 0,
+  status CHARACTER VARYING(10) CHECK (status IN ('open', 'done')),
+  created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT pk_container_section PRIMARY KEY (id),
+  CONSTRAINT fk_container_section_container_id FOREIGN KEY(container_id) REFERENCES public.taskcontainer(id) ON DELETE CASCADE
+);
+
+ALTER TABLE public.taskcontainer_task ADD COLUMN IF NOT EXISTS section_id uuid
+    REFERENCES public.container_section(id) ON DELETE SET NULL;
+ALTER TABLE public.taskcontainer_task ADD COLUMN IF NOT EXISTS position integer NOT NULL DEFAULT 0;
+
+COMMIT;
@@ -0,0 +1,16 @@
+-- Adds the per-user pinning and ordering of containers. Containers without a
+-- row keep the default order after the ordered ones.
+-- create_tables.sql already contains these changes for new databases.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS public.user_container_order (
+  user_id bigint NOT NULL,
+  container_id uuid NOT NULL,
+  position integer NOT NULL,
+  is_pinned boolean NOT NULL DEFAULT false,
+  PRIMARY KEY (user_id, container_id),
+  CONSTRAINT fk_user_container_order_user_id FOREIGN KEY(user_id) REFERENCES public.user(id) ON DELETE CASCADE,
+  CONSTRAINT fk_user_container_order_container_id FOREIGN KEY(container_id) REFERENCES public.taskcontainer(id) ON DELETE CASCADE
+);
+
+COMMIT;
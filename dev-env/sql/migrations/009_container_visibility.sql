@@ -0,0 +1,18 @@
+-- Adds container visibility. Containers restricted to 'members' are only
+-- visible to the users in taskcontainer_member who still belong to the group.
+-- Existing containers stay visible to the whole group.
+-- create_tables.sql already contains these changes for new databases.
+BEGIN;
+
+ALTER TABLE public.taskcontainer ADD COLUMN IF NOT EXISTS visibility CHARACTER VARYING(10) NOT NULL DEFAULT 'group'
+    CHECK (visibility IN ('group', 'members'));
+
+CREATE TABLE IF NOT EXISTS public.taskcontainer_member (
+  container_id uuid NOT NULL,
+  user_id bigint NOT NULL,
+  PRIMARY KEY (container_id, user_id),
+  CONSTRAINT fk_taskcontainer_member_container_id FOREIGN KEY(container_id) REFERENCES public.taskcontainer(id) ON DELETE CASCADE,
+  CONSTRAINT fk_taskcontainer_member_user_id FOREIGN KEY(user_id) REFERENCES public.user(id) ON DELETE CASCADE
+);
+
+COMMIT;
@@ -0,0 +1,25 @@
+-- Adds admin and member roles to group membership and the group activity
+-- history. The member with the lowest user id of each group becomes its admin
+-- and everyone else stays a member.
+-- create_tables.sql already contains these changes for new databases.
+BEGIN;
+
+ALTER TABLE public.usergroup_user ADD COLUMN IF NOT EXISTS role CHARACTER VARYING(10) NOT NULL DEFAULT 'member'
+    CHECK (role IN ('admin', 'member'));
+UPDATE public.usergroup_user uu SET role = 'admin'
+    WHERE uu.user_id = (SELECT MIN(m.user_id) FROM public.usergroup_user m WHERE m.usergroup_id = uu.usergroup_id);
+
+CREATE TABLE IF NOT EXISTS public.usergroup_activity (
+    id uuid NOT NULL DEFAULT public.uuid_generate_v7(),
+    usergroup_id bigint NOT NULL,
+    user_id bigint,
+    action CHARACTER VARYING(30) NOT NULL,
+    detail CHARACTER VARYING(255),
+    created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT pk_usergroup_activity PRIMARY KEY (id),
+    CONSTRAINT fk_usergroup_activity_usergroup_id FOREIGN KEY (usergroup_id) REFERENCES public.usergroup(id) ON DELETE CASCADE,
+    CONSTRAINT fk_usergroup_activity_user_id FOREIGN KEY (user_id) REFERENCES public.user(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_usergroup_activity_group ON public.usergroup_activity (usergroup_id, created_at);
+
+COMMIT;
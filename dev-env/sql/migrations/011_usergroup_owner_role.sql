@@ -0,0 +1,12 @@
+-- Adds the owner role to group membership. The member with the lowest user id
+-- of each group becomes its only owner, so every group keeps exactly one.
+-- create_tables.sql already contains these changes for new databases.
+BEGIN;
+
+ALTER TABLE public.usergroup_user DROP CONSTRAINT IF EXISTS usergroup_user_role_check;
+ALTER TABLE public.usergroup_user ADD CONSTRAINT usergroup_user_role_check
+    CHECK (role IN ('owner', 'admin', 'member'));
+UPDATE public.usergroup_user uu SET role = 'owner'
+    WHERE uu.user_id = (SELECT MIN(m.user_id) FROM public.usergroup_user m WHERE m.usergroup_id = uu.usergroup_id);
+
+COMMIT;
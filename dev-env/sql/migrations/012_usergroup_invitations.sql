@@ -0,0 +1,23 @@
+-- Adds invitation tokens that let users join a group without being added by
+-- user id.
+-- create_tables.sql already contains these changes for new databases.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS public.usergroup_invitation (
+    id uuid NOT NULL DEFAULT public.uuid_generate_v7(),
+    usergroup_id bigint NOT NULL,
+    token CHARACTER VARYING(64) NOT NULL,
+    created_by bigint,
+    max_uses integer NOT NULL CHECK (max_uses > 0),
+    uses integer NOT NULL DEFAULT 0,
+    expires_at timestamp with time zone NOT NULL,
+    revoked_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT pk_usergroup_invitation PRIMARY KEY (id),
+    CONSTRAINT uq_usergroup_invitation_token UNIQUE (token),
+    CONSTRAINT fk_usergroup_invitation_usergroup_id FOREIGN KEY (usergroup_id) REFERENCES public.usergroup(id) ON DELETE CASCADE,
+    CONSTRAINT fk_usergroup_invitation_created_by FOREIGN KEY (created_by) REFERENCES public.user(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_usergroup_invitation_group ON public.usergroup_invitation (usergroup_id, created_at);
+
+COMMIT;
@@ -0,0 +1,9 @@
+-- Adds the completion time of tasks. Tasks completed before it existed take
+-- their last update as the closest known completion time.
+-- create_tables.sql already contains these changes for new databases.
+BEGIN;
+
+ALTER TABLE public.task ADD COLUMN IF NOT EXISTS completed_at timestamp with time zone;
+UPDATE public.task SET completed_at = updated_at WHERE is_completed AND completed_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,8 @@
+-- Adds the profile time zone of users. Tasks created without a time zone are
+-- planned in their creator's zone; existing users keep UTC.
+-- create_tables.sql already contains these changes for new databases.
+BEGIN;
+
+ALTER TABLE public.user ADD COLUMN IF NOT EXISTS timezone character varying(64) NOT NULL DEFAULT 'UTC';
+
+COMMIT;
@@ -0,0 +1,27 @@
+-- Adds task reminders. Failed deliveries are retried with backoff until the
+-- reminder runs out of attempts and is marked as failed.
+-- create_tables.sql already contains these changes for new databases.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS public.task_reminder (
+  id uuid NOT NULL DEFAULT public.uuid_generate_v7(),
+  task_id uuid NOT NULL,
+  user_id bigint NOT NULL,
+  channel CHARACTER VARYING(20) NOT NULL DEFAULT 'in_app' CHECK (channel IN ('in_app', 'webhook', 'email')),
+  target CHARACTER VARYING(255),
+  offset_minutes integer,
+  remind_at timestamp with time zone NOT NULL,
+  fired_at timestamp with time zone,
+  created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT pk_task_reminder PRIMARY KEY (id),
+  CONSTRAINT fk_task_reminder_task_id FOREIGN KEY(task_id) REFERENCES public.task(id) ON DELETE CASCADE,
+  CONSTRAINT fk_task_reminder_user_id FOREIGN KEY(user_id) REFERENCES public.user(id) ON DELETE CASCADE
+);
+ALTER TABLE public.task_reminder ADD COLUMN IF NOT EXISTS attempts integer NOT NULL DEFAULT 0;
+ALTER TABLE public.task_reminder ADD COLUMN IF NOT EXISTS next_attempt_at timestamp with time zone;
+ALTER TABLE public.task_reminder ADD COLUMN IF NOT EXISTS failed_at timestamp with time zone;
+
+DROP INDEX IF EXISTS public.idx_task_reminder_pending;
+CREATE INDEX idx_task_reminder_pending ON public.task_reminder (COALESCE(next_attempt_at, remind_at)) WHERE fired_at IS NULL AND failed_at IS NULL;
+
+COMMIT;
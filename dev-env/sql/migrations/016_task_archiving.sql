@@ -0,0 +1,10 @@
+-- Adds automatic archiving of completed tasks. Groups opt in by setting
+-- archive_completed_after_days; existing groups keep every task visible.
+-- create_tables.sql already contains these changes for new databases.
+BEGIN;
+
+ALTER TABLE public.usergroup ADD COLUMN IF NOT EXISTS archive_completed_after_days integer
+    CHECK (archive_completed_after_days BETWEEN 1 AND 365);
+ALTER TABLE public.task ADD COLUMN IF NOT EXISTS archived_at timestamp with time zone;
+
+COMMIT;
@@ -0,0 +1,17 @@
+-- Adds task revisions. Every update stores a snapshot of the task so edits can
+-- be diffed and reverted. Existing tasks start without history.
+-- create_tables.sql already contains these changes for new databases.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS public.task_revision (
+  task_id uuid NOT NULL,
+  number integer NOT NULL,
+  snapshot jsonb NOT NULL,
+  edited_by bigint,
+  created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (task_id, number),
+  CONSTRAINT fk_task_revision_task_id FOREIGN KEY(task_id) REFERENCES public.task(id) ON DELETE CASCADE,
+  CONSTRAINT fk_task_revision_edited_by FOREIGN KEY(edited_by) REFERENCES public.user(id) ON DELETE SET NULL
+);
+
+COMMIT;
@@ -0,0 +1,20 @@
+-- Adds task snoozing and the task activity log. Existing tasks start unsnoozed
+-- and without recorded activity.
+-- create_tables.sql already contains these changes for new databases.
+BEGIN;
+
+ALTER TABLE public.task ADD COLUMN IF NOT EXISTS snoozed_until timestamp with time zone;
+
+CREATE TABLE IF NOT EXISTS public.task_activity (
+    id uuid NOT NULL DEFAULT public.uuid_generate_v7(),
+    task_id uuid NOT NULL,
+    user_id bigint,
+    action CHARACTER VARYING(30) NOT NULL,
+    detail CHARACTER VARYING(255),
+    created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT pk_task_activity PRIMARY KEY (id),
+    CONSTRAINT fk_task_activity_task_id FOREIGN KEY (task_id) REFERENCES public.task(id) ON DELETE CASCADE,
+    CONSTRAINT fk_task_activity_user_id FOREIGN KEY (user_id) REFERENCES public.user(id) ON DELETE SET NULL
+);
+
+COMMIT;
@@ -0,0 +1,31 @@
+-- Adds task creators, watchers and in-app notifications. Existing tasks have no
+-- known creator and no watchers.
+-- create_tables.sql already contains these changes for new databases.
+BEGIN;
+
+ALTER TABLE public.task ADD COLUMN IF NOT EXISTS created_by bigint
+    CONSTRAINT fk_task_created_by REFERENCES public.user(id) ON DELETE SET NULL;
+
+CREATE TABLE IF NOT EXISTS public.task_watcher (
+  task_id uuid NOT NULL,
+  user_id bigint NOT NULL,
+  created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (task_id, user_id),
+  CONSTRAINT fk_task_watcher_task_id FOREIGN KEY(task_id) REFERENCES public.task(id) ON DELETE CASCADE,
+  CONSTRAINT fk_task_watcher_user_id FOREIGN KEY(user_id) REFERENCES public.user(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS public.notification (
+    id uuid NOT NULL DEFAULT public.uuid_generate_v7(),
+    user_id bigint NOT NULL,
+    type CHARACTER VARYING(30) NOT NULL,
+    message CHARACTER VARYING(255),
+    task_id uuid,
+    is_read boolean NOT NULL DEFAULT false,
+    created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT pk_notification PRIMARY KEY (id),
+    CONSTRAINT fk_notification_user_id FOREIGN KEY (user_id) REFERENCES public.user(id) ON DELETE CASCADE,
+    CONSTRAINT fk_notification_task_id FOREIGN KEY (task_id) REFERENCES public.task(id) ON DELETE CASCADE
+);
+
+COMMIT;
@@ -0,0 +1,15 @@
+-- Adds @mentions of group members in task descriptions. Existing descriptions
+-- are not scanned, so their mentions appear on the next edit.
+-- create_tables.sql already contains these changes for new databases.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS public.task_mention (
+  task_id uuid NOT NULL,
+  user_id bigint NOT NULL,
+  position int NOT NULL DEFAULT 0,
+  PRIMARY KEY (task_id, user_id),
+  CONSTRAINT fk_task_mention_task_id FOREIGN KEY(task_id) REFERENCES public.task(id) ON DELETE CASCADE,
+  CONSTRAINT fk_task_mention_user_id FOREIGN KEY(user_id) REFERENCES public.user(id) ON DELETE CASCADE
+);
+
+COMMIT;
@@ -0,0 +1,55 @@
+package activitylevel
+
+import (
+	"context"
+	"time"
+
+	"github.com/happYness-Project/taskManagementGolang/internal/taskcontainer/model"
+	"github.com/happYness-Project/taskManagementGolang/pkg/loggers"
+	"github.com/happYness-Project/taskManagementGolang/pkg/utils"
+)
+
+type ContainerActivity interface {
+	RefreshActivityLevels(since time.Time) (int64, error)
+}
+
+// Refresher periodically recomputes the activity level of every container over
+// the sliding model.ActivityWindow.
+type Refresher struct {
+	logger     *loggers.AppLogger
+	containers ContainerActivity
+	clock      utils.Clock
+	interval   time.Duration
+}
+
+func NewRefresher(logger *loggers.AppLogger, containers ContainerActivity, clock utils.Clock, interval time.Duration) *Refresher {
+	return &Refresher{logger: logger, containers: containers, clock: clock, interval: interval}
+}
+
+// Start runs the refresher until ctx is cancelled.
+func (a *Refresher) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(a.interval)
+		defer ticker.Stop()
+		for {
+			a.RunOnce()
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (a *Refresher) RunOnce() int64 {
+	changed, err := a.containers.RefreshActivityLevels(a.clock.Now().Add(-model.ActivityWindow))
+	if err != nil {
+		a.logger.Error().Err(err).Msg("Error occurred during RefreshActivityLevels")
+		return 0
+	}
+	if changed > 0 {
+		a.logger.Info().Int64("Changed", changed).Msg("Refreshed container activity levels")
+	}
+	return changed
+}
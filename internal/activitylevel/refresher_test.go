@@ -0,0 +1,54 @@
+package activitylevel
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/happYness-Project/taskManagementGolang/internal/taskcontainer/model"
+	"github.com/happYness-Project/taskManagementGolang/pkg/configs"
+	"github.com/happYness-Project/taskManagementGolang/pkg/loggers"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+type fakeContainers struct {
+	since   time.Time
+	changed int64
+	err     error
+}
+
+func (f *fakeContainers) RefreshActivityLevels(since time.Time) (int64, error) {
+	f.since = since
+	return f.changed, f.err
+}
+
+func TestRefresher_RunOnce(t *testing.T) {
+	logger := loggers.Setup(configs.Env{})
+	now := time.Date(2025, 1, 10, 3, 0, 0, 0, time.UTC)
+
+	t.Run("when run, Then activity is counted over the window ending at the clock time", func(t *testing.T) {
+		// Given
+		containers := &fakeContainers{changed: 4}
+		refresher := NewRefresher(logger, containers, &fakeClock{now: now}, time.Minute)
+
+		// When
+		changed := refresher.RunOnce()
+
+		// Then
+		assert.Equal(t, int64(4), changed)
+		assert.Equal(t, now.Add(-model.ActivityWindow), containers.since)
+	})
+
+	t.Run("when refreshing fails, Then nothing is reported as changed", func(t *testing.T) {
+		containers := &fakeContainers{err: errors.New("connection refused")}
+		refresher := NewRefresher(logger, containers, &fakeClock{now: now}, time.Minute)
+
+		assert.Equal(t, int64(0), refresher.RunOnce())
+	})
+}
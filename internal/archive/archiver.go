@@ -0,0 +1,54 @@
+package archive
+
+import (
+	"context"
+	"time"
+
+	"github.com/happYness-Project/taskManagementGolang/pkg/loggers"
+	"github.com/happYness-Project/taskManagementGolang/pkg/utils"
+)
+
+type TaskArchiver interface {
+	ArchiveCompletedTasks(now time.Time) (int64, error)
+}
+
+// Archiver periodically moves completed tasks to the archived state according
+// to each group's archive_completed_after_days setting.
+type Archiver struct {
+	logger   *loggers.AppLogger
+	tasks    TaskArchiver
+	clock    utils.Clock
+	interval time.Duration
+}
+
+func NewArchiver(logger *loggers.AppLogger, tasks TaskArchiver, clock utils.Clock, interval time.Duration) *Archiver {
+	return &Archiver{logger: logger, tasks: tasks, clock: clock, interval: interval}
+}
+
+// Start runs the archiver until ctx is cancelled.
+func (a *Archiver) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(a.interval)
+		defer ticker.Stop()
+		for {
+			a.RunOnce()
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (a *Archiver) RunOnce() int64 {
+	archived, err := a.tasks.ArchiveCompletedTasks(a.clock.Now())
+	if err != nil {
+		a.logger.Error().Err(err).Msg("Error occurred during ArchiveCompletedTasks")
+		return 0
+	}
+	if archived > 0 {
+		a.logger.Info().Int64("Archived", archived).Msg("Archived completed tasks")
+	}
+	return archived
+}
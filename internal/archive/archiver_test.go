@@ -0,0 +1,58 @@
+package archive
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/happYness-Project/taskManagementGolang/pkg/configs"
+	"github.com/happYness-Project/taskManagementGolang/pkg/loggers"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+type fakeTasks struct {
+	calledWith time.Time
+	archived   int64
+	err        error
+}
+
+func (f *fakeTasks) ArchiveCompletedTasks(now time.Time) (int64, error) {
+	f.calledWith = now
+	return f.archived, f.err
+}
+
+func TestArchiver_RunOnce(t *testing.T) {
+	logger := loggers.Setup(configs.Env{})
+	now := time.Date(2025, 1, 10, 3, 0, 0, 0, time.UTC)
+
+	t.Run("when run, Then completed tasks are archived as of the clock time", func(t *testing.T) {
+		// Given
+		tasks := &fakeTasks{archived: 3}
+		archiver := NewArchiver(logger, tasks, &fakeClock{now: now}, time.Hour)
+
+		// When
+		archived := archiver.RunOnce()
+
+		// Then
+		assert.Equal(t, int64(3), archived)
+		assert.Equal(t, now, tasks.calledWith)
+	})
+
+	t.Run("when archiving fails, Then nothing is reported as archived", func(t *testing.T) {
+		// Given
+		tasks := &fakeTasks{err: errors.New("connection refused")}
+		archiver := NewArchiver(logger, tasks, &fakeClock{now: now}, time.Hour)
+
+		// When
+		archived := archiver.RunOnce()
+
+		// Then
+		assert.Equal(t, int64(0), archived)
+	})
+}
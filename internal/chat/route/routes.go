@@ -1,11 +1,16 @@
 package route
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth"
+	"github.com/happYness-Project/taskManagementGolang/internal/chat/model"
 	chatRepo "github.com/happYness-Project/taskManagementGolang/internal/chat/repository"
+	containerRepo "github.com/happYness-Project/taskManagementGolang/internal/taskcontainer/repository"
+	userRepo "github.com/happYness-Project/taskManagementGolang/internal/user/repository"
 	usergroupRepo "github.com/happYness-Project/taskManagementGolang/internal/usergroup/repository"
 	"github.com/happYness-Project/taskManagementGolang/pkg/constants"
 	"github.com/happYness-Project/taskManagementGolang/pkg/loggers"
@@ -13,13 +18,15 @@ import (
 )
 
 type Handler struct {
-	logger    *loggers.AppLogger
-	chatRepo  chatRepo.ChatRepository
-	groupRepo usergroupRepo.UserGroupRepository
+	logger        *loggers.AppLogger
+	chatRepo      chatRepo.ChatRepository
+	groupRepo     usergroupRepo.UserGroupRepository
+	containerRepo containerRepo.ContainerRepository
+	userRepo      userRepo.UserRepository
 }
 
-func NewHandler(logger *loggers.AppLogger, repo chatRepo.ChatRepository, ugRepo usergroupRepo.UserGroupRepository) *Handler {
-	return &Handler{logger: logger, chatRepo: repo, groupRepo: ugRepo}
+func NewHandler(logger *loggers.AppLogger, repo chatRepo.ChatRepository, ugRepo usergroupRepo.UserGroupRepository, tcRepo containerRepo.ContainerRepository, uRepo userRepo.UserRepository) *Handler {
+	return &Handler{logger: logger, chatRepo: repo, groupRepo: ugRepo, containerRepo: tcRepo, userRepo: uRepo}
 }
 func (h *Handler) RegisterRoutes(router chi.Router) {
 	router.Route("/api/chats", func(r chi.Router) {
@@ -38,6 +45,12 @@ func (h *Handler) handleGetAllChats(w http.ResponseWriter, r *http.Request) {
 		response.InternalServerError(w, "Error occurred during getting all chats.")
 		return
 	}
+	chats, err = h.visibleChats(r, chats)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", ChatGetServerError).Msg("Error occurred during CanAccessContainer")
+		response.InternalServerError(w, "Error occurred during getting all chats.")
+		return
+	}
 	response.SuccessJson(w, chats, "successfully get chats", http.StatusOK)
 }
 
@@ -55,6 +68,17 @@ func (h *Handler) handleGetChatByUsergroupId(w http.ResponseWriter, r *http.Requ
 		response.InternalServerError(w, "Error occurred during getting all chats.")
 		return
 	}
+	ok, err := h.canSeeChat(h.currentUserId(r), *chats)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", ChatGetServerError).Msg("Error occurred during CanAccessContainer")
+		response.InternalServerError(w, "Error occurred during getting all chats.")
+		return
+	}
+	if !ok {
+		h.logger.Error().Str("ErrorCode", ChatGetNotFound).Msg("Chat belongs to a container restricted to other members")
+		response.NotFound(w, ChatGetNotFound)
+		return
+	}
 	response.SuccessJson(w, chats, "successfully get chats", http.StatusOK)
 }
 
@@ -77,6 +101,53 @@ func (h *Handler) handleGetChatById(w http.ResponseWriter, r *http.Request) {
 		response.NotFound(w, ChatGetNotFound)
 		return
 	}
+	ok, err := h.canSeeChat(h.currentUserId(r), *chat)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", ChatGetServerError).Msg("Error occurred during CanAccessContainer")
+		response.InternalServerError(w, "Error occurred during getting chat by ID.")
+		return
+	}
+	if !ok {
+		h.logger.Error().Str("ErrorCode", ChatGetNotFound).Msg("Chat belongs to a container restricted to other members")
+		response.NotFound(w, ChatGetNotFound)
+		return
+	}
 
 	response.SuccessJson(w, chat, "successfully get chat", http.StatusOK)
 }
+
+// visibleChats filters chats through canSeeChat for the caller, who is
+// resolved once for the whole list.
+func (h *Handler) visibleChats(r *http.Request, chats []model.Chat) ([]model.Chat, error) {
+	userId := h.currentUserId(r)
+	visible := []model.Chat{}
+	for _, chat := range chats {
+		ok, err := h.canSeeChat(userId, chat)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			visible = append(visible, chat)
+		}
+	}
+	return visible, nil
+}
+
+// canSeeChat hides the chats of containers restricted to other members.
+func (h *Handler) canSeeChat(userId int, chat model.Chat) (bool, error) {
+	if !chat.IsContainerChat() || chat.ContainerId == nil {
+		return true, nil
+	}
+	return h.containerRepo.CanAccessContainer(*chat.ContainerId, userId)
+}
+
+// currentUserId resolves the caller from the nameid claim of the token. An
+// unknown caller is 0 and only sees chats that are not restricted.
+func (h *Handler) currentUserId(r *http.Request) int {
+	_, claims, _ := jwtauth.FromContext(r.Context())
+	user, err := h.userRepo.GetUserByUserId(fmt.Sprintf("%v", claims["nameid"]))
+	if err != nil || user == nil {
+		return 0
+	}
+	return user.Id
+}
@@ -0,0 +1,89 @@
+package route
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth"
+	"github.com/happYness-Project/taskManagementGolang/internal/chat/model"
+	"github.com/happYness-Project/taskManagementGolang/internal/mocks"
+	userModel "github.com/happYness-Project/taskManagementGolang/internal/user/model"
+	"github.com/happYness-Project/taskManagementGolang/pkg/configs"
+	"github.com/happYness-Project/taskManagementGolang/pkg/loggers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChatHandler_Visibility(t *testing.T) {
+	logger := loggers.Setup(configs.Env{})
+	mockChatRepo := new(mocks.MockChatRepo)
+	mockContainerRepo := new(mocks.MockContainerRepo)
+	mockUserRepo := new(mocks.MockUserRepo)
+	handler := NewHandler(logger, mockChatRepo, new(mocks.MockUserGroupRepo), mockContainerRepo, mockUserRepo)
+	router := chi.NewRouter()
+	handler.RegisterRoutes(router)
+	mockUserRepo.On("GetUserByUserId", "user-a").Return(&userModel.User{Id: 1, UserId: "user-a"}, nil)
+	groupId := 7
+	party, shared := "party", "chores"
+	groupChat := model.Chat{Id: "group", Type: model.ChatTypeGroup, UserGroupId: &groupId}
+	partyChat := model.Chat{Id: "party-chat", Type: model.ChatTypeContainer, ContainerId: &party}
+	sharedChat := model.Chat{Id: "chores-chat", Type: model.ChatTypeContainer, ContainerId: &shared}
+	mockContainerRepo.On("CanAccessContainer", party, 1).Return(false, nil)
+	mockContainerRepo.On("CanAccessContainer", shared, 1).Return(true, nil)
+
+	t.Run("when get all chats, Then chats of containers restricted to other members are left out", func(t *testing.T) {
+		// Arrange
+		mockChatRepo.On("GetAllChats").Return([]model.Chat{groupChat, partyChat, sharedChat}, nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodGet, "/api/chats/", nil), "user-a")
+		rr := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var body struct {
+			Data []model.Chat `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		chats := body.Data
+		require.Len(t, chats, 2)
+		assert.Equal(t, groupChat.Id, chats[0].Id)
+		assert.Equal(t, sharedChat.Id, chats[1].Id)
+		mockUserRepo.AssertNumberOfCalls(t, "GetUserByUserId", 1)
+	})
+
+	t.Run("when get the chat of a container restricted to other members, Then return status code 404", func(t *testing.T) {
+		mockChatRepo.On("GetChatById", partyChat.Id).Return(&partyChat, nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodGet, "/api/chats/"+partyChat.Id, nil), "user-a")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("when get the chat of the group, Then return status code 200 and the chat", func(t *testing.T) {
+		mockChatRepo.On("GetChatByUserGroupId", groupId).Return(&groupChat, nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodGet, "/api/user-groups/7/chats", nil), "user-a")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var body struct {
+			Data model.Chat `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Equal(t, groupChat.Id, body.Data.Id)
+	})
+}
+
+func withCaller(t *testing.T, req *http.Request, userId string) *http.Request {
+	token, _, err := jwtauth.New("HS256", []byte("secret"), nil).Encode(map[string]interface{}{"nameid": userId})
+	require.NoError(t, err)
+	return req.WithContext(jwtauth.NewContext(req.Context(), token, nil))
+}
@@ -0,0 +1,38 @@
+package mocks
+
+import (
+	"github.com/happYness-Project/taskManagementGolang/internal/chat/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockChatRepo struct{ mock.Mock }
+
+// GetAllChats implements repository.ChatRepository.
+func (m *MockChatRepo) GetAllChats() ([]model.Chat, error) {
+	args := m.Called()
+	return args.Get(0).([]model.Chat), args.Error(1)
+}
+
+// GetChatByUserGroupId implements repository.ChatRepository.
+func (m *MockChatRepo) GetChatByUserGroupId(userGroupId int) (*model.Chat, error) {
+	args := m.Called(userGroupId)
+	return args.Get(0).(*model.Chat), args.Error(1)
+}
+
+// GetChatById implements repository.ChatRepository.
+func (m *MockChatRepo) GetChatById(id string) (*model.Chat, error) {
+	args := m.Called(id)
+	return args.Get(0).(*model.Chat), args.Error(1)
+}
+
+// CreateChat implements repository.ChatRepository.
+func (m *MockChatRepo) CreateChat(chat model.Chat) error {
+	args := m.Called(chat)
+	return args.Error(0)
+}
+
+// DeleteChat implements repository.ChatRepository.
+func (m *MockChatRepo) DeleteChat(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
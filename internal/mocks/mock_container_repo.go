@@ -1,15 +1,19 @@
 package mocks
 
 import (
+	"time"
+
+	chatModel "github.com/happYness-Project/taskManagementGolang/internal/chat/model"
 	"github.com/happYness-Project/taskManagementGolang/internal/taskcontainer/model"
+	"github.com/happYness-Project/taskManagementGolang/internal/taskcontainer/repository"
 	"github.com/stretchr/testify/mock"
 )
 
 type MockContainerRepo struct{ mock.Mock }
 
 // AllTaskContainers implements repository.ContainerRepository.
-func (m *MockContainerRepo) AllTaskContainers() ([]*model.TaskContainer, error) {
-	args := m.Called()
+func (m *MockContainerRepo) AllTaskContainers(userId int) ([]*model.TaskContainer, error) {
+	args := m.Called(userId)
 	return args.Get(0).([]*model.TaskContainer), args.Error(1)
 }
 
@@ -20,17 +24,71 @@ func (m *MockContainerRepo) GetById(id string) (*model.TaskContainer, error) {
 }
 
 // GetContainersByGroupId implements repository.ContainerRepository.
-func (m *MockContainerRepo) GetContainersByGroupId(groupId int) ([]model.TaskContainer, error) {
-	args := m.Called(groupId)
+func (m *MockContainerRepo) GetContainersByGroupId(groupId int, filter repository.ContainerFilter) ([]model.TaskContainer, error) {
+	args := m.Called(groupId, filter)
 	return args.Get(0).([]model.TaskContainer), args.Error(1)
 }
 
 // CreateContainer implements repository.ContainerRepository.
-func (m *MockContainerRepo) CreateContainer(container model.TaskContainer) error {
+func (m *MockContainerRepo) CreateContainer(container model.TaskContainer, createdBy int, chat chatModel.Chat, participants []chatModel.ChatParticipant) error {
+	args := m.Called(container, createdBy, chat, participants)
+	return args.Error(0)
+}
+
+// UpdateContainer implements repository.ContainerRepository.
+func (m *MockContainerRepo) UpdateContainer(container model.TaskContainer) error {
 	args := m.Called(container)
 	return args.Error(0)
 }
 
+// SetContainerActive implements repository.ContainerRepository.
+func (m *MockContainerRepo) SetContainerActive(id string, active bool) error {
+	args := m.Called(id, active)
+	return args.Error(0)
+}
+
+// CanAccessContainer implements repository.ContainerRepository.
+func (m *MockContainerRepo) CanAccessContainer(id string, userId int) (bool, error) {
+	args := m.Called(id, userId)
+	return args.Bool(0), args.Error(1)
+}
+
+// GetContainerMembers implements repository.ContainerRepository.
+func (m *MockContainerRepo) GetContainerMembers(id string) ([]int, error) {
+	args := m.Called(id)
+	return args.Get(0).([]int), args.Error(1)
+}
+
+// AddContainerMember implements repository.ContainerRepository.
+func (m *MockContainerRepo) AddContainerMember(id string, userId int) error {
+	args := m.Called(id, userId)
+	return args.Error(0)
+}
+
+// RemoveContainerMember implements repository.ContainerRepository.
+func (m *MockContainerRepo) RemoveContainerMember(id string, userId int) error {
+	args := m.Called(id, userId)
+	return args.Error(0)
+}
+
+// ReplaceContainerOrders implements repository.ContainerRepository.
+func (m *MockContainerRepo) ReplaceContainerOrders(userId int, groupId int, orders []model.ContainerOrder) error {
+	args := m.Called(userId, groupId, orders)
+	return args.Error(0)
+}
+
+// DuplicateContainer implements repository.ContainerRepository.
+func (m *MockContainerRepo) DuplicateContainer(sourceId string, duplicate model.TaskContainer, options model.DuplicateOptions, createdBy int, now time.Time, chat chatModel.Chat, participants []chatModel.ChatParticipant) (int, error) {
+	args := m.Called(sourceId, duplicate, options, createdBy, now, chat, participants)
+	return args.Int(0), args.Error(1)
+}
+
+// RefreshActivityLevels implements repository.ContainerRepository.
+func (m *MockContainerRepo) RefreshActivityLevels(since time.Time) (int64, error) {
+	args := m.Called(since)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 // DeleteContainer implements repository.ContainerRepository.
 func (m *MockContainerRepo) DeleteContainer(id string) error {
 	args := m.Called(id)
@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	"time"
+
 	"github.com/happYness-Project/taskManagementGolang/internal/usergroup/model"
 	"github.com/stretchr/testify/mock"
 )
@@ -41,7 +43,60 @@ func (m *MockUserGroupRepo) RemoveUserFromUserGroup(groupId int, userId int) err
 	args := m.Called(groupId, userId)
 	return args.Error(0)
 }
+func (m *MockUserGroupRepo) IsUserInGroup(groupId int, userId int) (bool, error) {
+	args := m.Called(groupId, userId)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockUserGroupRepo) IsGroupAdmin(groupId int, userId int) (bool, error) {
+	args := m.Called(groupId, userId)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockUserGroupRepo) GetMemberRole(groupId int, userId int) (string, error) {
+	args := m.Called(groupId, userId)
+	return args.String(0), args.Error(1)
+}
+func (m *MockUserGroupRepo) CountOwners(groupId int) (int, error) {
+	args := m.Called(groupId)
+	return args.Int(0), args.Error(1)
+}
+func (m *MockUserGroupRepo) UpdateMemberRole(groupId int, userId int, role string, activity model.GroupActivity) error {
+	args := m.Called(groupId, userId, role, activity)
+	return args.Error(0)
+}
+func (m *MockUserGroupRepo) UpdateGroup(ug model.UserGroup, activity model.GroupActivity) error {
+	args := m.Called(ug, activity)
+	return args.Error(0)
+}
+func (m *MockUserGroupRepo) GetGroupActivities(groupId int) ([]model.GroupActivity, error) {
+	args := m.Called(groupId)
+	return args.Get(0).([]model.GroupActivity), args.Error(1)
+}
+func (m *MockUserGroupRepo) UpdateArchiveSetting(groupId int, days *int) error {
+	args := m.Called(groupId, days)
+	return args.Error(0)
+}
 func (m *MockUserGroupRepo) CreateGroupWithUsers(ug model.UserGroup, userId int) (int, error) {
 	args := m.Called(ug, userId)
 	return args.Get(0).(int), args.Error(0)
 }
+func (m *MockUserGroupRepo) CreateInvitation(invitation model.Invitation) (string, error) {
+	args := m.Called(invitation)
+	return args.String(0), args.Error(1)
+}
+func (m *MockUserGroupRepo) GetInvitationByToken(token string) (*model.Invitation, error) {
+	args := m.Called(token)
+	invitation, _ := args.Get(0).(*model.Invitation)
+	return invitation, args.Error(1)
+}
+func (m *MockUserGroupRepo) GetOutstandingInvitations(groupId int, now time.Time) ([]model.Invitation, error) {
+	args := m.Called(groupId, now)
+	return args.Get(0).([]model.Invitation), args.Error(1)
+}
+func (m *MockUserGroupRepo) RevokeInvitation(groupId int, id string, now time.Time) error {
+	args := m.Called(groupId, id, now)
+	return args.Error(0)
+}
+func (m *MockUserGroupRepo) AcceptInvitation(id string, groupId int, userId int, now time.Time) (bool, error) {
+	args := m.Called(id, groupId, userId, now)
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,44 @@
+package mocks
+
+import (
+	"github.com/happYness-Project/taskManagementGolang/internal/taskcontainer/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSectionRepo struct{ mock.Mock }
+
+// GetSections implements repository.SectionRepository.
+func (m *MockSectionRepo) GetSections(containerId string) ([]model.ContainerSection, error) {
+	args := m.Called(containerId)
+	return args.Get(0).([]model.ContainerSection), args.Error(1)
+}
+
+// GetSection implements repository.SectionRepository.
+func (m *MockSectionRepo) GetSection(containerId string, sectionId string) (*model.ContainerSection, error) {
+	args := m.Called(containerId, sectionId)
+	return args.Get(0).(*model.ContainerSection), args.Error(1)
+}
+
+// CreateSection implements repository.SectionRepository.
+func (m *MockSectionRepo) CreateSection(section model.ContainerSection) (model.ContainerSection, error) {
+	args := m.Called(section)
+	return args.Get(0).(model.ContainerSection), args.Error(1)
+}
+
+// UpdateSection implements repository.SectionRepository.
+func (m *MockSectionRepo) UpdateSection(section model.ContainerSection) error {
+	args := m.Called(section)
+	return args.Error(0)
+}
+
+// UpdateSectionPositions implements repository.SectionRepository.
+func (m *MockSectionRepo) UpdateSectionPositions(containerId string, sections []model.ContainerSection) error {
+	args := m.Called(containerId, sections)
+	return args.Error(0)
+}
+
+// DeleteSection implements repository.SectionRepository.
+func (m *MockSectionRepo) DeleteSection(containerId string, sectionId string) error {
+	args := m.Called(containerId, sectionId)
+	return args.Error(0)
+}
@@ -58,8 +58,8 @@ func (m *MockUserRepo) UpdateUser(user userModel.User) error {
 
 type TaskRepo struct{ mock.Mock }
 
-func (m *TaskRepo) GetAllTasks() ([]model.Task, error) {
-	args := m.Called()
+func (m *TaskRepo) GetAllTasks(viewerId int) ([]model.Task, error) {
+	args := m.Called(viewerId)
 	return args.Get(0).([]model.Task), args.Error(1)
 }
 
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+type Notification struct {
+	Id        string    `json:"id"`
+	UserId    int       `json:"user_id"`
+	Type      string    `json:"type"`
+	Message   string    `json:"message"`
+	TaskId    *string   `json:"task_id,omitempty"`
+	IsRead    bool      `json:"is_read"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+const (
+	TypeTaskUpdated   = "task_updated"
+	TypeTaskCompleted = "task_completed"
+	TypeTaskReopened  = "task_reopened"
+	TypeTaskMentioned = "task_mentioned"
+	TypeTaskReminder  = "task_reminder"
+)
+
+func NewNotification(userId int, notificationType string, message string, taskId *string) (*Notification, error) {
+	if userId <= 0 {
+		return nil, errors.New("notification must have a recipient")
+	}
+	if notificationType == "" {
+		return nil, errors.New("notification type cannot be empty")
+	}
+	return &Notification{
+		UserId:    userId,
+		Type:      notificationType,
+		Message:   message,
+		TaskId:    taskId,
+		IsRead:    false,
+		CreatedAt: time.Now(),
+	}, nil
+}
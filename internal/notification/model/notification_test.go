@@ -0,0 +1,45 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNotification(t *testing.T) {
+	t.Run("when creating notification with valid data, Then return unread notification", func(t *testing.T) {
+		// Given
+		taskId := "task-1"
+
+		// When
+		notification, err := NewNotification(1, TypeTaskUpdated, "task has been updated", &taskId)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, 1, notification.UserId)
+		assert.Equal(t, TypeTaskUpdated, notification.Type)
+		assert.Equal(t, &taskId, notification.TaskId)
+		assert.False(t, notification.IsRead)
+		assert.NotZero(t, notification.CreatedAt)
+	})
+
+	t.Run("when creating notification without recipient, Then return error", func(t *testing.T) {
+		// When
+		notification, err := NewNotification(0, TypeTaskUpdated, "", nil)
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, notification)
+		assert.Contains(t, err.Error(), "notification must have a recipient")
+	})
+
+	t.Run("when creating notification without type, Then return error", func(t *testing.T) {
+		// When
+		notification, err := NewNotification(1, "", "", nil)
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, notification)
+	})
+}
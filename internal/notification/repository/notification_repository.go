@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/happYness-Project/taskManagementGolang/internal/notification/model"
+)
+
+type NotificationRepository interface {
+	CreateNotification(notification model.Notification) error
+	GetNotificationsByUserId(userId int) ([]model.Notification, error)
+	MarkAsRead(id string, userId int) error
+}
+
+type NotificationRepo struct {
+	DB *sql.DB
+}
+
+func NewNotificationRepository(db *sql.DB) *NotificationRepo {
+	return &NotificationRepo{DB: db}
+}
+
+func (m *NotificationRepo) CreateNotification(n model.Notification) error {
+	_, err := m.DB.Exec(sqlCreateNotification, n.UserId, n.Type, n.Message, n.TaskId, n.IsRead, n.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("unable to insert into notification table : %w", err)
+	}
+	return nil
+}
+
+func (m *NotificationRepo) GetNotificationsByUserId(userId int) ([]model.Notification, error) {
+	rows, err := m.DB.Query(sqlGetNotificationsByUserId, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notifications := []model.Notification{}
+	for rows.Next() {
+		var n model.Notification
+		err := rows.Scan(&n.Id, &n.UserId, &n.Type, &n.Message, &n.TaskId, &n.IsRead, &n.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, n)
+	}
+	return notifications, nil
+}
+
+func (m *NotificationRepo) MarkAsRead(id string, userId int) error {
+	result, err := m.DB.Exec(sqlMarkNotificationAsRead, id, userId)
+	if err != nil {
+		return fmt.Errorf("unable to update notification : %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+package repository
+
+const (
+	sqlCreateNotification = `INSERT INTO public.notification(user_id, type, message, task_id, is_read, created_at)
+							VALUES ($1, $2, $3, $4, $5, $6)`
+	sqlGetNotificationsByUserId = `SELECT id, user_id, type, message, task_id, is_read, created_at
+									FROM public.notification
+									WHERE user_id = $1
+									ORDER BY created_at DESC`
+	sqlMarkNotificationAsRead = `UPDATE public.notification SET is_read = true WHERE id = $1 AND user_id = $2`
+)
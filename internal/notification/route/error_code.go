@@ -0,0 +1,10 @@
+package route
+
+const prefix = "notifications_"
+
+const (
+	NotificationGetServerError = prefix + "get_server_error"
+	NotificationNotFound       = prefix + "get_not_found"
+	NotificationUserNotFound   = prefix + "user_get_not_found"
+	NotificationUpdateError    = prefix + "update_server_error"
+)
@@ -0,0 +1,73 @@
+package route
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth"
+	notificationRepo "github.com/happYness-Project/taskManagementGolang/internal/notification/repository"
+	userRepo "github.com/happYness-Project/taskManagementGolang/internal/user/repository"
+	"github.com/happYness-Project/taskManagementGolang/pkg/loggers"
+	"github.com/happYness-Project/taskManagementGolang/pkg/response"
+)
+
+type Handler struct {
+	logger           *loggers.AppLogger
+	notificationRepo notificationRepo.NotificationRepository
+	userRepo         userRepo.UserRepository
+}
+
+func NewHandler(logger *loggers.AppLogger, repo notificationRepo.NotificationRepository, userRepo userRepo.UserRepository) *Handler {
+	return &Handler{logger: logger, notificationRepo: repo, userRepo: userRepo}
+}
+
+func (h *Handler) RegisterRoutes(router chi.Router) {
+	router.Route("/api/notifications", func(r chi.Router) {
+		r.Get("/", h.handleGetMyNotifications)
+		r.Patch("/{notificationID}/read", h.handleMarkAsRead)
+	})
+}
+
+func (h *Handler) handleGetMyNotifications(w http.ResponseWriter, r *http.Request) {
+	_, claims, _ := jwtauth.FromContext(r.Context())
+	user, err := h.userRepo.GetUserByUserId(fmt.Sprintf("%v", claims["nameid"]))
+	if err != nil || user == nil {
+		h.logger.Error().Err(err).Str("ErrorCode", NotificationUserNotFound).Msg("Not able to find user from token")
+		response.NotFound(w, NotificationUserNotFound, "Not able to find a user")
+		return
+	}
+
+	notifications, err := h.notificationRepo.GetNotificationsByUserId(user.Id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", NotificationGetServerError).Msg("Error occurred during GetNotificationsByUserId")
+		response.InternalServerError(w, "Error occurred during getting notifications.")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusOK, notifications)
+}
+
+func (h *Handler) handleMarkAsRead(w http.ResponseWriter, r *http.Request) {
+	_, claims, _ := jwtauth.FromContext(r.Context())
+	user, err := h.userRepo.GetUserByUserId(fmt.Sprintf("%v", claims["nameid"]))
+	if err != nil || user == nil {
+		h.logger.Error().Err(err).Str("ErrorCode", NotificationUserNotFound).Msg("Not able to find user from token")
+		response.NotFound(w, NotificationUserNotFound, "Not able to find a user")
+		return
+	}
+
+	err = h.notificationRepo.MarkAsRead(chi.URLParam(r, "notificationID"), user.Id)
+	if errors.Is(err, sql.ErrNoRows) {
+		h.logger.Error().Err(err).Str("ErrorCode", NotificationNotFound).Msg("Notification not found")
+		response.NotFound(w, NotificationNotFound, "Notification does not exist")
+		return
+	}
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", NotificationUpdateError).Msg("Error occurred during MarkAsRead")
+		response.InternalServerError(w, "Error occurred during updating notification.")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusOK, "notification is marked as read.")
+}
@@ -0,0 +1,185 @@
+package reminder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/smtp"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	notificationModel "github.com/happYness-Project/taskManagementGolang/internal/notification/model"
+	notificationRepo "github.com/happYness-Project/taskManagementGolang/internal/notification/repository"
+	"github.com/happYness-Project/taskManagementGolang/internal/task/model"
+)
+
+// Notifier delivers a fired reminder over one channel.
+type Notifier interface {
+	Notify(ctx context.Context, reminder model.TaskReminder, task model.Task) error
+}
+
+func reminderMessage(task model.Task) string {
+	if due, ok := task.DueStart(); ok {
+		if task.IsAllDay() {
+			return fmt.Sprintf("Reminder: task '%s' is due on %s.", task.TaskName, task.DueDate)
+		}
+		return fmt.Sprintf("Reminder: task '%s' is due at %s.", task.TaskName, due.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("Reminder: task '%s'.", task.TaskName)
+}
+
+type InAppNotifier struct {
+	notificationRepo notificationRepo.NotificationRepository
+}
+
+func NewInAppNotifier(nRepo notificationRepo.NotificationRepository) *InAppNotifier {
+	return &InAppNotifier{notificationRepo: nRepo}
+}
+
+func (n *InAppNotifier) Notify(ctx context.Context, reminder model.TaskReminder, task model.Task) error {
+	notification, err := notificationModel.NewNotification(reminder.UserId, notificationModel.TypeTaskReminder, reminderMessage(task), &task.TaskId)
+	if err != nil {
+		return err
+	}
+	return n.notificationRepo.CreateNotification(*notification)
+}
+
+// ErrWebhookTargetNotAllowed is returned for webhook targets that are not https
+// or that resolve to a loopback, private, link-local or metadata address.
+var ErrWebhookTargetNotAllowed = errors.New("webhook target is not allowed")
+
+// blockedPrefixes are internal ranges the netip predicates do not cover,
+// including the metadata services of clouds outside the link-local range.
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+}
+
+type WebhookNotifier struct {
+	client *http.Client
+}
+
+// NewWebhookNotifier posts reminders to https webhooks. A nil client gets one
+// that checks every address it connects to after DNS resolution, so redirects
+// and rebinding cannot reach internal hosts either.
+func NewWebhookNotifier(client *http.Client) *WebhookNotifier {
+	if client == nil {
+		client = newWebhookClient()
+	}
+	return &WebhookNotifier{client: client}
+}
+
+func newWebhookClient() *http.Client {
+	dialer := &net.Dialer{Timeout: 5 * time.Second, Control: checkWebhookAddress}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if req.URL.Scheme != "https" {
+				return ErrWebhookTargetNotAllowed
+			}
+			if len(via) >= 5 {
+				return errors.New("webhook stopped after 5 redirects")
+			}
+			return nil
+		},
+	}
+}
+
+func checkWebhookAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil || !isPublicAddr(ip) {
+		return fmt.Errorf("%w: %s", ErrWebhookTargetNotAllowed, host)
+	}
+	return nil
+}
+
+func isPublicAddr(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+type webhookPayload struct {
+	ReminderId string     `json:"reminder_id"`
+	Message    string     `json:"message"`
+	Task       model.Task `json:"task"`
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, reminder model.TaskReminder, task model.Task) error {
+	body, err := json.Marshal(webhookPayload{ReminderId: reminder.Id, Message: reminderMessage(task), Task: task})
+	if err != nil {
+		return err
+	}
+	target, err := url.Parse(reminder.Target)
+	if err != nil || target.Scheme != "https" {
+		return ErrWebhookTargetNotAllowed
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", res.StatusCode)
+	}
+	return nil
+}
+
+type EmailConfig struct {
+	Host     string
+	Port     string
+	User     string
+	Password string
+	From     string
+}
+
+type EmailNotifier struct {
+	config   EmailConfig
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func NewEmailNotifier(config EmailConfig) *EmailNotifier {
+	return &EmailNotifier{config: config, sendMail: smtp.SendMail}
+}
+
+func (n *EmailNotifier) Notify(ctx context.Context, reminder model.TaskReminder, task model.Task) error {
+	var auth smtp.Auth
+	if n.config.User != "" {
+		auth = smtp.PlainAuth("", n.config.User, n.config.Password, n.config.Host)
+	}
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", n.config.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", reminder.Target)
+	fmt.Fprintf(&msg, "Subject: Reminder: %s\r\n\r\n", task.TaskName)
+	msg.WriteString(reminderMessage(task) + "\r\n")
+	return n.sendMail(n.config.Host+":"+n.config.Port, auth, n.config.From, []string{reminder.Target}, []byte(msg.String()))
+}
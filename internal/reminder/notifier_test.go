@@ -0,0 +1,72 @@
+package reminder
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/happYness-Project/taskManagementGolang/internal/task/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	task := model.Task{TaskId: "task-1", TaskName: "Dish Wash"}
+
+	t.Run("when target is not https, Then it is not requested", func(t *testing.T) {
+		// Given
+		called := false
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { called = true }))
+		defer server.Close()
+		notifier := NewWebhookNotifier(server.Client())
+
+		// When
+		err := notifier.Notify(context.Background(), model.TaskReminder{Id: "r-1", Target: server.URL}, task)
+
+		// Then
+		assert.ErrorIs(t, err, ErrWebhookTargetNotAllowed)
+		assert.False(t, called)
+	})
+
+	t.Run("when target resolves to a loopback address, Then the connection is refused", func(t *testing.T) {
+		// Given
+		called := false
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { called = true }))
+		defer server.Close()
+		notifier := NewWebhookNotifier(nil)
+
+		// When
+		err := notifier.Notify(context.Background(), model.TaskReminder{Id: "r-1", Target: server.URL}, task)
+
+		// Then
+		assert.ErrorIs(t, err, ErrWebhookTargetNotAllowed)
+		assert.False(t, called)
+	})
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	tests := []struct {
+		addr   string
+		public bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:4700::1111", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00:ec2::254", false},
+		{"100.100.100.200", false},
+		{"0.0.0.0", false},
+		{"::ffff:127.0.0.1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			assert.Equal(t, tt.public, isPublicAddr(netip.MustParseAddr(tt.addr)))
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/happYness-Project/taskManagementGolang/internal/task/model"
+	"github.com/happYness-Project/taskManagementGolang/pkg/loggers"
+	"github.com/happYness-Project/taskManagementGolang/pkg/utils"
+)
+
+const batchSize = 100
+
+type ReminderStore interface {
+	GetPendingReminders(now time.Time, limit int) ([]model.TaskReminder, error)
+	ClaimReminder(id string, firedAt time.Time) (bool, error)
+	ReleaseReminder(reminder model.TaskReminder) error
+	FailReminder(reminder model.TaskReminder) error
+}
+
+type TaskGetter interface {
+	GetTaskById(id string) (*model.Task, error)
+}
+
+// Scheduler fires due reminders. Pending reminders live in Postgres, so a
+// restarted server picks up the ones it missed on its first run.
+type Scheduler struct {
+	logger    *loggers.AppLogger
+	store     ReminderStore
+	tasks     TaskGetter
+	notifiers map[string]Notifier
+	clock     utils.Clock
+	interval  time.Duration
+}
+
+func NewScheduler(logger *loggers.AppLogger, store ReminderStore, tasks TaskGetter, notifiers map[string]Notifier, clock utils.Clock, interval time.Duration) *Scheduler {
+	return &Scheduler{
+		logger:    logger,
+		store:     store,
+		tasks:     tasks,
+		notifiers: notifiers,
+		clock:     clock,
+		interval:  interval,
+	}
+}
+
+// Start runs the scheduler until ctx is cancelled.
+func (s *Scheduler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			s.RunDue(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// RunDue fires every pending reminder that is due and returns how many were
+// delivered. A reminder whose delivery fails is retried with backoff until it
+// runs out of attempts and is marked as failed.
+func (s *Scheduler) RunDue(ctx context.Context) int {
+	now := s.clock.Now()
+	reminders, err := s.store.GetPendingReminders(now, batchSize)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error occurred during GetPendingReminders")
+		return 0
+	}
+	fired := 0
+	for _, reminder := range reminders {
+		if s.fire(ctx, reminder, now) {
+			fired++
+		}
+	}
+	return fired
+}
+
+func (s *Scheduler) fire(ctx context.Context, reminder model.TaskReminder, now time.Time) bool {
+	claimed, err := s.store.ClaimReminder(reminder.Id, now)
+	if err != nil || !claimed {
+		return false
+	}
+	task, err := s.tasks.GetTaskById(reminder.TaskId)
+	if err != nil {
+		s.logger.Error().Err(err).Str("ReminderId", reminder.Id).Msg("Error occurred during GetTaskById for reminder")
+		s.retry(reminder, now)
+		return false
+	}
+	// Reminders of removed or completed tasks are dropped.
+	if task == nil || task.IsCompleted {
+		return false
+	}
+	notifier, ok := s.notifiers[reminder.Channel]
+	if !ok {
+		s.logger.Error().Str("ReminderId", reminder.Id).Str("Channel", reminder.Channel).Msg("No notifier configured for reminder channel")
+		return false
+	}
+	if err := notifier.Notify(ctx, reminder, *task); err != nil {
+		s.logger.Error().Err(err).Str("ReminderId", reminder.Id).Msg("Error occurred during sending reminder")
+		if errors.Is(err, ErrWebhookTargetNotAllowed) {
+			reminder.Attempts++
+			reminder.MarkFailed(now)
+			s.fail(reminder)
+			return false
+		}
+		s.retry(reminder, now)
+		return false
+	}
+	return true
+}
+
+func (s *Scheduler) retry(reminder model.TaskReminder, now time.Time) {
+	reminder.RecordFailure(now)
+	if reminder.HasFailed() {
+		s.fail(reminder)
+		return
+	}
+	if err := s.store.ReleaseReminder(reminder); err != nil {
+		s.logger.Error().Err(err).Str("ReminderId", reminder.Id).Msg("Error occurred during ReleaseReminder")
+	}
+}
+
+func (s *Scheduler) fail(reminder model.TaskReminder) {
+	if err := s.store.FailReminder(reminder); err != nil {
+		s.logger.Error().Err(err).Str("ReminderId", reminder.Id).Msg("Error occurred during FailReminder")
+	}
+}
@@ -0,0 +1,231 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/happYness-Project/taskManagementGolang/internal/task/model"
+	"github.com/happYness-Project/taskManagementGolang/pkg/configs"
+	"github.com/happYness-Project/taskManagementGolang/pkg/loggers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+type fakeStore struct {
+	reminders []model.TaskReminder
+	released  []string
+	failed    []string
+}
+
+func (s *fakeStore) GetPendingReminders(now time.Time, limit int) ([]model.TaskReminder, error) {
+	pending := []model.TaskReminder{}
+	for _, r := range s.reminders {
+		due := r.RemindAt
+		if r.NextAttemptAt != nil {
+			due = *r.NextAttemptAt
+		}
+		if r.FiredAt == nil && r.FailedAt == nil && !due.After(now) {
+			pending = append(pending, r)
+		}
+	}
+	return pending, nil
+}
+
+func (s *fakeStore) ClaimReminder(id string, firedAt time.Time) (bool, error) {
+	for i := range s.reminders {
+		if s.reminders[i].Id == id && s.reminders[i].FiredAt == nil {
+			s.reminders[i].FiredAt = &firedAt
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *fakeStore) ReleaseReminder(reminder model.TaskReminder) error {
+	for i := range s.reminders {
+		if s.reminders[i].Id == reminder.Id {
+			s.reminders[i].FiredAt = nil
+			s.reminders[i].Attempts = reminder.Attempts
+			s.reminders[i].NextAttemptAt = reminder.NextAttemptAt
+		}
+	}
+	s.released = append(s.released, reminder.Id)
+	return nil
+}
+
+func (s *fakeStore) FailReminder(reminder model.TaskReminder) error {
+	for i := range s.reminders {
+		if s.reminders[i].Id == reminder.Id {
+			s.reminders[i].Attempts = reminder.Attempts
+			s.reminders[i].FailedAt = reminder.FailedAt
+		}
+	}
+	s.failed = append(s.failed, reminder.Id)
+	return nil
+}
+
+type fakeTasks map[string]*model.Task
+
+func (f fakeTasks) GetTaskById(id string) (*model.Task, error) { return f[id], nil }
+
+type fakeNotifier struct {
+	sent []model.TaskReminder
+	err  error
+}
+
+func (n *fakeNotifier) Notify(ctx context.Context, reminder model.TaskReminder, task model.Task) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.sent = append(n.sent, reminder)
+	return nil
+}
+
+func TestScheduler_RunDue(t *testing.T) {
+	logger := loggers.Setup(configs.Env{})
+	start := time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)
+	tasks := fakeTasks{
+		"task-1": {TaskId: "task-1", TaskName: "Dish Wash"},
+		"task-2": {TaskId: "task-2", TaskName: "Laundry", IsCompleted: true},
+	}
+
+	t.Run("when reminder is due, Then it is sent once", func(t *testing.T) {
+		// Given
+		clock := &fakeClock{now: start}
+		store := &fakeStore{reminders: []model.TaskReminder{
+			{Id: "r-1", TaskId: "task-1", UserId: 1, Channel: model.ReminderChannelInApp, RemindAt: start.Add(time.Hour)},
+		}}
+		notifier := &fakeNotifier{}
+		scheduler := NewScheduler(logger, store, tasks, map[string]Notifier{model.ReminderChannelInApp: notifier}, clock, time.Minute)
+
+		// When
+		beforeDue := scheduler.RunDue(context.Background())
+		clock.now = start.Add(time.Hour)
+		atDue := scheduler.RunDue(context.Background())
+		afterDue := scheduler.RunDue(context.Background())
+
+		// Then
+		assert.Equal(t, 0, beforeDue)
+		assert.Equal(t, 1, atDue)
+		assert.Equal(t, 0, afterDue)
+		require.Len(t, notifier.sent, 1)
+		assert.Equal(t, "r-1", notifier.sent[0].Id)
+	})
+
+	t.Run("when reminder was missed while server was down, Then it is sent on first run", func(t *testing.T) {
+		// Given
+		clock := &fakeClock{now: start.Add(24 * time.Hour)}
+		store := &fakeStore{reminders: []model.TaskReminder{
+			{Id: "r-1", TaskId: "task-1", UserId: 1, Channel: model.ReminderChannelInApp, RemindAt: start},
+		}}
+		notifier := &fakeNotifier{}
+		scheduler := NewScheduler(logger, store, tasks, map[string]Notifier{model.ReminderChannelInApp: notifier}, clock, time.Minute)
+
+		// When
+		fired := scheduler.RunDue(context.Background())
+
+		// Then
+		assert.Equal(t, 1, fired)
+	})
+
+	t.Run("when delivery fails, Then reminder is released for retry", func(t *testing.T) {
+		// Given
+		clock := &fakeClock{now: start}
+		store := &fakeStore{reminders: []model.TaskReminder{
+			{Id: "r-1", TaskId: "task-1", UserId: 1, Channel: model.ReminderChannelWebhook, RemindAt: start},
+		}}
+		notifier := &fakeNotifier{err: errors.New("connection refused")}
+		scheduler := NewScheduler(logger, store, tasks, map[string]Notifier{model.ReminderChannelWebhook: notifier}, clock, time.Minute)
+
+		// When
+		fired := scheduler.RunDue(context.Background())
+
+		// Then
+		assert.Equal(t, 0, fired)
+		assert.Equal(t, []string{"r-1"}, store.released)
+		assert.Nil(t, store.reminders[0].FiredAt)
+		assert.Equal(t, 1, store.reminders[0].Attempts)
+		require.NotNil(t, store.reminders[0].NextAttemptAt)
+		assert.Equal(t, start.Add(model.ReminderRetryDelay), *store.reminders[0].NextAttemptAt)
+	})
+
+	t.Run("when delivery keeps failing, Then retries back off and the reminder is marked failed", func(t *testing.T) {
+		// Given
+		clock := &fakeClock{now: start}
+		store := &fakeStore{reminders: []model.TaskReminder{
+			{Id: "r-1", TaskId: "task-1", UserId: 1, Channel: model.ReminderChannelWebhook, RemindAt: start},
+		}}
+		notifier := &fakeNotifier{err: errors.New("connection refused")}
+		scheduler := NewScheduler(logger, store, tasks, map[string]Notifier{model.ReminderChannelWebhook: notifier}, clock, time.Minute)
+
+		// When
+		beforeRetry := 0
+		for attempt := 0; attempt < model.MaxReminderAttempts; attempt++ {
+			scheduler.RunDue(context.Background())
+			clock.now = clock.now.Add(time.Second)
+			beforeRetry += scheduler.RunDue(context.Background())
+			if store.reminders[0].FailedAt != nil {
+				break
+			}
+			clock.now = *store.reminders[0].NextAttemptAt
+		}
+
+		// Then
+		assert.Equal(t, 0, beforeRetry)
+		assert.Len(t, store.released, model.MaxReminderAttempts-1)
+		assert.Equal(t, []string{"r-1"}, store.failed)
+		assert.Equal(t, model.MaxReminderAttempts, store.reminders[0].Attempts)
+		assert.Empty(t, mustPending(t, store, clock.now.Add(24*time.Hour)))
+	})
+
+	t.Run("when webhook target is not allowed, Then reminder is marked failed without retry", func(t *testing.T) {
+		// Given
+		clock := &fakeClock{now: start}
+		store := &fakeStore{reminders: []model.TaskReminder{
+			{Id: "r-1", TaskId: "task-1", UserId: 1, Channel: model.ReminderChannelWebhook, RemindAt: start},
+		}}
+		notifier := &fakeNotifier{err: ErrWebhookTargetNotAllowed}
+		scheduler := NewScheduler(logger, store, tasks, map[string]Notifier{model.ReminderChannelWebhook: notifier}, clock, time.Minute)
+
+		// When
+		fired := scheduler.RunDue(context.Background())
+
+		// Then
+		assert.Equal(t, 0, fired)
+		assert.Empty(t, store.released)
+		assert.Equal(t, []string{"r-1"}, store.failed)
+		assert.NotNil(t, store.reminders[0].FailedAt)
+	})
+
+	t.Run("when task is completed, Then reminder is dropped without sending", func(t *testing.T) {
+		// Given
+		clock := &fakeClock{now: start}
+		store := &fakeStore{reminders: []model.TaskReminder{
+			{Id: "r-1", TaskId: "task-2", UserId: 1, Channel: model.ReminderChannelInApp, RemindAt: start},
+		}}
+		notifier := &fakeNotifier{}
+		scheduler := NewScheduler(logger, store, tasks, map[string]Notifier{model.ReminderChannelInApp: notifier}, clock, time.Minute)
+
+		// When
+		fired := scheduler.RunDue(context.Background())
+
+		// Then
+		assert.Equal(t, 0, fired)
+		assert.Empty(t, notifier.sent)
+		assert.NotNil(t, store.reminders[0].FiredAt)
+	})
+}
+
+func mustPending(t *testing.T, store *fakeStore, now time.Time) []model.TaskReminder {
+	pending, err := store.GetPendingReminders(now, batchSize)
+	require.NoError(t, err)
+	return pending
+}
@@ -0,0 +1,34 @@
+package rotation
+
+import (
+	"github.com/happYness-Project/taskManagementGolang/pkg/loggers"
+)
+
+type RotationStore interface {
+	AddMemberToRotations(groupId int, userId int) error
+	RemoveMemberFromRotations(groupId int, userId int) error
+}
+
+// MembershipSync keeps the chore rotations of a group in line with its members.
+// The membership change itself has already been saved when it is called, so a
+// failure here is only logged.
+type MembershipSync struct {
+	logger *loggers.AppLogger
+	store  RotationStore
+}
+
+func NewMembershipSync(logger *loggers.AppLogger, store RotationStore) *MembershipSync {
+	return &MembershipSync{logger: logger, store: store}
+}
+
+func (s *MembershipSync) MemberJoined(groupId int, userId int) {
+	if err := s.store.AddMemberToRotations(groupId, userId); err != nil {
+		s.logger.Error().Err(err).Int("GroupId", groupId).Msg("Error occurred during AddMemberToRotations")
+	}
+}
+
+func (s *MembershipSync) MemberLeft(groupId int, userId int) {
+	if err := s.store.RemoveMemberFromRotations(groupId, userId); err != nil {
+		s.logger.Error().Err(err).Int("GroupId", groupId).Msg("Error occurred during RemoveMemberFromRotations")
+	}
+}
@@ -0,0 +1,53 @@
+package rotation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/happYness-Project/taskManagementGolang/pkg/configs"
+	"github.com/happYness-Project/taskManagementGolang/pkg/loggers"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeStore struct {
+	added   [][2]int
+	removed [][2]int
+	err     error
+}
+
+func (f *fakeStore) AddMemberToRotations(groupId int, userId int) error {
+	f.added = append(f.added, [2]int{groupId, userId})
+	return f.err
+}
+
+func (f *fakeStore) RemoveMemberFromRotations(groupId int, userId int) error {
+	f.removed = append(f.removed, [2]int{groupId, userId})
+	return f.err
+}
+
+func TestMembershipSync(t *testing.T) {
+	logger := loggers.Setup(configs.Env{})
+
+	t.Run("when a member joins or leaves, Then the rotations of that group follow", func(t *testing.T) {
+		// Given
+		store := &fakeStore{}
+		sync := NewMembershipSync(logger, store)
+
+		// When
+		sync.MemberJoined(1, 7)
+		sync.MemberLeft(2, 8)
+
+		// Then
+		assert.Equal(t, [][2]int{{1, 7}}, store.added)
+		assert.Equal(t, [][2]int{{2, 8}}, store.removed)
+	})
+
+	t.Run("when the store fails, Then the error is only logged", func(t *testing.T) {
+		// Given
+		store := &fakeStore{err: errors.New("connection refused")}
+		sync := NewMembershipSync(logger, store)
+
+		// When / Then
+		assert.NotPanics(t, func() { sync.MemberJoined(1, 7) })
+	})
+}
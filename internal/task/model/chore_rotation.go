@@ -0,0 +1,146 @@
+package model
+
+import (
+	"errors"
+)
+
+type RotationStrategy string
+
+const (
+	RotationRoundRobin  RotationStrategy = "round_robin"
+	RotationLeastLoaded RotationStrategy = "least_loaded"
+)
+
+var (
+	ErrInvalidRotationStrategy  = errors.New("rotation strategy must be round_robin or least_loaded")
+	ErrRotationNoMembers        = errors.New("rotation needs at least one member")
+	ErrRotationDuplicateMember  = errors.New("rotation members must be distinct")
+	ErrRotationMemberNotFound   = errors.New("user is not part of the rotation")
+	ErrRotationMemberNotInGroup = errors.New("rotation members must belong to the user group")
+)
+
+// ChoreRotation assigns the occurrences of a recurring chore to group members
+// in turn. Current is the index in Members of the member assigned to the open
+// occurrence.
+type ChoreRotation struct {
+	SeriesId    string           `json:"series_id"`
+	UsergroupId int              `json:"usergroup_id"`
+	Strategy    RotationStrategy `json:"strategy"`
+	Members     []int            `json:"members"`
+	Current     int              `json:"current"`
+}
+
+func NewChoreRotation(seriesId string, usergroupId int, strategy RotationStrategy, members []int) (*ChoreRotation, error) {
+	if strategy == "" {
+		strategy = RotationRoundRobin
+	}
+	if strategy != RotationRoundRobin && strategy != RotationLeastLoaded {
+		return nil, ErrInvalidRotationStrategy
+	}
+	if len(members) == 0 {
+		return nil, ErrRotationNoMembers
+	}
+	seen := map[int]bool{}
+	for _, m := range members {
+		if seen[m] {
+			return nil, ErrRotationDuplicateMember
+		}
+		seen[m] = true
+	}
+	return &ChoreRotation{
+		SeriesId:    seriesId,
+		UsergroupId: usergroupId,
+		Strategy:    strategy,
+		Members:     append([]int{}, members...),
+		Current:     0,
+	}, nil
+}
+
+// CurrentAssignee returns the member assigned to the open occurrence.
+func (c *ChoreRotation) CurrentAssignee() (int, bool) {
+	if len(c.Members) == 0 {
+		return 0, false
+	}
+	return c.Members[c.Current], true
+}
+
+// Advance picks the assignee of the next occurrence. Round robin takes the next
+// member in order; least loaded takes the member with the fewest open tasks in
+// load, breaking ties in rotation order.
+func (c *ChoreRotation) Advance(load map[int]int) (int, bool) {
+	return c.pick(load, false)
+}
+
+// Skip passes the open occurrence to the next member instead of the current one.
+func (c *ChoreRotation) Skip(load map[int]int) (int, bool) {
+	return c.pick(load, true)
+}
+
+func (c *ChoreRotation) pick(load map[int]int, excludeCurrent bool) (int, bool) {
+	n := len(c.Members)
+	if n == 0 {
+		return 0, false
+	}
+	if c.Strategy != RotationLeastLoaded {
+		c.Current = (c.Current + 1) % n
+		return c.Members[c.Current], true
+	}
+	best := -1
+	for step := 1; step <= n; step++ {
+		i := (c.Current + step) % n
+		if excludeCurrent && i == c.Current && n > 1 {
+			continue
+		}
+		if best == -1 || load[c.Members[i]] < load[c.Members[best]] {
+			best = i
+		}
+	}
+	c.Current = best
+	return c.Members[c.Current], true
+}
+
+// Swap exchanges the turns of two members. If one of them holds the open
+// occurrence, the other one takes it over.
+func (c *ChoreRotation) Swap(userId int, otherUserId int) error {
+	i, j := c.indexOf(userId), c.indexOf(otherUserId)
+	if i == -1 || j == -1 {
+		return ErrRotationMemberNotFound
+	}
+	c.Members[i], c.Members[j] = c.Members[j], c.Members[i]
+	return nil
+}
+
+// AddMember appends a member at the end of the rotation.
+func (c *ChoreRotation) AddMember(userId int) bool {
+	if c.indexOf(userId) != -1 {
+		return false
+	}
+	c.Members = append(c.Members, userId)
+	return true
+}
+
+// RemoveMember drops a member from the rotation. When the member held the open
+// occurrence, the next member in order takes it over.
+func (c *ChoreRotation) RemoveMember(userId int) bool {
+	i := c.indexOf(userId)
+	if i == -1 {
+		return false
+	}
+	c.Members = append(c.Members[:i], c.Members[i+1:]...)
+	if i < c.Current {
+		c.Current--
+	}
+	if c.Current >= len(c.Members) {
+		c.Current = 0
+	}
+	return true
+}
+
+func (c *ChoreRotation) indexOf(userId int) int {
+	for i, m := range c.Members {
+		if m == userId {
+			return i
+		}
+	}
+	return -1
+}
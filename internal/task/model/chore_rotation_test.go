@@ -0,0 +1,178 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewChoreRotation(t *testing.T) {
+	t.Run("when strategy is empty, Then default to round robin", func(t *testing.T) {
+		rotation, err := NewChoreRotation("series-1", 1, "", []int{1, 2})
+
+		require.NoError(t, err)
+		assert.Equal(t, RotationRoundRobin, rotation.Strategy)
+		assert.Equal(t, 0, rotation.Current)
+	})
+
+	t.Run("when members repeat, Then return error", func(t *testing.T) {
+		_, err := NewChoreRotation("series-1", 1, RotationRoundRobin, []int{1, 1})
+
+		assert.ErrorIs(t, err, ErrRotationDuplicateMember)
+	})
+
+	t.Run("when there are no members, Then return error", func(t *testing.T) {
+		_, err := NewChoreRotation("series-1", 1, RotationRoundRobin, nil)
+
+		assert.ErrorIs(t, err, ErrRotationNoMembers)
+	})
+}
+
+func TestChoreRotationAdvance(t *testing.T) {
+	t.Run("when round robin, Then members take turns and wrap around", func(t *testing.T) {
+		// Given
+		rotation, _ := NewChoreRotation("series-1", 1, RotationRoundRobin, []int{1, 2, 3})
+
+		// When
+		var turns []int
+		for i := 0; i < 4; i++ {
+			next, _ := rotation.Advance(nil)
+			turns = append(turns, next)
+		}
+
+		// Then
+		assert.Equal(t, []int{2, 3, 1, 2}, turns)
+	})
+
+	t.Run("when least loaded, Then pick the member with fewest open tasks", func(t *testing.T) {
+		rotation, _ := NewChoreRotation("series-1", 1, RotationLeastLoaded, []int{1, 2, 3})
+
+		next, ok := rotation.Advance(map[int]int{1: 0, 2: 3, 3: 1})
+
+		require.True(t, ok)
+		assert.Equal(t, 1, next)
+	})
+
+	t.Run("when least loaded members tie, Then follow rotation order", func(t *testing.T) {
+		rotation, _ := NewChoreRotation("series-1", 1, RotationLeastLoaded, []int{1, 2, 3})
+
+		next, _ := rotation.Advance(map[int]int{})
+
+		assert.Equal(t, 2, next)
+	})
+}
+
+func TestChoreRotationSkip(t *testing.T) {
+	t.Run("when least loaded and current member has the least load, Then pass to someone else", func(t *testing.T) {
+		rotation, _ := NewChoreRotation("series-1", 1, RotationLeastLoaded, []int{1, 2, 3})
+
+		next, _ := rotation.Skip(map[int]int{1: 0, 2: 2, 3: 1})
+
+		assert.Equal(t, 3, next)
+	})
+}
+
+func TestChoreRotationSwap(t *testing.T) {
+	t.Run("when current member swaps, Then the other member holds the open occurrence", func(t *testing.T) {
+		rotation, _ := NewChoreRotation("series-1", 1, RotationRoundRobin, []int{1, 2, 3})
+
+		err := rotation.Swap(1, 3)
+
+		require.NoError(t, err)
+		current, _ := rotation.CurrentAssignee()
+		assert.Equal(t, 3, current)
+		assert.Equal(t, []int{3, 2, 1}, rotation.Members)
+	})
+
+	t.Run("when a member is not in the rotation, Then return error", func(t *testing.T) {
+		rotation, _ := NewChoreRotation("series-1", 1, RotationRoundRobin, []int{1, 2})
+
+		assert.ErrorIs(t, rotation.Swap(1, 9), ErrRotationMemberNotFound)
+	})
+}
+
+func TestChoreRotationMembers(t *testing.T) {
+	t.Run("when the current member leaves, Then the next member takes over", func(t *testing.T) {
+		rotation, _ := NewChoreRotation("series-1", 1, RotationRoundRobin, []int{1, 2, 3})
+		rotation.Advance(nil)
+
+		removed := rotation.RemoveMember(2)
+
+		require.True(t, removed)
+		current, _ := rotation.CurrentAssignee()
+		assert.Equal(t, 3, current)
+	})
+
+	t.Run("when an earlier member leaves, Then the current member keeps the turn", func(t *testing.T) {
+		rotation, _ := NewChoreRotation("series-1", 1, RotationRoundRobin, []int{1, 2, 3})
+		rotation.Advance(nil)
+
+		rotation.RemoveMember(1)
+
+		current, _ := rotation.CurrentAssignee()
+		assert.Equal(t, 2, current)
+	})
+
+	t.Run("when the last member leaves, Then nobody is assigned", func(t *testing.T) {
+		rotation, _ := NewChoreRotation("series-1", 1, RotationRoundRobin, []int{1})
+
+		rotation.RemoveMember(1)
+
+		_, ok := rotation.CurrentAssignee()
+		assert.False(t, ok)
+	})
+
+	t.Run("when a member joins twice, Then it is added once", func(t *testing.T) {
+		rotation, _ := NewChoreRotation("series-1", 1, RotationRoundRobin, []int{1})
+
+		assert.True(t, rotation.AddMember(2))
+		assert.False(t, rotation.AddMember(2))
+		assert.Equal(t, []int{1, 2}, rotation.Members)
+	})
+}
+
+func TestTaskNextOccurrence(t *testing.T) {
+	t.Run("when an all-day task repeats weekly, Then the due date moves a week", func(t *testing.T) {
+		// Given
+		task := Task{TaskId: "task-1", TaskName: "Dish Wash", DueDate: "2025-01-31", Timezone: "UTC"}
+		task.SetRecurrence(RecurrenceWeekly)
+
+		// When
+		next, err := task.NextOccurrence(time.Now())
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, "2025-02-07", next.DueDate)
+		assert.Equal(t, "task-1", *next.SeriesId)
+		assert.False(t, next.IsCompleted)
+	})
+
+	t.Run("when a timed task repeats monthly, Then the target date moves a month", func(t *testing.T) {
+		target := time.Date(2025, 3, 10, 18, 0, 0, 0, time.UTC)
+		task := Task{TaskId: "task-1", TargetDate: target}
+		task.SetRecurrence(RecurrenceMonthly)
+
+		next, err := task.NextOccurrence(time.Now())
+
+		require.NoError(t, err)
+		assert.Equal(t, target.AddDate(0, 1, 0), next.TargetDate)
+	})
+
+	t.Run("when the task does not recur, Then return error", func(t *testing.T) {
+		task := Task{TaskId: "task-1"}
+
+		_, err := task.NextOccurrence(time.Now())
+
+		assert.ErrorIs(t, err, ErrTaskNotRecurring)
+	})
+}
+
+func TestParseRecurrence(t *testing.T) {
+	t.Run("when value is unknown, Then return error", func(t *testing.T) {
+		_, err := ParseRecurrence("yearly")
+
+		assert.ErrorIs(t, err, ErrInvalidRecurrence)
+	})
+}
@@ -0,0 +1,149 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// DateLayout is the format of an all-day due date (a calendar date without a time).
+const DateLayout = "2006-01-02"
+
+const DefaultTimezone = "UTC"
+
+var (
+	ErrInvalidTimezone    = errors.New("timezone must be a valid IANA time zone name")
+	ErrInvalidDueDate     = errors.New("due date must be formatted as YYYY-MM-DD")
+	ErrDueDateConflict    = errors.New("task cannot have both an all-day due date and a timed target date")
+	ErrDueDateBeforeToday = errors.New("due date cannot be before the creation date of the task")
+)
+
+// LoadTimezone resolves an IANA time zone name. An empty name means UTC.
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	if name == "Local" {
+		return nil, ErrInvalidTimezone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ErrInvalidTimezone
+	}
+	return loc, nil
+}
+
+// SetTimezone records the IANA time zone the task was planned in. All-day due
+// dates are interpreted in this zone when no viewer zone is given.
+func (t *Task) SetTimezone(name string) error {
+	if _, err := LoadTimezone(name); err != nil {
+		return err
+	}
+	if name == "" {
+		name = DefaultTimezone
+	}
+	t.Timezone = name
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+func (t *Task) IsAllDay() bool {
+	return t.DueDate != ""
+}
+
+// RescheduleAllDay sets a calendar due date and clears the timed target date.
+// The date is compared against the creation day in the task's time zone.
+func (t *Task) RescheduleAllDay(date string, allowBeforeCreation bool) error {
+	loc, err := t.location()
+	if err != nil {
+		return err
+	}
+	due, err := time.ParseInLocation(DateLayout, date, loc)
+	if err != nil {
+		return ErrInvalidDueDate
+	}
+	if !allowBeforeCreation {
+		y, m, d := t.CreatedAt.In(loc).Date()
+		if due.Before(time.Date(y, m, d, 0, 0, 0, 0, loc)) {
+			return ErrDueDateBeforeToday
+		}
+	}
+	t.DueDate = due.Format(DateLayout)
+	t.TargetDate = time.Time{}
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// DueAt returns the instant at which the task becomes overdue for a viewer in
+// loc. An all-day task is due until the end of its date in that zone; a nil loc
+// falls back to the task's own zone. ok is false when the task has no due date.
+func (t *Task) DueAt(loc *time.Location) (due time.Time, ok bool) {
+	if t.IsAllDay() {
+		if loc == nil {
+			var err error
+			if loc, err = t.location(); err != nil {
+				return time.Time{}, false
+			}
+		}
+		day, err := time.ParseInLocation(DateLayout, t.DueDate, loc)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return day.AddDate(0, 0, 1), true
+	}
+	if t.TargetDate.IsZero() {
+		return time.Time{}, false
+	}
+	return t.TargetDate, true
+}
+
+// DueStart returns the moment a task's due date begins: the timed target date,
+// or the start of an all-day due date in the task's own time zone.
+func (t *Task) DueStart() (time.Time, bool) {
+	if t.IsAllDay() {
+		loc, err := t.location()
+		if err != nil {
+			return time.Time{}, false
+		}
+		day, err := time.ParseInLocation(DateLayout, t.DueDate, loc)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return day, true
+	}
+	if t.TargetDate.IsZero() {
+		return time.Time{}, false
+	}
+	return t.TargetDate, true
+}
+
+func (t *Task) IsOverdue(now time.Time, loc *time.Location) bool {
+	if t.IsCompleted {
+		return false
+	}
+	due, ok := t.DueAt(loc)
+	return ok && !now.Before(due)
+}
+
+func (t *Task) IsDueToday(now time.Time, loc *time.Location) bool {
+	if loc == nil {
+		var err error
+		if loc, err = t.location(); err != nil {
+			return false
+		}
+	}
+	today := now.In(loc).Format(DateLayout)
+	if t.IsAllDay() {
+		return t.DueDate == today
+	}
+	return !t.TargetDate.IsZero() && t.TargetDate.In(loc).Format(DateLayout) == today
+}
+
+// EvaluateDue fills the computed due flags for a viewer in loc.
+func (t *Task) EvaluateDue(now time.Time, loc *time.Location) {
+	t.Overdue = t.IsOverdue(now, loc)
+	t.DueToday = t.IsDueToday(now, loc)
+}
+
+func (t *Task) location() (*time.Location, error) {
+	return LoadTimezone(t.Timezone)
+}
@@ -0,0 +1,126 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTimezone(t *testing.T) {
+	t.Run("when timezone is empty, Then return UTC", func(t *testing.T) {
+		loc, err := LoadTimezone("")
+
+		require.NoError(t, err)
+		assert.Equal(t, time.UTC, loc)
+	})
+
+	t.Run("when timezone is not an IANA name, Then return error", func(t *testing.T) {
+		loc, err := LoadTimezone("Mars/Olympus")
+
+		assert.ErrorIs(t, err, ErrInvalidTimezone)
+		assert.Nil(t, loc)
+	})
+}
+
+func TestTaskAllDayDueDate(t *testing.T) {
+	vancouver, err := time.LoadLocation("America/Vancouver")
+	require.NoError(t, err)
+
+	t.Run("when all-day task is due Friday, Then it is due Friday for a Vancouver viewer", func(t *testing.T) {
+		// Given
+		task := Task{TaskId: "task-1", CreatedAt: time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC)}
+		require.NoError(t, task.SetTimezone("America/Vancouver"))
+
+		// When
+		err := task.RescheduleAllDay("2025-01-10", false)
+
+		// Then
+		require.NoError(t, err)
+		assert.True(t, task.IsAllDay())
+		assert.True(t, task.TargetDate.IsZero())
+		thursdayEvening := time.Date(2025, 1, 9, 20, 0, 0, 0, vancouver)
+		fridayEvening := time.Date(2025, 1, 10, 20, 0, 0, 0, vancouver)
+		assert.False(t, task.IsDueToday(thursdayEvening, vancouver))
+		assert.True(t, task.IsDueToday(fridayEvening, vancouver))
+		assert.False(t, task.IsOverdue(fridayEvening, vancouver))
+		assert.True(t, task.IsOverdue(time.Date(2025, 1, 11, 0, 0, 0, 0, vancouver), vancouver))
+	})
+
+	t.Run("when all-day due date is not a date, Then return error", func(t *testing.T) {
+		// Given
+		task := Task{TaskId: "task-1", CreatedAt: time.Now()}
+
+		// When
+		err := task.RescheduleAllDay("Friday", false)
+
+		// Then
+		assert.ErrorIs(t, err, ErrInvalidDueDate)
+	})
+
+	t.Run("when all-day due date is before creation day in task timezone, Then return error", func(t *testing.T) {
+		// Given
+		task := Task{TaskId: "task-1", CreatedAt: time.Date(2025, 1, 10, 3, 0, 0, 0, time.UTC)}
+		require.NoError(t, task.SetTimezone("America/Vancouver"))
+
+		// When
+		err := task.RescheduleAllDay("2025-01-08", false)
+
+		// Then
+		assert.ErrorIs(t, err, ErrDueDateBeforeToday)
+	})
+
+	t.Run("when timed target date is set, Then all-day due date is cleared", func(t *testing.T) {
+		// Given
+		task := Task{TaskId: "task-1", DueDate: "2025-01-10", CreatedAt: time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC)}
+		targetDate := time.Date(2025, 1, 10, 17, 0, 0, 0, vancouver)
+
+		// When
+		err := task.Reschedule(targetDate, false)
+
+		// Then
+		require.NoError(t, err)
+		assert.False(t, task.IsAllDay())
+		assert.Equal(t, targetDate, task.TargetDate)
+	})
+}
+
+func TestTaskEvaluateDue(t *testing.T) {
+	t.Run("when timed task is past its due instant, Then it is overdue", func(t *testing.T) {
+		// Given
+		due := time.Date(2025, 1, 10, 17, 0, 0, 0, time.UTC)
+		task := Task{TaskId: "task-1", TargetDate: due}
+
+		// When
+		task.EvaluateDue(due.Add(time.Minute), nil)
+
+		// Then
+		assert.True(t, task.Overdue)
+		assert.True(t, task.DueToday)
+	})
+
+	t.Run("when task is completed, Then it is not overdue", func(t *testing.T) {
+		// Given
+		due := time.Date(2025, 1, 10, 17, 0, 0, 0, time.UTC)
+		task := Task{TaskId: "task-1", TargetDate: due, IsCompleted: true}
+
+		// When
+		task.EvaluateDue(due.Add(time.Hour), nil)
+
+		// Then
+		assert.False(t, task.Overdue)
+	})
+
+	t.Run("when task has no due date, Then it is neither due nor overdue", func(t *testing.T) {
+		// Given
+		task := Task{TaskId: "task-1"}
+
+		// When
+		task.EvaluateDue(time.Now(), time.UTC)
+
+		// Then
+		assert.False(t, task.Overdue)
+		assert.False(t, task.DueToday)
+	})
+}
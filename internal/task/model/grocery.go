@@ -0,0 +1,126 @@
+package model
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	MaxTaskUnitLength  = 20
+	MaxTaskAisleLength = 50
+)
+
+// UncategorizedAisle is the shopping section for items without an aisle.
+const UncategorizedAisle = "Other"
+
+var (
+	ErrInvalidQuantity = errors.New("quantity must be greater than zero")
+	ErrUnitWithoutQty  = errors.New("unit requires a quantity")
+	ErrUnitTooLong     = errors.New("unit cannot be longer than 20 characters")
+	ErrAisleTooLong    = errors.New("aisle cannot be longer than 50 characters")
+	ErrUnitMismatch    = errors.New("items with different units cannot be merged")
+)
+
+// AisleSection groups the open items of a grocery list for the shopping view.
+type AisleSection struct {
+	Aisle string `json:"aisle"`
+	Items []Task `json:"items"`
+}
+
+// NormalizeItemName is used to detect duplicate grocery items, so "Banana",
+// " banana " and "BANANA" are the same item.
+func NormalizeItemName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+func (t *Task) SetQuantity(quantity *float64, unit string) error {
+	unit = strings.ToLower(strings.TrimSpace(unit))
+	if quantity != nil && *quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+	if quantity == nil && unit != "" {
+		return ErrUnitWithoutQty
+	}
+	if utf8.RuneCountInString(unit) > MaxTaskUnitLength {
+		return ErrUnitTooLong
+	}
+	t.Quantity = quantity
+	t.Unit = unit
+	return nil
+}
+
+func (t *Task) SetAisle(aisle string) error {
+	aisle = strings.TrimSpace(aisle)
+	if utf8.RuneCountInString(aisle) > MaxTaskAisleLength {
+		return ErrAisleTooLong
+	}
+	t.Aisle = aisle
+	return nil
+}
+
+// CanMerge reports whether item is the same open grocery item as t.
+func (t *Task) CanMerge(item Task) bool {
+	return !t.IsCompleted && !t.IsArchived() &&
+		NormalizeItemName(t.TaskName) == NormalizeItemName(item.TaskName) &&
+		(t.Unit == item.Unit || t.Quantity == nil || item.Quantity == nil)
+}
+
+// MergeItem adds the quantity of item to t. An item without a quantity counts
+// as one. The existing description and aisle are kept unless they are empty.
+func (t *Task) MergeItem(item Task) error {
+	if !t.CanMerge(item) {
+		return ErrUnitMismatch
+	}
+	total := quantityOrOne(t.Quantity) + quantityOrOne(item.Quantity)
+	t.Quantity = &total
+	if t.Unit == "" {
+		t.Unit = item.Unit
+	}
+	if t.TaskDesc == "" {
+		t.TaskDesc = item.TaskDesc
+	}
+	if t.Aisle == "" {
+		t.Aisle = item.Aisle
+	}
+	t.UpdatedAt = item.UpdatedAt
+	return nil
+}
+
+func quantityOrOne(quantity *float64) float64 {
+	if quantity == nil {
+		return 1
+	}
+	return *quantity
+}
+
+// GroupByAisle groups open items by aisle in alphabetical order, with items
+// without an aisle last.
+func GroupByAisle(tasks []Task) []AisleSection {
+	index := map[string]int{}
+	sections := []AisleSection{}
+	for _, task := range tasks {
+		if task.IsCompleted {
+			continue
+		}
+		aisle := task.Aisle
+		if aisle == "" {
+			aisle = UncategorizedAisle
+		}
+		i, ok := index[strings.ToLower(aisle)]
+		if !ok {
+			i = len(sections)
+			index[strings.ToLower(aisle)] = i
+			sections = append(sections, AisleSection{Aisle: aisle, Items: []Task{}})
+		}
+		sections[i].Items = append(sections[i].Items, task)
+	}
+	sort.SliceStable(sections, func(a, b int) bool {
+		if (sections[a].Aisle == UncategorizedAisle) != (sections[b].Aisle == UncategorizedAisle) {
+			return sections[b].Aisle == UncategorizedAisle
+		}
+		return strings.ToLower(sections[a].Aisle) < strings.ToLower(sections[b].Aisle)
+	})
+	return sections
+}
@@ -0,0 +1,124 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func quantity(q float64) *float64 {
+	return &q
+}
+
+func TestNormalizeItemName(t *testing.T) {
+	t.Run("when names differ only in case and spacing, Then they normalize the same", func(t *testing.T) {
+		assert.Equal(t, "green onion", NormalizeItemName("  Green   Onion "))
+		assert.Equal(t, NormalizeItemName("banana"), NormalizeItemName("BANANA"))
+	})
+}
+
+func TestTaskSetQuantity(t *testing.T) {
+	t.Run("when quantity and unit are valid, Then unit is normalized", func(t *testing.T) {
+		// Given
+		task := Task{TaskId: "task-1"}
+
+		// When
+		err := task.SetQuantity(quantity(1.5), " KG ")
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, 1.5, *task.Quantity)
+		assert.Equal(t, "kg", task.Unit)
+	})
+
+	t.Run("when quantity is zero, Then return error", func(t *testing.T) {
+		task := Task{TaskId: "task-1"}
+
+		err := task.SetQuantity(quantity(0), "")
+
+		assert.ErrorIs(t, err, ErrInvalidQuantity)
+	})
+
+	t.Run("when unit is given without quantity, Then return error", func(t *testing.T) {
+		task := Task{TaskId: "task-1"}
+
+		err := task.SetQuantity(nil, "kg")
+
+		assert.ErrorIs(t, err, ErrUnitWithoutQty)
+	})
+}
+
+func TestTaskMergeItem(t *testing.T) {
+	t.Run("when merging the same item without quantities, Then quantity becomes two", func(t *testing.T) {
+		// Given
+		existing := Task{TaskId: "task-1", TaskName: "Banana", TaskDesc: "need this for breakfast"}
+		item := Task{TaskName: " banana"}
+
+		// When
+		err := existing.MergeItem(item)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, 2.0, *existing.Quantity)
+		assert.Equal(t, "need this for breakfast", existing.TaskDesc)
+	})
+
+	t.Run("when merging the same item with the same unit, Then quantities are summed", func(t *testing.T) {
+		// Given
+		existing := Task{TaskId: "task-1", TaskName: "Milk", Quantity: quantity(1), Unit: "l"}
+		item := Task{TaskName: "Milk", Quantity: quantity(2), Unit: "l", Aisle: "Dairy"}
+
+		// When
+		err := existing.MergeItem(item)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, 3.0, *existing.Quantity)
+		assert.Equal(t, "l", existing.Unit)
+		assert.Equal(t, "Dairy", existing.Aisle)
+	})
+
+	t.Run("when units differ, Then items are not merged", func(t *testing.T) {
+		// Given
+		existing := Task{TaskId: "task-1", TaskName: "Milk", Quantity: quantity(1), Unit: "l"}
+		item := Task{TaskName: "Milk", Quantity: quantity(500), Unit: "ml"}
+
+		// When
+		err := existing.MergeItem(item)
+
+		// Then
+		assert.ErrorIs(t, err, ErrUnitMismatch)
+		assert.Equal(t, 1.0, *existing.Quantity)
+	})
+
+	t.Run("when existing item is completed, Then it cannot be merged", func(t *testing.T) {
+		existing := Task{TaskId: "task-1", TaskName: "Milk", IsCompleted: true}
+
+		assert.False(t, existing.CanMerge(Task{TaskName: "Milk"}))
+	})
+}
+
+func TestGroupByAisle(t *testing.T) {
+	t.Run("when items have aisles, Then open items are grouped alphabetically with other last", func(t *testing.T) {
+		// Given
+		tasks := []Task{
+			{TaskId: "1", TaskName: "Apple", Aisle: "Produce"},
+			{TaskId: "2", TaskName: "Dish soap"},
+			{TaskId: "3", TaskName: "Milk", Aisle: "Dairy"},
+			{TaskId: "4", TaskName: "Banana", Aisle: "produce"},
+			{TaskId: "5", TaskName: "Cheese", Aisle: "Dairy", IsCompleted: true},
+		}
+
+		// When
+		sections := GroupByAisle(tasks)
+
+		// Then
+		require.Len(t, sections, 3)
+		assert.Equal(t, "Dairy", sections[0].Aisle)
+		assert.Len(t, sections[0].Items, 1)
+		assert.Equal(t, "Produce", sections[1].Aisle)
+		assert.Len(t, sections[1].Items, 2)
+		assert.Equal(t, UncategorizedAisle, sections[2].Aisle)
+	})
+}
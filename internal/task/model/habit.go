@@ -0,0 +1,206 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// HabitFrequency is the period a habit's target count applies to.
+type HabitFrequency string
+
+const (
+	HabitDaily  HabitFrequency = "daily"
+	HabitWeekly HabitFrequency = "weekly"
+)
+
+// MaxHabitCalendarDays bounds the range of a habit calendar request.
+const MaxHabitCalendarDays = 366
+
+var (
+	ErrInvalidHabitFrequency = errors.New("frequency must be daily or weekly")
+	ErrInvalidHabitTarget    = errors.New("weekly target count must be between 1 and 7")
+	ErrTaskNotHabit          = errors.New("task is not a habit")
+	ErrHabitNotCompletable   = errors.New("habits are checked in per date instead of being completed")
+	ErrCheckInInFuture       = errors.New("check-in date cannot be in the future")
+	ErrInvalidCalendarRange  = errors.New("calendar range must be at most 366 days and from must not be after to")
+)
+
+// HabitCheckIn marks a habit as done on a calendar date in the task's time zone.
+type HabitCheckIn struct {
+	TaskId    string    `json:"task_id"`
+	Date      string    `json:"date"`
+	UserId    *int      `json:"user_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type HabitDay struct {
+	Date      string `json:"date"`
+	CheckedIn bool   `json:"checked_in"`
+}
+
+type HabitStreak struct {
+	Frequency     HabitFrequency `json:"frequency"`
+	TargetCount   int            `json:"target_count"`
+	CurrentStreak int            `json:"current_streak"`
+	LongestStreak int            `json:"longest_streak"`
+	TotalCheckIns int            `json:"total_check_ins"`
+}
+
+func ParseHabitFrequency(s string) (HabitFrequency, error) {
+	f := HabitFrequency(strings.ToLower(strings.TrimSpace(s)))
+	switch f {
+	case "":
+		return HabitDaily, nil
+	case HabitDaily, HabitWeekly:
+		return f, nil
+	}
+	return "", ErrInvalidHabitFrequency
+}
+
+func (t *Task) IsHabit() bool {
+	return t.Frequency != ""
+}
+
+// SetHabitTarget turns the task into a habit done count times per period. A
+// daily habit is always done once a day.
+func (t *Task) SetHabitTarget(frequency HabitFrequency, count int) error {
+	switch frequency {
+	case HabitDaily:
+		count = 1
+	case HabitWeekly:
+		if count == 0 {
+			count = 1
+		}
+		if count < 1 || count > 7 {
+			return ErrInvalidHabitTarget
+		}
+	default:
+		return ErrInvalidHabitFrequency
+	}
+	t.Frequency = frequency
+	t.TargetCount = count
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// Today returns the current date in the task's time zone.
+func (t *Task) Today(now time.Time) string {
+	loc, err := t.location()
+	if err != nil {
+		loc = time.UTC
+	}
+	return now.In(loc).Format(DateLayout)
+}
+
+// NewCheckIn checks the habit in on date, which defaults to today in the
+// task's time zone.
+func NewCheckIn(task Task, date string, userId *int, now time.Time) (*HabitCheckIn, error) {
+	if !task.IsHabit() {
+		return nil, ErrTaskNotHabit
+	}
+	today := task.Today(now)
+	if date == "" {
+		date = today
+	}
+	day, err := time.Parse(DateLayout, date)
+	if err != nil {
+		return nil, ErrInvalidDueDate
+	}
+	date = day.Format(DateLayout)
+	if date > today {
+		return nil, ErrCheckInInFuture
+	}
+	return &HabitCheckIn{TaskId: task.TaskId, Date: date, UserId: userId, CreatedAt: now}, nil
+}
+
+// HabitCalendar lists every date from from to to with whether the habit was
+// checked in on it.
+func HabitCalendar(from string, to string, checkIns []string) ([]HabitDay, error) {
+	start, err := time.Parse(DateLayout, from)
+	if err != nil {
+		return nil, ErrInvalidDueDate
+	}
+	end, err := time.Parse(DateLayout, to)
+	if err != nil {
+		return nil, ErrInvalidDueDate
+	}
+	if end.Before(start) || end.Sub(start) >= MaxHabitCalendarDays*24*time.Hour {
+		return nil, ErrInvalidCalendarRange
+	}
+	done := make(map[string]bool, len(checkIns))
+	for _, d := range checkIns {
+		done[d] = true
+	}
+	days := []HabitDay{}
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		date := d.Format(DateLayout)
+		days = append(days, HabitDay{Date: date, CheckedIn: done[date]})
+	}
+	return days, nil
+}
+
+// Streak counts the consecutive periods (days or Monday-based weeks) in which
+// the habit met its target count. The current period does not break the
+// streak until it is over.
+func (t *Task) Streak(checkIns []string, today string) HabitStreak {
+	streak := HabitStreak{Frequency: t.Frequency, TargetCount: t.TargetCount, TotalCheckIns: len(checkIns)}
+	target := t.TargetCount
+	if target < 1 {
+		target = 1
+	}
+	step := 1
+	if t.Frequency == HabitWeekly {
+		step = 7
+	}
+
+	counts := map[string]int{}
+	var first time.Time
+	for _, date := range checkIns {
+		day, err := time.Parse(DateLayout, date)
+		if err != nil {
+			continue
+		}
+		period := t.periodStart(day)
+		counts[period.Format(DateLayout)]++
+		if first.IsZero() || period.Before(first) {
+			first = period
+		}
+	}
+	met := func(period time.Time) bool {
+		return counts[period.Format(DateLayout)] >= target
+	}
+
+	now, err := time.Parse(DateLayout, today)
+	if err != nil || first.IsZero() {
+		return streak
+	}
+	current := t.periodStart(now)
+	if !met(current) {
+		current = current.AddDate(0, 0, -step)
+	}
+	for met(current) {
+		streak.CurrentStreak++
+		current = current.AddDate(0, 0, -step)
+	}
+
+	run := 0
+	for period := first; !period.After(t.periodStart(now)); period = period.AddDate(0, 0, step) {
+		if met(period) {
+			run++
+			if run > streak.LongestStreak {
+				streak.LongestStreak = run
+			}
+		} else {
+			run = 0
+		}
+	}
+	return streak
+}
+
+func (t *Task) periodStart(day time.Time) time.Time {
+	if t.Frequency != HabitWeekly {
+		return day
+	}
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
@@ -0,0 +1,109 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskSetHabitTarget(t *testing.T) {
+	t.Run("when habit is daily, Then target count is always one", func(t *testing.T) {
+		task := Task{}
+
+		require.NoError(t, task.SetHabitTarget(HabitDaily, 4))
+
+		assert.True(t, task.IsHabit())
+		assert.Equal(t, 1, task.TargetCount)
+	})
+
+	t.Run("when weekly target is above seven, Then return error", func(t *testing.T) {
+		task := Task{}
+
+		assert.ErrorIs(t, task.SetHabitTarget(HabitWeekly, 8), ErrInvalidHabitTarget)
+	})
+}
+
+func TestNewCheckIn(t *testing.T) {
+	task := Task{TaskId: "habit-1", Timezone: "America/Vancouver"}
+	_ = task.SetHabitTarget(HabitDaily, 1)
+	now := time.Date(2025, 6, 3, 5, 0, 0, 0, time.UTC)
+
+	t.Run("when date is empty, Then check in today in the task time zone", func(t *testing.T) {
+		checkIn, err := NewCheckIn(task, "", nil, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, "2025-06-02", checkIn.Date)
+	})
+
+	t.Run("when date is in the future, Then return error", func(t *testing.T) {
+		_, err := NewCheckIn(task, "2025-06-03", nil, now)
+
+		assert.ErrorIs(t, err, ErrCheckInInFuture)
+	})
+
+	t.Run("when task is not a habit, Then return error", func(t *testing.T) {
+		_, err := NewCheckIn(Task{TaskId: "task-1"}, "", nil, now)
+
+		assert.ErrorIs(t, err, ErrTaskNotHabit)
+	})
+}
+
+func TestTaskStreak(t *testing.T) {
+	t.Run("when daily habit has a gap, Then current and longest streaks differ", func(t *testing.T) {
+		// Given
+		task := Task{}
+		_ = task.SetHabitTarget(HabitDaily, 1)
+		checkIns := []string{"2025-06-01", "2025-06-02", "2025-06-03", "2025-06-05", "2025-06-06"}
+
+		// When
+		streak := task.Streak(checkIns, "2025-06-07")
+
+		// Then
+		assert.Equal(t, 2, streak.CurrentStreak)
+		assert.Equal(t, 3, streak.LongestStreak)
+		assert.Equal(t, 5, streak.TotalCheckIns)
+	})
+
+	t.Run("when weekly habit meets its target in consecutive weeks, Then count weeks", func(t *testing.T) {
+		task := Task{}
+		_ = task.SetHabitTarget(HabitWeekly, 2)
+		// Weeks starting 2025-06-02 and 2025-06-09 meet the target; the current week has one check-in so far.
+		checkIns := []string{"2025-06-02", "2025-06-04", "2025-06-10", "2025-06-15", "2025-06-16"}
+
+		streak := task.Streak(checkIns, "2025-06-17")
+
+		assert.Equal(t, 2, streak.CurrentStreak)
+		assert.Equal(t, 2, streak.LongestStreak)
+	})
+
+	t.Run("when there are no check-ins, Then streaks are zero", func(t *testing.T) {
+		task := Task{}
+		_ = task.SetHabitTarget(HabitDaily, 1)
+
+		streak := task.Streak(nil, "2025-06-07")
+
+		assert.Equal(t, 0, streak.CurrentStreak)
+		assert.Equal(t, 0, streak.LongestStreak)
+	})
+}
+
+func TestHabitCalendar(t *testing.T) {
+	t.Run("when range is valid, Then list every day with its check-in", func(t *testing.T) {
+		days, err := HabitCalendar("2025-06-01", "2025-06-03", []string{"2025-06-02"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []HabitDay{
+			{Date: "2025-06-01", CheckedIn: false},
+			{Date: "2025-06-02", CheckedIn: true},
+			{Date: "2025-06-03", CheckedIn: false},
+		}, days)
+	})
+
+	t.Run("when from is after to, Then return error", func(t *testing.T) {
+		_, err := HabitCalendar("2025-06-03", "2025-06-01", nil)
+
+		assert.ErrorIs(t, err, ErrInvalidCalendarRange)
+	})
+}
@@ -0,0 +1,151 @@
+package model
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MaxTaskPoints caps the explicit points a single task can be worth.
+const MaxTaskPoints = 1000
+
+// PriorityPoints is what completing a task is worth when it has no explicit points.
+var PriorityPoints = map[Priority]int{
+	PriorityLow:    1,
+	PriorityNormal: 2,
+	PriorityHigh:   3,
+	PriorityUrgent: 5,
+}
+
+var (
+	ErrInvalidPoints            = errors.New("points must be between 0 and 1000")
+	ErrInvalidLeaderboardPeriod = errors.New("period must be one of week, month, all")
+)
+
+type LeaderboardPeriod string
+
+const (
+	PeriodWeek  LeaderboardPeriod = "week"
+	PeriodMonth LeaderboardPeriod = "month"
+	PeriodAll   LeaderboardPeriod = "all"
+)
+
+// PointAward records the points a user earned by completing a task. Awards
+// outlive the task so that deleting a done chore does not take points away;
+// reopening the task revokes them.
+type PointAward struct {
+	TaskId      string    `json:"task_id"`
+	UserId      int       `json:"user_id"`
+	UsergroupId int       `json:"usergroup_id"`
+	Points      int       `json:"points"`
+	AwardedAt   time.Time `json:"awarded_at"`
+	AwardedOn   string    `json:"awarded_on"`
+}
+
+type LeaderboardEntry struct {
+	UserId    int    `json:"user_id"`
+	UserName  string `json:"username"`
+	Points    int    `json:"points"`
+	Completed int    `json:"completed"`
+	Streak    int    `json:"streak"`
+	// Timezone of the user; their streak is counted up to today in this zone.
+	Timezone string `json:"-"`
+}
+
+// SetPoints overrides the priority based points of the task. nil goes back to
+// the priority based value.
+func (t *Task) SetPoints(points *int) error {
+	if points != nil && (*points < 0 || *points > MaxTaskPoints) {
+		return ErrInvalidPoints
+	}
+	t.Points = points
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// EarnedPoints is what completing the task is worth.
+func (t *Task) EarnedPoints() int {
+	if t.Points != nil {
+		return *t.Points
+	}
+	return PriorityPoints[t.Priority]
+}
+
+// NewPointAward credits the user who completed the task. The award day is taken
+// in loc, the user's own time zone, so that a late evening chore counts for the
+// day the streak of the user is measured against.
+func NewPointAward(task Task, userId int, usergroupId int, now time.Time, loc *time.Location) PointAward {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return PointAward{
+		TaskId:      task.TaskId,
+		UserId:      userId,
+		UsergroupId: usergroupId,
+		Points:      task.EarnedPoints(),
+		AwardedAt:   now,
+		AwardedOn:   now.In(loc).Format(DateLayout),
+	}
+}
+
+func ParseLeaderboardPeriod(s string) (LeaderboardPeriod, error) {
+	p := LeaderboardPeriod(strings.ToLower(strings.TrimSpace(s)))
+	switch p {
+	case "":
+		return PeriodWeek, nil
+	case PeriodWeek, PeriodMonth, PeriodAll:
+		return p, nil
+	}
+	return "", ErrInvalidLeaderboardPeriod
+}
+
+// Since returns the start of the period containing now in loc. Weeks start on
+// Monday. ok is false for the all-time period.
+func (p LeaderboardPeriod) Since(now time.Time, loc *time.Location) (since time.Time, ok bool) {
+	now = now.In(loc)
+	y, m, d := now.Date()
+	switch p {
+	case PeriodWeek:
+		offset := (int(now.Weekday()) + 6) % 7
+		return time.Date(y, m, d-offset, 0, 0, 0, 0, loc), true
+	case PeriodMonth:
+		return time.Date(y, m, 1, 0, 0, 0, 0, loc), true
+	}
+	return time.Time{}, false
+}
+
+// Streak counts the consecutive days with at least one completion ending today.
+// A streak is kept alive through today as long as yesterday had a completion.
+func Streak(days []string, today string) int {
+	seen := make(map[string]bool, len(days))
+	for _, day := range days {
+		seen[day] = true
+	}
+	current, err := time.Parse(DateLayout, today)
+	if err != nil {
+		return 0
+	}
+	if !seen[today] {
+		current = current.AddDate(0, 0, -1)
+	}
+	streak := 0
+	for seen[current.Format(DateLayout)] {
+		streak++
+		current = current.AddDate(0, 0, -1)
+	}
+	return streak
+}
+
+// SortLeaderboard orders entries by points, then completions, then streak.
+func SortLeaderboard(entries []LeaderboardEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Points != entries[j].Points {
+			return entries[i].Points > entries[j].Points
+		}
+		if entries[i].Completed != entries[j].Completed {
+			return entries[i].Completed > entries[j].Completed
+		}
+		return entries[i].Streak > entries[j].Streak
+	})
+}
@@ -0,0 +1,86 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskEarnedPoints(t *testing.T) {
+	t.Run("when task has no explicit points, Then use the priority points", func(t *testing.T) {
+		task := Task{Priority: PriorityUrgent}
+
+		assert.Equal(t, PriorityPoints[PriorityUrgent], task.EarnedPoints())
+	})
+
+	t.Run("when task has explicit points, Then they win over priority", func(t *testing.T) {
+		task := Task{Priority: PriorityUrgent}
+		points := 10
+
+		require.NoError(t, task.SetPoints(&points))
+
+		assert.Equal(t, 10, task.EarnedPoints())
+	})
+
+	t.Run("when points are out of range, Then return error", func(t *testing.T) {
+		task := Task{}
+		points := MaxTaskPoints + 1
+
+		assert.ErrorIs(t, task.SetPoints(&points), ErrInvalidPoints)
+	})
+}
+
+func TestNewPointAward(t *testing.T) {
+	t.Run("when completed late in the evening, Then the award day is taken in the user's time zone", func(t *testing.T) {
+		// Given
+		task := Task{TaskId: "task-1", Priority: PriorityHigh, Timezone: "Asia/Seoul"}
+		loc, _ := time.LoadLocation("America/Vancouver")
+		now := time.Date(2025, 6, 3, 5, 30, 0, 0, time.UTC)
+
+		// When
+		award := NewPointAward(task, 7, 1, now, loc)
+
+		// Then
+		assert.Equal(t, "2025-06-02", award.AwardedOn)
+		assert.Equal(t, PriorityPoints[PriorityHigh], award.Points)
+	})
+}
+
+func TestStreak(t *testing.T) {
+	t.Run("when completed on consecutive days including today, Then count them all", func(t *testing.T) {
+		assert.Equal(t, 3, Streak([]string{"2025-06-01", "2025-06-02", "2025-06-03"}, "2025-06-03"))
+	})
+
+	t.Run("when nothing is completed yet today, Then keep yesterday's streak", func(t *testing.T) {
+		assert.Equal(t, 2, Streak([]string{"2025-06-01", "2025-06-02"}, "2025-06-03"))
+	})
+
+	t.Run("when a day was missed, Then the streak is broken", func(t *testing.T) {
+		assert.Equal(t, 0, Streak([]string{"2025-05-30", "2025-06-01"}, "2025-06-03"))
+	})
+}
+
+func TestLeaderboardPeriodSince(t *testing.T) {
+	now := time.Date(2025, 6, 5, 12, 0, 0, 0, time.UTC) // Thursday
+
+	t.Run("when period is week, Then start on Monday", func(t *testing.T) {
+		since, ok := PeriodWeek.Since(now, time.UTC)
+
+		require.True(t, ok)
+		assert.Equal(t, time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), since)
+	})
+
+	t.Run("when period is all, Then there is no start", func(t *testing.T) {
+		_, ok := PeriodAll.Since(now, time.UTC)
+
+		assert.False(t, ok)
+	})
+
+	t.Run("when period is unknown, Then return error", func(t *testing.T) {
+		_, err := ParseLeaderboardPeriod("year")
+
+		assert.ErrorIs(t, err, ErrInvalidLeaderboardPeriod)
+	})
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"errors"
+	"strings"
+)
+
+type Priority string
+
+const (
+	PriorityLow    Priority = "low"
+	PriorityNormal Priority = "normal"
+	PriorityHigh   Priority = "high"
+	PriorityUrgent Priority = "urgent"
+)
+
+// Priorities lists every priority from the least to the most pressing.
+var Priorities = []Priority{PriorityLow, PriorityNormal, PriorityHigh, PriorityUrgent}
+
+var ErrInvalidPriority = errors.New("priority must be one of low, normal, high, urgent")
+
+// ParsePriority normalizes the given value. An empty value falls back to normal.
+func ParsePriority(value string) (Priority, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return PriorityNormal, nil
+	}
+	for _, p := range Priorities {
+		if string(p) == value {
+			return p, nil
+		}
+	}
+	return "", ErrInvalidPriority
+}
+
+// Rank orders priorities semantically, starting at 1 for low.
+// Unknown values rank 0 so they sort before every valid priority.
+func (p Priority) Rank() int {
+	for i, priority := range Priorities {
+		if priority == p {
+			return i + 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePriority(t *testing.T) {
+	t.Run("when parsing valid priorities with different casing, Then return normalized priority", func(t *testing.T) {
+		cases := map[string]Priority{
+			"low":    PriorityLow,
+			"Normal": PriorityNormal,
+			" HIGH ": PriorityHigh,
+			"urgent": PriorityUrgent,
+			"":       PriorityNormal,
+			"  ":     PriorityNormal,
+		}
+		for value, expected := range cases {
+			priority, err := ParsePriority(value)
+			require.NoError(t, err, "priority %q should be valid", value)
+			assert.Equal(t, expected, priority)
+		}
+	})
+
+	t.Run("when parsing unknown priority, Then return error", func(t *testing.T) {
+		priority, err := ParsePriority("asap")
+
+		assert.ErrorIs(t, err, ErrInvalidPriority)
+		assert.Equal(t, Priority(""), priority)
+	})
+}
+
+func TestPriorityRank(t *testing.T) {
+	t.Run("when ranking priorities, Then order is semantic rather than alphabetical", func(t *testing.T) {
+		assert.Less(t, PriorityLow.Rank(), PriorityNormal.Rank())
+		assert.Less(t, PriorityNormal.Rank(), PriorityHigh.Rank())
+		assert.Less(t, PriorityHigh.Rank(), PriorityUrgent.Rank())
+		assert.Equal(t, 0, Priority("asap").Rank())
+	})
+}
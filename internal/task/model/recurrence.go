@@ -0,0 +1,94 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Recurrence repeats a task: completing an occurrence creates the next one.
+type Recurrence string
+
+const (
+	RecurrenceNone    Recurrence = ""
+	RecurrenceDaily   Recurrence = "daily"
+	RecurrenceWeekly  Recurrence = "weekly"
+	RecurrenceMonthly Recurrence = "monthly"
+)
+
+var (
+	ErrInvalidRecurrence = errors.New("recurrence must be one of daily, weekly, monthly")
+	ErrTaskNotRecurring  = errors.New("task is not recurring")
+)
+
+func ParseRecurrence(s string) (Recurrence, error) {
+	r := Recurrence(strings.ToLower(strings.TrimSpace(s)))
+	switch r {
+	case RecurrenceNone, RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly:
+		return r, nil
+	}
+	return RecurrenceNone, ErrInvalidRecurrence
+}
+
+func (r Recurrence) Next(t time.Time) time.Time {
+	switch r {
+	case RecurrenceDaily:
+		return t.AddDate(0, 0, 1)
+	case RecurrenceWeekly:
+		return t.AddDate(0, 0, 7)
+	case RecurrenceMonthly:
+		return t.AddDate(0, 1, 0)
+	}
+	return t
+}
+
+func (t *Task) IsRecurring() bool {
+	return t.Recurrence != RecurrenceNone
+}
+
+// SetRecurrence makes the task the first occurrence of a series. The series id
+// is kept when recurrence is turned off so that past occurrences stay linked.
+func (t *Task) SetRecurrence(r Recurrence) {
+	t.Recurrence = r
+	if r != RecurrenceNone && t.SeriesId == nil {
+		seriesId := t.TaskId
+		t.SeriesId = &seriesId
+	}
+}
+
+// NextOccurrence copies the task into the next open occurrence of its series
+// with the due date moved by the recurrence. The caller sets the task id.
+func (t *Task) NextOccurrence(now time.Time) (*Task, error) {
+	if !t.IsRecurring() || t.SeriesId == nil {
+		return nil, ErrTaskNotRecurring
+	}
+	next := &Task{
+		TaskName:    t.TaskName,
+		TaskDesc:    t.TaskDesc,
+		TaskType:    t.TaskType,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Priority:    t.Priority,
+		Category:    t.Category,
+		Timezone:    t.Timezone,
+		Quantity:    t.Quantity,
+		Unit:        t.Unit,
+		Aisle:       t.Aisle,
+		IsImportant: t.IsImportant,
+		CreatedBy:   t.CreatedBy,
+		AssigneeId:  t.AssigneeId,
+		Points:      t.Points,
+		Recurrence:  t.Recurrence,
+		SeriesId:    t.SeriesId,
+	}
+	if t.IsAllDay() {
+		day, err := time.Parse(DateLayout, t.DueDate)
+		if err != nil {
+			return nil, ErrInvalidDueDate
+		}
+		next.DueDate = t.Recurrence.Next(day).Format(DateLayout)
+	} else if !t.TargetDate.IsZero() {
+		next.TargetDate = t.Recurrence.Next(t.TargetDate)
+	}
+	return next, nil
+}
@@ -0,0 +1,31 @@
+package model
+
+import "errors"
+
+const (
+	DefaultStatsDays = 30
+	MaxStatsDays     = 365
+)
+
+var ErrInvalidStatsDays = errors.New("days must be between 1 and 365")
+
+// ContainerStats summarizes the tasks of a container, archived ones included.
+type ContainerStats struct {
+	Total     int `json:"total"`
+	Open      int `json:"open"`
+	Completed int `json:"completed"`
+	Overdue   int `json:"overdue"`
+	Important int `json:"important"`
+	// ByPriority and ByCategory count every task; tasks without a category are
+	// counted under an empty key.
+	ByPriority map[string]int `json:"by_priority"`
+	ByCategory map[string]int `json:"by_category"`
+	// AverageCompletionHours is nil when no task has been completed yet.
+	AverageCompletionHours *float64     `json:"average_completion_hours"`
+	DailyCompletions       []DailyCount `json:"daily_completions"`
+}
+
+type DailyCount struct {
+	Date  string `json:"date"`
+	Count int    `json:"count"`
+}
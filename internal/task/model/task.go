@@ -1,17 +1,202 @@
 package model
 
-import "time"
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Limits mirror the column sizes of the task table.
+const (
+	MaxTaskNameLength     = 100
+	MaxTaskDescLength     = 512
+	MaxTaskCategoryLength = 20
+)
+
+var (
+	ErrTaskNameEmpty            = errors.New("task name cannot be empty")
+	ErrTaskNameTooLong          = errors.New("task name cannot be longer than 100 characters")
+	ErrTaskDescTooLong          = errors.New("task description cannot be longer than 512 characters")
+	ErrTaskCategoryTooLong      = errors.New("task category cannot be longer than 20 characters")
+	ErrTargetDateBeforeCreation = errors.New("target date cannot be before the creation date of the task")
+	ErrTaskAlreadyCompleted     = errors.New("task is already completed")
+	ErrTaskNotCompleted         = errors.New("task is not completed")
+)
 
 type Task struct {
-	TaskId      string    `json:"id"`
-	TaskName    string    `json:"name"`
-	TaskDesc    string    `json:"description"`
-	TaskType    string    `json:"type"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	TargetDate  time.Time `json:"target_date"`
-	Priority    string    `json:"priority"`
-	Category    string    `json:"category"`
-	IsCompleted bool      `json:"is_completed"`
-	IsImportant bool      `json:"is_important"`
+	TaskId       string         `json:"id"`
+	TaskName     string         `json:"name"`
+	TaskDesc     string         `json:"description"`
+	TaskType     string         `json:"type"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	TargetDate   time.Time      `json:"target_date"`
+	DueDate      string         `json:"due_date,omitempty"`
+	Timezone     string         `json:"timezone"`
+	Priority     Priority       `json:"priority"`
+	Category     string         `json:"category"`
+	Quantity     *float64       `json:"quantity,omitempty"`
+	Unit         string         `json:"unit,omitempty"`
+	Aisle        string         `json:"aisle,omitempty"`
+	IsCompleted  bool           `json:"is_completed"`
+	IsImportant  bool           `json:"is_important"`
+	CompletedAt  *time.Time     `json:"completed_at,omitempty"`
+	SnoozedUntil *time.Time     `json:"snoozed_until,omitempty"`
+	ArchivedAt   *time.Time     `json:"archived_at,omitempty"`
+	CreatedBy    *int           `json:"created_by,omitempty"`
+	AssigneeId   *int           `json:"assignee_id,omitempty"`
+	Recurrence   Recurrence     `json:"recurrence,omitempty"`
+	Frequency    HabitFrequency `json:"frequency,omitempty"`
+	TargetCount  int            `json:"target_count,omitempty"`
+	Points       *int           `json:"points,omitempty"`
+	SeriesId     *string        `json:"series_id,omitempty"`
+
+	ContainerIds []string      `json:"container_ids"`
+	Mentions     []TaskMention `json:"mentions,omitempty"`
+
+	// Computed for the viewer by EvaluateDue; not stored.
+	Overdue  bool `json:"is_overdue"`
+	DueToday bool `json:"is_due_today"`
+}
+
+// NewTask validates the task fields. A zero target date means the task has no
+// due date; otherwise it cannot be before today unless allowPastTargetDate is set.
+func NewTask(name string, desc string, category string, priority Priority, targetDate time.Time, allowPastTargetDate bool) (*Task, error) {
+	now := time.Now()
+	task := &Task{
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Priority:    priority,
+		Timezone:    DefaultTimezone,
+		IsCompleted: false,
+		IsImportant: false,
+	}
+	if err := task.Rename(name); err != nil {
+		return nil, err
+	}
+	if err := task.Describe(desc); err != nil {
+		return nil, err
+	}
+	if err := task.Categorize(category); err != nil {
+		return nil, err
+	}
+	if err := task.Reschedule(targetDate, allowPastTargetDate); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (t *Task) Rename(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return ErrTaskNameEmpty
+	}
+	if utf8.RuneCountInString(name) > MaxTaskNameLength {
+		return ErrTaskNameTooLong
+	}
+	t.TaskName = name
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+func (t *Task) Describe(desc string) error {
+	if utf8.RuneCountInString(desc) > MaxTaskDescLength {
+		return ErrTaskDescTooLong
+	}
+	t.TaskDesc = desc
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+func (t *Task) Categorize(category string) error {
+	if utf8.RuneCountInString(category) > MaxTaskCategoryLength {
+		return ErrTaskCategoryTooLong
+	}
+	t.Category = category
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// Reschedule moves the timed target date and clears any all-day due date. The
+// date is compared by day, so a task created in the afternoon can still be due today.
+func (t *Task) Reschedule(targetDate time.Time, allowBeforeCreation bool) error {
+	if !targetDate.IsZero() && !allowBeforeCreation {
+		y, m, d := t.CreatedAt.In(targetDate.Location()).Date()
+		if targetDate.Before(time.Date(y, m, d, 0, 0, 0, 0, targetDate.Location())) {
+			return ErrTargetDateBeforeCreation
+		}
+	}
+	t.TargetDate = targetDate
+	t.DueDate = ""
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+func (t *Task) Complete(now time.Time) error {
+	if t.IsCompleted {
+		return ErrTaskAlreadyCompleted
+	}
+	t.IsCompleted = true
+	t.CompletedAt = &now
+	t.UpdatedAt = now
+	return nil
+}
+
+func (t *Task) Reopen(now time.Time) error {
+	if !t.IsCompleted {
+		return ErrTaskNotCompleted
+	}
+	t.IsCompleted = false
+	t.CompletedAt = nil
+	t.ArchivedAt = nil
+	t.UpdatedAt = now
+	return nil
+}
+
+func (t *Task) IsArchived() bool {
+	return t.ArchivedAt != nil
+}
+
+func (t *Task) IsInContainer(containerId string) bool {
+	for _, id := range t.ContainerIds {
+		if id == containerId {
+			return true
+		}
+	}
+	return false
+}
+
+// Snooze hides the task from the default listings until the given time.
+// The target date is only moved when moveTargetDate is set.
+func (t *Task) Snooze(until time.Time, now time.Time, moveTargetDate bool) error {
+	if !until.After(now) {
+		return errors.New("snooze time must be in the future")
+	}
+	t.SnoozedUntil = &until
+	if moveTargetDate {
+		if t.IsAllDay() {
+			loc, err := t.location()
+			if err != nil {
+				return err
+			}
+			t.DueDate = until.In(loc).Format(DateLayout)
+		} else {
+			t.TargetDate = until
+		}
+	}
+	t.UpdatedAt = now
+	return nil
+}
+
+func (t *Task) Unsnooze(now time.Time) error {
+	if !t.IsSnoozed(now) {
+		return errors.New("task is not snoozed")
+	}
+	t.SnoozedUntil = nil
+	t.UpdatedAt = now
+	return nil
+}
+
+func (t *Task) IsSnoozed(now time.Time) bool {
+	return t.SnoozedUntil != nil && t.SnoozedUntil.After(now)
 }
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+type TaskActivity struct {
+	Id        string    `json:"id"`
+	TaskId    string    `json:"task_id"`
+	UserId    *int      `json:"user_id,omitempty"`
+	Action    string    `json:"action"`
+	Detail    string    `json:"detail"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+const (
+	ActivitySnoozed   = "snoozed"
+	ActivityUnsnoozed = "unsnoozed"
+	ActivityReverted  = "reverted"
+	ActivityMerged    = "merged"
+	ActivityCheckedIn = "checked_in"
+)
+
+func NewTaskActivity(taskId string, userId *int, action string, detail string) (*TaskActivity, error) {
+	if taskId == "" {
+		return nil, errors.New("task id cannot be empty")
+	}
+	if action == "" {
+		return nil, errors.New("activity action cannot be empty")
+	}
+	return &TaskActivity{
+		TaskId:    taskId,
+		UserId:    userId,
+		Action:    action,
+		Detail:    detail,
+		CreatedAt: time.Now(),
+	}, nil
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+)
+
+type TaskMention struct {
+	UserId   int    `json:"user_id"`
+	Username string `json:"username"`
+}
+
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([A-Za-z0-9_.\-]+)`)
+
+// ParseMentions returns the distinct usernames mentioned with @username in the
+// given text, in order of first appearance. Email addresses are not mentions.
+func ParseMentions(text string) []string {
+	usernames := []string{}
+	seen := map[string]bool{}
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		username := strings.TrimRight(match[1], ".-")
+		if username == "" || seen[username] {
+			continue
+		}
+		seen[username] = true
+		usernames = append(usernames, username)
+	}
+	return usernames
+}
+
+// NewMentions returns the mentions in current that are not in previous.
+func NewMentions(previous []TaskMention, current []TaskMention) []TaskMention {
+	existing := map[int]bool{}
+	for _, m := range previous {
+		existing[m.UserId] = true
+	}
+	added := []TaskMention{}
+	for _, m := range current {
+		if !existing[m.UserId] {
+			added = append(added, m)
+		}
+	}
+	return added
+}
@@ -0,0 +1,9 @@
+package model
+
+// TaskPlacement is where a task sits on the board of one of its containers.
+// Tasks without a section are listed apart from the sections.
+type TaskPlacement struct {
+	TaskId    string  `json:"task_id"`
+	SectionId *string `json:"section_id,omitempty"`
+	Position  int     `json:"position"`
+}
@@ -0,0 +1,150 @@
+package model
+
+import (
+	"errors"
+	"net/mail"
+	"net/url"
+	"time"
+)
+
+// MaxReminderAttempts is how many times delivery of a reminder is tried before
+// it is marked as failed. Retries back off from ReminderRetryDelay, doubling
+// after each attempt.
+const (
+	MaxReminderAttempts = 5
+	ReminderRetryDelay  = time.Minute
+)
+
+const (
+	ReminderChannelInApp   = "in_app"
+	ReminderChannelWebhook = "webhook"
+	ReminderChannelEmail   = "email"
+)
+
+var (
+	ErrReminderInvalidChannel = errors.New("reminder channel must be one of in_app, webhook, email")
+	ErrReminderInvalidTarget  = errors.New("reminder target must be a https url for webhook or an email address for email")
+	ErrReminderInPast         = errors.New("reminder time must be in the future")
+	ErrReminderNoDueDate      = errors.New("offset reminder requires the task to have a due date")
+	ErrReminderNegativeOffset = errors.New("reminder offset cannot be negative")
+)
+
+// TaskReminder fires once at RemindAt. Offset reminders keep OffsetMinutes so
+// RemindAt can be recomputed when the task's due date moves.
+type TaskReminder struct {
+	Id            string     `json:"id"`
+	TaskId        string     `json:"task_id"`
+	UserId        int        `json:"user_id"`
+	Channel       string     `json:"channel"`
+	Target        string     `json:"target,omitempty"`
+	OffsetMinutes *int       `json:"offset_minutes,omitempty"`
+	RemindAt      time.Time  `json:"remind_at"`
+	FiredAt       *time.Time `json:"fired_at,omitempty"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	FailedAt      *time.Time `json:"failed_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+func NewAbsoluteReminder(taskId string, userId int, channel string, target string, remindAt time.Time, now time.Time) (*TaskReminder, error) {
+	if err := validateReminderChannel(channel, target); err != nil {
+		return nil, err
+	}
+	if !remindAt.After(now) {
+		return nil, ErrReminderInPast
+	}
+	return &TaskReminder{
+		TaskId:    taskId,
+		UserId:    userId,
+		Channel:   channel,
+		Target:    target,
+		RemindAt:  remindAt,
+		CreatedAt: now,
+	}, nil
+}
+
+func NewOffsetReminder(task Task, userId int, channel string, target string, offset time.Duration, now time.Time) (*TaskReminder, error) {
+	if err := validateReminderChannel(channel, target); err != nil {
+		return nil, err
+	}
+	if offset < 0 {
+		return nil, ErrReminderNegativeOffset
+	}
+	minutes := int(offset / time.Minute)
+	reminder := &TaskReminder{
+		TaskId:        task.TaskId,
+		UserId:        userId,
+		Channel:       channel,
+		Target:        target,
+		OffsetMinutes: &minutes,
+		CreatedAt:     now,
+	}
+	if err := reminder.Reschedule(task); err != nil {
+		return nil, err
+	}
+	if !reminder.RemindAt.After(now) {
+		return nil, ErrReminderInPast
+	}
+	return reminder, nil
+}
+
+func (r *TaskReminder) IsOffset() bool {
+	return r.OffsetMinutes != nil
+}
+
+// Reschedule recomputes an offset reminder from the task's current due date.
+// Absolute reminders are left untouched.
+func (r *TaskReminder) Reschedule(task Task) error {
+	if !r.IsOffset() {
+		return nil
+	}
+	due, ok := task.DueStart()
+	if !ok {
+		return ErrReminderNoDueDate
+	}
+	r.RemindAt = due.Add(-time.Duration(*r.OffsetMinutes) * time.Minute)
+	return nil
+}
+
+// RecordFailure counts a failed delivery. It schedules the next attempt with
+// exponential backoff, or marks the reminder as failed once MaxReminderAttempts
+// is reached.
+func (r *TaskReminder) RecordFailure(now time.Time) {
+	r.Attempts++
+	if r.Attempts >= MaxReminderAttempts {
+		r.MarkFailed(now)
+		return
+	}
+	next := now.Add(ReminderRetryDelay << (r.Attempts - 1))
+	r.NextAttemptAt = &next
+}
+
+// MarkFailed gives up on the reminder; it is not delivered again.
+func (r *TaskReminder) MarkFailed(now time.Time) {
+	r.NextAttemptAt = nil
+	r.FailedAt = &now
+}
+
+func (r *TaskReminder) HasFailed() bool {
+	return r.FailedAt != nil
+}
+
+func validateReminderChannel(channel string, target string) error {
+	switch channel {
+	case ReminderChannelInApp:
+		return nil
+	case ReminderChannelWebhook:
+		u, err := url.Parse(target)
+		if err != nil || u.Scheme != "https" || u.Hostname() == "" {
+			return ErrReminderInvalidTarget
+		}
+		return nil
+	case ReminderChannelEmail:
+		if _, err := mail.ParseAddress(target); err != nil {
+			return ErrReminderInvalidTarget
+		}
+		return nil
+	default:
+		return ErrReminderInvalidChannel
+	}
+}
@@ -0,0 +1,135 @@
+package model
+
+import "time"
+
+// TaskSnapshot holds the fields that UpdateTask replaces. A revision stores one
+// snapshot so that a full-replace update can be reviewed and reverted.
+type TaskSnapshot struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Category    string     `json:"category"`
+	Priority    Priority   `json:"priority"`
+	TargetDate  *time.Time `json:"target_date,omitempty"`
+	DueDate     string     `json:"due_date,omitempty"`
+	Timezone    string     `json:"timezone"`
+	Quantity    *float64   `json:"quantity,omitempty"`
+	Unit        string     `json:"unit,omitempty"`
+	Aisle       string     `json:"aisle,omitempty"`
+}
+
+type TaskRevision struct {
+	TaskId    string       `json:"task_id"`
+	Number    int          `json:"number"`
+	Snapshot  TaskSnapshot `json:"snapshot"`
+	EditedBy  *int         `json:"edited_by,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type FieldChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+func (t *Task) Snapshot() TaskSnapshot {
+	snapshot := TaskSnapshot{
+		Name:        t.TaskName,
+		Description: t.TaskDesc,
+		Category:    t.Category,
+		Priority:    t.Priority,
+		DueDate:     t.DueDate,
+		Timezone:    t.Timezone,
+		Quantity:    t.Quantity,
+		Unit:        t.Unit,
+		Aisle:       t.Aisle,
+	}
+	if !t.TargetDate.IsZero() {
+		targetDate := t.TargetDate
+		snapshot.TargetDate = &targetDate
+	}
+	return snapshot
+}
+
+// Restore puts the task back to a snapshot. Dates are restored as they were,
+// even if they are in the past by now.
+func (t *Task) Restore(snapshot TaskSnapshot) error {
+	if err := t.Rename(snapshot.Name); err != nil {
+		return err
+	}
+	if err := t.Describe(snapshot.Description); err != nil {
+		return err
+	}
+	if err := t.Categorize(snapshot.Category); err != nil {
+		return err
+	}
+	if err := t.SetTimezone(snapshot.Timezone); err != nil {
+		return err
+	}
+	t.Priority = snapshot.Priority
+	t.TargetDate = time.Time{}
+	if snapshot.TargetDate != nil {
+		t.TargetDate = *snapshot.TargetDate
+	}
+	t.DueDate = snapshot.DueDate
+	t.Quantity = snapshot.Quantity
+	t.Unit = snapshot.Unit
+	t.Aisle = snapshot.Aisle
+	return nil
+}
+
+// Diff lists the fields that changed from previous to s. A nil previous means
+// s is the first revision, so every field that has a value is reported.
+func (s TaskSnapshot) Diff(previous *TaskSnapshot) []FieldChange {
+	if previous == nil {
+		previous = &TaskSnapshot{}
+	}
+	changes := []FieldChange{}
+	add := func(field string, from any, to any) {
+		changes = append(changes, FieldChange{Field: field, From: from, To: to})
+	}
+	if previous.Name != s.Name {
+		add("name", previous.Name, s.Name)
+	}
+	if previous.Description != s.Description {
+		add("description", previous.Description, s.Description)
+	}
+	if previous.Category != s.Category {
+		add("category", previous.Category, s.Category)
+	}
+	if previous.Priority != s.Priority {
+		add("priority", previous.Priority, s.Priority)
+	}
+	if !sameTime(previous.TargetDate, s.TargetDate) {
+		add("target_date", previous.TargetDate, s.TargetDate)
+	}
+	if previous.DueDate != s.DueDate {
+		add("due_date", previous.DueDate, s.DueDate)
+	}
+	if previous.Timezone != s.Timezone {
+		add("timezone", previous.Timezone, s.Timezone)
+	}
+	if !sameQuantity(previous.Quantity, s.Quantity) {
+		add("quantity", previous.Quantity, s.Quantity)
+	}
+	if previous.Unit != s.Unit {
+		add("unit", previous.Unit, s.Unit)
+	}
+	if previous.Aisle != s.Aisle {
+		add("aisle", previous.Aisle, s.Aisle)
+	}
+	return changes
+}
+
+func sameTime(a *time.Time, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func sameQuantity(a *float64, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,480 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskSnooze(t *testing.T) {
+	t.Run("when snoozing task to a future time, Then snoozed until is set and target date is kept", func(t *testing.T) {
+		// Given
+		now := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+		targetDate := now.Add(48 * time.Hour)
+		task := Task{TaskId: "task-1", TargetDate: targetDate}
+		until := now.Add(3 * time.Hour)
+
+		// When
+		err := task.Snooze(until, now, false)
+
+		// Then
+		require.NoError(t, err)
+		require.NotNil(t, task.SnoozedUntil)
+		assert.Equal(t, until, *task.SnoozedUntil)
+		assert.Equal(t, targetDate, task.TargetDate)
+		assert.True(t, task.IsSnoozed(now))
+		assert.False(t, task.IsSnoozed(until))
+	})
+
+	t.Run("when snoozing task with moving target date, Then target date is changed to snoozed until", func(t *testing.T) {
+		// Given
+		now := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+		task := Task{TaskId: "task-1", TargetDate: now}
+		until := now.Add(24 * time.Hour)
+
+		// When
+		err := task.Snooze(until, now, true)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, until, task.TargetDate)
+	})
+
+	t.Run("when snoozing task to a past time, Then return error", func(t *testing.T) {
+		// Given
+		now := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+		task := Task{TaskId: "task-1"}
+
+		// When
+		err := task.Snooze(now.Add(-time.Minute), now, false)
+
+		// Then
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "snooze time must be in the future")
+		assert.Nil(t, task.SnoozedUntil)
+	})
+
+	t.Run("when unsnoozing snoozed task, Then snoozed until is cleared", func(t *testing.T) {
+		// Given
+		now := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+		task := Task{TaskId: "task-1"}
+		require.NoError(t, task.Snooze(now.Add(time.Hour), now, false))
+
+		// When
+		err := task.Unsnooze(now)
+
+		// Then
+		require.NoError(t, err)
+		assert.Nil(t, task.SnoozedUntil)
+	})
+
+	t.Run("when unsnoozing task that is not snoozed, Then return error", func(t *testing.T) {
+		// Given
+		task := Task{TaskId: "task-1"}
+
+		// When
+		err := task.Unsnooze(time.Now())
+
+		// Then
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "task is not snoozed")
+	})
+}
+
+func TestNewTaskActivity(t *testing.T) {
+	t.Run("when creating task activity with valid data, Then return activity", func(t *testing.T) {
+		// Given
+		userId := 1
+
+		// When
+		activity, err := NewTaskActivity("task-1", &userId, ActivitySnoozed, "detail")
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, "task-1", activity.TaskId)
+		assert.Equal(t, &userId, activity.UserId)
+		assert.Equal(t, ActivitySnoozed, activity.Action)
+		assert.NotZero(t, activity.CreatedAt)
+	})
+
+	t.Run("when creating task activity without action, Then return error", func(t *testing.T) {
+		// When
+		activity, err := NewTaskActivity("task-1", nil, "", "")
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, activity)
+	})
+}
+
+func TestTaskWatchers(t *testing.T) {
+	t.Run("when task has a creator, Then creator is an implicit watcher listed once", func(t *testing.T) {
+		// Given
+		creatorId := 1
+		task := Task{TaskId: "task-1", CreatedBy: &creatorId}
+		explicit := []TaskWatcher{
+			{TaskId: "task-1", UserId: 1},
+			{TaskId: "task-1", UserId: 2},
+		}
+
+		// When
+		watchers := task.Watchers(explicit, true)
+
+		// Then
+		require.Len(t, watchers, 2)
+		assert.Equal(t, 1, watchers[0].UserId)
+		assert.True(t, watchers[0].IsCreator)
+		assert.Equal(t, 2, watchers[1].UserId)
+		assert.False(t, watchers[1].IsCreator)
+	})
+
+	t.Run("when task has no creator, Then only explicit watchers are returned", func(t *testing.T) {
+		// Given
+		task := Task{TaskId: "task-1"}
+
+		// When
+		watchers := task.Watchers([]TaskWatcher{{TaskId: "task-1", UserId: 3}}, true)
+
+		// Then
+		require.Len(t, watchers, 1)
+		assert.Equal(t, 3, watchers[0].UserId)
+	})
+
+	t.Run("when creator has left the group, Then creator is not a watcher", func(t *testing.T) {
+		// Given
+		creatorId := 1
+		task := Task{TaskId: "task-1", CreatedBy: &creatorId}
+
+		// When
+		watchers := task.Watchers([]TaskWatcher{{TaskId: "task-1", UserId: 2}}, false)
+
+		// Then
+		require.Len(t, watchers, 1)
+		assert.Equal(t, 2, watchers[0].UserId)
+	})
+}
+
+func TestParseMentions(t *testing.T) {
+	t.Run("when description has mentions, Then return distinct usernames in order", func(t *testing.T) {
+		// When
+		usernames := ParseMentions("@macyhorvath17 can you buy apples? cc @hyunbin7303, @macyhorvath17.")
+
+		// Then
+		assert.Equal(t, []string{"macyhorvath17", "hyunbin7303"}, usernames)
+	})
+
+	t.Run("when description has email address, Then it is not a mention", func(t *testing.T) {
+		// When
+		usernames := ParseMentions("send it to testing1@hproject.com")
+
+		// Then
+		assert.Empty(t, usernames)
+	})
+
+	t.Run("when description has no mentions, Then return empty", func(t *testing.T) {
+		// When
+		usernames := ParseMentions("need this for apple pie")
+
+		// Then
+		assert.Empty(t, usernames)
+	})
+}
+
+func TestNewMentions(t *testing.T) {
+	t.Run("when mentions are added, Then only the new ones are returned", func(t *testing.T) {
+		// Given
+		previous := []TaskMention{{UserId: 1, Username: "a"}}
+		current := []TaskMention{{UserId: 1, Username: "a"}, {UserId: 2, Username: "b"}}
+
+		// When
+		added := NewMentions(previous, current)
+
+		// Then
+		require.Len(t, added, 1)
+		assert.Equal(t, 2, added[0].UserId)
+	})
+}
+
+func TestTaskIsInContainer(t *testing.T) {
+	t.Run("when task is linked to several containers, Then each of them is reported", func(t *testing.T) {
+		// Given
+		task := Task{TaskId: "task-1", ContainerIds: []string{"grocery", "party-prep"}}
+
+		// Then
+		assert.True(t, task.IsInContainer("grocery"))
+		assert.True(t, task.IsInContainer("party-prep"))
+		assert.False(t, task.IsInContainer("chores"))
+	})
+}
+
+func TestNewTask(t *testing.T) {
+	t.Run("when creating new task with valid data, Then return task with correct fields", func(t *testing.T) {
+		// Given
+		targetDate := time.Now().Add(72 * time.Hour)
+
+		// When
+		task, err := NewTask("Apple", "need this for apple pie", "grocery", PriorityHigh, targetDate, false)
+
+		// Then
+		require.NoError(t, err)
+		require.NotNil(t, task)
+		assert.Equal(t, "Apple", task.TaskName)
+		assert.Equal(t, "need this for apple pie", task.TaskDesc)
+		assert.Equal(t, "grocery", task.Category)
+		assert.Equal(t, PriorityHigh, task.Priority)
+		assert.Equal(t, targetDate, task.TargetDate)
+		assert.False(t, task.IsCompleted)
+		assert.NotZero(t, task.CreatedAt)
+	})
+
+	t.Run("when creating new task with empty name, Then return error", func(t *testing.T) {
+		// When
+		task, err := NewTask("   ", "", "", PriorityNormal, time.Time{}, false)
+
+		// Then
+		assert.ErrorIs(t, err, ErrTaskNameEmpty)
+		assert.Nil(t, task)
+	})
+
+	t.Run("when creating new task with name longer than 100 characters, Then return error", func(t *testing.T) {
+		// When
+		task, err := NewTask(strings.Repeat("a", MaxTaskNameLength+1), "", "", PriorityNormal, time.Time{}, false)
+
+		// Then
+		assert.ErrorIs(t, err, ErrTaskNameTooLong)
+		assert.Nil(t, task)
+	})
+
+	t.Run("when creating new task with name of 100 multibyte characters, Then return task", func(t *testing.T) {
+		// When
+		task, err := NewTask(strings.Repeat("김", MaxTaskNameLength), "", "", PriorityNormal, time.Time{}, false)
+
+		// Then
+		require.NoError(t, err)
+		assert.NotNil(t, task)
+	})
+
+	t.Run("when creating new task with description longer than 512 characters, Then return error", func(t *testing.T) {
+		// When
+		task, err := NewTask("Apple", strings.Repeat("a", MaxTaskDescLength+1), "", PriorityNormal, time.Time{}, false)
+
+		// Then
+		assert.ErrorIs(t, err, ErrTaskDescTooLong)
+		assert.Nil(t, task)
+	})
+
+	t.Run("when creating new task with target date before today, Then return error", func(t *testing.T) {
+		// When
+		task, err := NewTask("Apple", "", "", PriorityNormal, time.Now().Add(-48*time.Hour), false)
+
+		// Then
+		assert.ErrorIs(t, err, ErrTargetDateBeforeCreation)
+		assert.Nil(t, task)
+	})
+
+	t.Run("when creating new task with past target date that is allowed, Then return task", func(t *testing.T) {
+		// When
+		task, err := NewTask("Apple", "", "", PriorityNormal, time.Now().Add(-48*time.Hour), true)
+
+		// Then
+		require.NoError(t, err)
+		assert.NotNil(t, task)
+	})
+
+	t.Run("when creating new task due earlier today, Then return task", func(t *testing.T) {
+		// Given
+		y, m, d := time.Now().Date()
+		startOfToday := time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+
+		// When
+		task, err := NewTask("Apple", "", "", PriorityNormal, startOfToday, false)
+
+		// Then
+		require.NoError(t, err)
+		assert.NotNil(t, task)
+	})
+}
+
+func TestTaskCompletion(t *testing.T) {
+	t.Run("when completing open task, Then task is completed with completion time", func(t *testing.T) {
+		// Given
+		now := time.Now()
+		task := Task{TaskId: "task-1"}
+
+		// When
+		err := task.Complete(now)
+
+		// Then
+		require.NoError(t, err)
+		assert.True(t, task.IsCompleted)
+		assert.Equal(t, &now, task.CompletedAt)
+	})
+
+	t.Run("when completing completed task, Then return error", func(t *testing.T) {
+		// Given
+		task := Task{TaskId: "task-1", IsCompleted: true}
+
+		// When
+		err := task.Complete(time.Now())
+
+		// Then
+		assert.ErrorIs(t, err, ErrTaskAlreadyCompleted)
+	})
+
+	t.Run("when reopening completed task, Then completion time is cleared", func(t *testing.T) {
+		// Given
+		task := Task{TaskId: "task-1"}
+		require.NoError(t, task.Complete(time.Now()))
+
+		// When
+		err := task.Reopen(time.Now())
+
+		// Then
+		require.NoError(t, err)
+		assert.False(t, task.IsCompleted)
+		assert.Nil(t, task.CompletedAt)
+	})
+
+	t.Run("when reopening archived task, Then task is restored from the archive", func(t *testing.T) {
+		// Given
+		archivedAt := time.Now()
+		task := Task{TaskId: "task-1", IsCompleted: true, ArchivedAt: &archivedAt}
+
+		// When
+		err := task.Reopen(time.Now())
+
+		// Then
+		require.NoError(t, err)
+		assert.False(t, task.IsArchived())
+	})
+
+	t.Run("when reopening open task, Then return error", func(t *testing.T) {
+		// Given
+		task := Task{TaskId: "task-1"}
+
+		// When
+		err := task.Reopen(time.Now())
+
+		// Then
+		assert.ErrorIs(t, err, ErrTaskNotCompleted)
+	})
+}
+
+func TestTaskReminder(t *testing.T) {
+	now := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+
+	t.Run("when creating offset reminder, Then remind at is computed from target date", func(t *testing.T) {
+		// Given
+		task := Task{TaskId: "task-1", TargetDate: now.Add(48 * time.Hour)}
+
+		// When
+		reminder, err := NewOffsetReminder(task, 1, ReminderChannelInApp, "", 30*time.Minute, now)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, now.Add(48*time.Hour-30*time.Minute), reminder.RemindAt)
+		require.NotNil(t, reminder.OffsetMinutes)
+		assert.Equal(t, 30, *reminder.OffsetMinutes)
+	})
+
+	t.Run("when target date moves, Then offset reminder moves with it", func(t *testing.T) {
+		// Given
+		task := Task{TaskId: "task-1", TargetDate: now.Add(48 * time.Hour)}
+		reminder, err := NewOffsetReminder(task, 1, ReminderChannelInApp, "", time.Hour, now)
+		require.NoError(t, err)
+		task.TargetDate = now.Add(72 * time.Hour)
+
+		// When
+		err = reminder.Reschedule(task)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, now.Add(71*time.Hour), reminder.RemindAt)
+	})
+
+	t.Run("when creating offset reminder for task without due date, Then return error", func(t *testing.T) {
+		// When
+		reminder, err := NewOffsetReminder(Task{TaskId: "task-1"}, 1, ReminderChannelInApp, "", time.Hour, now)
+
+		// Then
+		assert.ErrorIs(t, err, ErrReminderNoDueDate)
+		assert.Nil(t, reminder)
+	})
+
+	t.Run("when creating absolute reminder in the past, Then return error", func(t *testing.T) {
+		// When
+		reminder, err := NewAbsoluteReminder("task-1", 1, ReminderChannelInApp, "", now.Add(-time.Minute), now)
+
+		// Then
+		assert.ErrorIs(t, err, ErrReminderInPast)
+		assert.Nil(t, reminder)
+	})
+
+	t.Run("when creating webhook reminder without url, Then return error", func(t *testing.T) {
+		// When
+		reminder, err := NewAbsoluteReminder("task-1", 1, ReminderChannelWebhook, "not a url", now.Add(time.Hour), now)
+
+		// Then
+		assert.ErrorIs(t, err, ErrReminderInvalidTarget)
+		assert.Nil(t, reminder)
+	})
+
+	t.Run("when creating reminder with unknown channel, Then return error", func(t *testing.T) {
+		// When
+		reminder, err := NewAbsoluteReminder("task-1", 1, "sms", "", now.Add(time.Hour), now)
+
+		// Then
+		assert.ErrorIs(t, err, ErrReminderInvalidChannel)
+		assert.Nil(t, reminder)
+	})
+}
+
+func TestTaskRevision(t *testing.T) {
+	t.Run("when description is wiped, Then diff reports only the description", func(t *testing.T) {
+		// Given
+		task := Task{TaskId: "task-1", TaskName: "Apple", TaskDesc: "need this for apple pie", Priority: PriorityNormal, Timezone: DefaultTimezone}
+		previous := task.Snapshot()
+		task.TaskDesc = ""
+
+		// When
+		changes := task.Snapshot().Diff(&previous)
+
+		// Then
+		require.Len(t, changes, 1)
+		assert.Equal(t, "description", changes[0].Field)
+		assert.Equal(t, "need this for apple pie", changes[0].From)
+		assert.Equal(t, "", changes[0].To)
+	})
+
+	t.Run("when first revision, Then every field with a value is reported", func(t *testing.T) {
+		// Given
+		task := Task{TaskId: "task-1", TaskName: "Apple", Priority: PriorityNormal, Timezone: DefaultTimezone}
+
+		// When
+		changes := task.Snapshot().Diff(nil)
+
+		// Then
+		assert.Len(t, changes, 3)
+	})
+
+	t.Run("when restoring a snapshot with a past target date, Then task is restored as it was", func(t *testing.T) {
+		// Given
+		targetDate := time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC)
+		original := Task{TaskId: "task-1", TaskName: "Apple", TaskDesc: "need this for apple pie", TargetDate: targetDate, Priority: PriorityHigh, Timezone: DefaultTimezone}
+		snapshot := original.Snapshot()
+		task := Task{TaskId: "task-1", TaskName: "Apple", DueDate: "2025-01-10", CreatedAt: time.Now(), Timezone: DefaultTimezone}
+
+		// When
+		err := task.Restore(snapshot)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, "need this for apple pie", task.TaskDesc)
+		assert.Equal(t, PriorityHigh, task.Priority)
+		assert.Equal(t, targetDate, task.TargetDate)
+		assert.False(t, task.IsAllDay())
+	})
+}
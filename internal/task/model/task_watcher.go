@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// TaskWatcher is a group member following a task. The creator of a task is an
+// implicit watcher and is reported with IsCreator set.
+type TaskWatcher struct {
+	TaskId    string    `json:"task_id"`
+	UserId    int       `json:"user_id"`
+	IsCreator bool      `json:"is_creator"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Watchers merges the explicit watchers with the task creator. A creator who
+// has left the group is no longer a watcher.
+func (t *Task) Watchers(explicit []TaskWatcher, creatorIsMember bool) []TaskWatcher {
+	watchers := []TaskWatcher{}
+	if t.CreatedBy != nil && creatorIsMember {
+		watchers = append(watchers, TaskWatcher{TaskId: t.TaskId, UserId: *t.CreatedBy, IsCreator: true, CreatedAt: t.CreatedAt})
+	}
+	for _, w := range explicit {
+		if t.CreatedBy != nil && w.UserId == *t.CreatedBy {
+			continue
+		}
+		watchers = append(watchers, w)
+	}
+	return watchers
+}
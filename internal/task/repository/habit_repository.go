@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/happYness-Project/taskManagementGolang/internal/task/model"
+)
+
+type HabitRepository interface {
+	CreateCheckIn(checkIn model.HabitCheckIn) (bool, error)
+	DeleteCheckIn(taskId string, date string) error
+	GetCheckInDates(taskId string) ([]string, error)
+	GetCheckInDatesBetween(taskId string, from string, to string) ([]string, error)
+}
+
+type HabitRepo struct {
+	DB *sql.DB
+}
+
+func NewHabitRepository(db *sql.DB) *HabitRepo {
+	return &HabitRepo{DB: db}
+}
+
+// CreateCheckIn returns false when the habit was already checked in on that date.
+func (m *HabitRepo) CreateCheckIn(checkIn model.HabitCheckIn) (bool, error) {
+	result, err := m.DB.Exec(sqlCreateCheckIn, checkIn.TaskId, checkIn.Date, checkIn.UserId, checkIn.CreatedAt)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// DeleteCheckIn returns sql.ErrNoRows when there was no check-in on that date.
+func (m *HabitRepo) DeleteCheckIn(taskId string, date string) error {
+	result, err := m.DB.Exec(sqlDeleteCheckIn, taskId, date)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (m *HabitRepo) GetCheckInDates(taskId string) ([]string, error) {
+	return m.queryDates(sqlGetCheckInDates, taskId)
+}
+
+func (m *HabitRepo) GetCheckInDatesBetween(taskId string, from string, to string) ([]string, error) {
+	return m.queryDates(sqlGetCheckInsRange, taskId, from, to)
+}
+
+func (m *HabitRepo) queryDates(query string, args ...any) ([]string, error) {
+	rows, err := m.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dates := []string{}
+	for rows.Next() {
+		var date string
+		if err := rows.Scan(&date); err != nil {
+			return nil, err
+		}
+		dates = append(dates, date)
+	}
+	return dates, nil
+}
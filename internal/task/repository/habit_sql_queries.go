@@ -0,0 +1,12 @@
+package repository
+
+const (
+	sqlCreateCheckIn = `INSERT INTO public.habit_check_in(task_id, check_in_date, user_id, created_at)
+						VALUES ($1, $2, $3, $4)
+						ON CONFLICT (task_id, check_in_date) DO NOTHING`
+	sqlDeleteCheckIn    = `DELETE FROM public.habit_check_in WHERE task_id = $1 AND check_in_date = $2`
+	sqlGetCheckInDates  = `SELECT to_char(check_in_date, 'YYYY-MM-DD') FROM public.habit_check_in WHERE task_id = $1 ORDER BY check_in_date`
+	sqlGetCheckInsRange = `SELECT to_char(check_in_date, 'YYYY-MM-DD') FROM public.habit_check_in
+							WHERE task_id = $1 AND check_in_date BETWEEN $2 AND $3
+							ORDER BY check_in_date`
+)
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/happYness-Project/taskManagementGolang/internal/task/model"
+)
+
+type PointsRepository interface {
+	GetLeaderboard(groupId int, since *time.Time) ([]model.LeaderboardEntry, error)
+	GetAwardDays(groupId int) (map[int][]string, error)
+}
+
+type PointsRepo struct {
+	DB *sql.DB
+}
+
+func NewPointsRepository(db *sql.DB) *PointsRepo {
+	return &PointsRepo{DB: db}
+}
+
+// GetLeaderboard sums the points of every group member awarded since the given
+// time, or over all time when since is nil.
+func (m *PointsRepo) GetLeaderboard(groupId int, since *time.Time) ([]model.LeaderboardEntry, error) {
+	rows, err := m.DB.Query(sqlGetLeaderboard, groupId, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []model.LeaderboardEntry{}
+	for rows.Next() {
+		var entry model.LeaderboardEntry
+		if err := rows.Scan(&entry.UserId, &entry.UserName, &entry.Timezone, &entry.Points, &entry.Completed); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// GetAwardDays returns, per user, the days on which they completed at least one task.
+func (m *PointsRepo) GetAwardDays(groupId int) (map[int][]string, error) {
+	rows, err := m.DB.Query(sqlGetAwardDays, groupId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	days := map[int][]string{}
+	for rows.Next() {
+		var userId int
+		var day string
+		if err := rows.Scan(&userId, &day); err != nil {
+			return nil, err
+		}
+		days[userId] = append(days[userId], day)
+	}
+	return days, nil
+}
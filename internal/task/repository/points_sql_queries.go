@@ -0,0 +1,20 @@
+package repository
+
+const (
+	// Awards are written in the completion transaction of the task, see DoneTask.
+	sqlCreatePointAward = `INSERT INTO public.task_point_award(task_id, user_id, usergroup_id, points, awarded_at, awarded_on)
+							VALUES ($1, $2, $3, $4, $5, $6)
+							ON CONFLICT (task_id) DO NOTHING`
+	sqlDeletePointAward = `DELETE FROM public.task_point_award WHERE task_id = $1`
+	// Every member of the group is listed, including the ones without points in the period.
+	sqlGetLeaderboard = `SELECT u.id, COALESCE(u.username, ''), u.timezone, COALESCE(SUM(a.points), 0), COUNT(a.task_id)
+							FROM public.usergroup_user ugu
+							INNER JOIN public.user u ON u.id = ugu.user_id
+							LEFT JOIN public.task_point_award a ON a.user_id = u.id AND a.usergroup_id = ugu.usergroup_id
+								AND ($2::timestamptz IS NULL OR a.awarded_at >= $2)
+							WHERE ugu.usergroup_id = $1
+							GROUP BY u.id, u.username, u.timezone`
+	sqlGetAwardDays = `SELECT DISTINCT user_id, to_char(awarded_on, 'YYYY-MM-DD')
+						FROM public.task_point_award
+						WHERE usergroup_id = $1`
+)
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/happYness-Project/taskManagementGolang/internal/task/model"
+)
+
+type ReminderRepository interface {
+	CreateReminder(reminder model.TaskReminder) (model.TaskReminder, error)
+	GetRemindersByTaskId(taskId string, userId int) ([]model.TaskReminder, error)
+	GetOffsetRemindersByTaskId(taskId string) ([]model.TaskReminder, error)
+	UpdateReminderRemindAt(id string, remindAt time.Time) error
+	DeleteReminder(taskId string, id string, userId int) error
+	GetPendingReminders(now time.Time, limit int) ([]model.TaskReminder, error)
+	ClaimReminder(id string, firedAt time.Time) (bool, error)
+	ReleaseReminder(reminder model.TaskReminder) error
+	FailReminder(reminder model.TaskReminder) error
+}
+
+type ReminderRepo struct {
+	DB *sql.DB
+}
+
+func NewReminderRepository(db *sql.DB) *ReminderRepo {
+	return &ReminderRepo{DB: db}
+}
+
+func (m *ReminderRepo) CreateReminder(reminder model.TaskReminder) (model.TaskReminder, error) {
+	err := m.DB.QueryRow(sqlCreateReminder, reminder.TaskId, reminder.UserId, reminder.Channel, reminder.Target,
+		reminder.OffsetMinutes, reminder.RemindAt, reminder.CreatedAt).Scan(&reminder.Id)
+	if err != nil {
+		return reminder, fmt.Errorf("unable to insert into task_reminder table : %w", err)
+	}
+	return reminder, nil
+}
+
+func (m *ReminderRepo) GetRemindersByTaskId(taskId string, userId int) ([]model.TaskReminder, error) {
+	return m.queryReminders(sqlGetRemindersByTaskId, taskId, userId)
+}
+
+func (m *ReminderRepo) GetOffsetRemindersByTaskId(taskId string) ([]model.TaskReminder, error) {
+	return m.queryReminders(sqlGetOffsetRemindersByTaskId, taskId)
+}
+
+func (m *ReminderRepo) UpdateReminderRemindAt(id string, remindAt time.Time) error {
+	_, err := m.DB.Exec(sqlUpdateReminderRemindAt, id, remindAt)
+	return err
+}
+
+// DeleteReminder returns sql.ErrNoRows when the reminder does not exist or
+// belongs to another user.
+func (m *ReminderRepo) DeleteReminder(taskId string, id string, userId int) error {
+	result, err := m.DB.Exec(sqlDeleteReminder, id, taskId, userId)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// GetPendingReminders returns unfired reminders that are due at now, including
+// the ones that were missed while the server was down. Reminders waiting for a
+// retry are due at their next attempt; failed reminders are skipped.
+func (m *ReminderRepo) GetPendingReminders(now time.Time, limit int) ([]model.TaskReminder, error) {
+	return m.queryReminders(sqlGetPendingReminders, now, limit)
+}
+
+// ClaimReminder marks the reminder as fired. It returns false when another
+// scheduler already claimed it, so each reminder is delivered at most once.
+func (m *ReminderRepo) ClaimReminder(id string, firedAt time.Time) (bool, error) {
+	result, err := m.DB.Exec(sqlClaimReminder, id, firedAt)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// ReleaseReminder makes a claimed reminder pending again. It is retried at its
+// NextAttemptAt.
+func (m *ReminderRepo) ReleaseReminder(reminder model.TaskReminder) error {
+	_, err := m.DB.Exec(sqlReleaseReminder, reminder.Id, reminder.Attempts, reminder.NextAttemptAt)
+	return err
+}
+
+// FailReminder records that the reminder will not be delivered.
+func (m *ReminderRepo) FailReminder(reminder model.TaskReminder) error {
+	_, err := m.DB.Exec(sqlFailReminder, reminder.Id, reminder.Attempts, reminder.FailedAt)
+	return err
+}
+
+func (m *ReminderRepo) queryReminders(query string, args ...any) ([]model.TaskReminder, error) {
+	rows, err := m.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reminders := []model.TaskReminder{}
+	for rows.Next() {
+		var reminder model.TaskReminder
+		var target sql.NullString
+		err := rows.Scan(&reminder.Id, &reminder.TaskId, &reminder.UserId, &reminder.Channel, &target,
+			&reminder.OffsetMinutes, &reminder.RemindAt, &reminder.FiredAt, &reminder.Attempts, &reminder.NextAttemptAt, &reminder.FailedAt, &reminder.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		reminder.Target = target.String
+		reminders = append(reminders, reminder)
+	}
+	return reminders, nil
+}
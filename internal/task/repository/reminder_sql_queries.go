@@ -0,0 +1,22 @@
+package repository
+
+const reminderColumns = `id, task_id, user_id, channel, target, offset_minutes, remind_at, fired_at, attempts, next_attempt_at, failed_at, created_at`
+
+const (
+	sqlCreateReminder = `INSERT INTO public.task_reminder(task_id, user_id, channel, target, offset_minutes, remind_at, created_at)
+		VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING id`
+	sqlGetRemindersByTaskId = `SELECT ` + reminderColumns + ` FROM public.task_reminder
+								WHERE task_id = $1 AND user_id = $2
+								ORDER BY remind_at`
+	sqlGetOffsetRemindersByTaskId = `SELECT ` + reminderColumns + ` FROM public.task_reminder
+								WHERE task_id = $1 AND offset_minutes IS NOT NULL AND fired_at IS NULL`
+	sqlDeleteReminder         = `DELETE FROM public.task_reminder WHERE id = $1 AND task_id = $2 AND user_id = $3`
+	sqlUpdateReminderRemindAt = `UPDATE public.task_reminder SET remind_at = $2, next_attempt_at = NULL WHERE id = $1`
+	sqlGetPendingReminders    = `SELECT ` + reminderColumns + ` FROM public.task_reminder
+								WHERE fired_at IS NULL AND failed_at IS NULL AND COALESCE(next_attempt_at, remind_at) <= $1
+								ORDER BY COALESCE(next_attempt_at, remind_at)
+								LIMIT $2`
+	sqlClaimReminder   = `UPDATE public.task_reminder SET fired_at = $2 WHERE id = $1 AND fired_at IS NULL`
+	sqlReleaseReminder = `UPDATE public.task_reminder SET fired_at = NULL, attempts = $2, next_attempt_at = $3 WHERE id = $1`
+	sqlFailReminder    = `UPDATE public.task_reminder SET attempts = $2, next_attempt_at = NULL, failed_at = $3 WHERE id = $1`
+)
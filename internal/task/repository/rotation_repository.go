@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/happYness-Project/taskManagementGolang/internal/task/model"
+)
+
+type RotationRepository interface {
+	GetRotation(seriesId string) (*model.ChoreRotation, error)
+	SaveRotation(rotation model.ChoreRotation) error
+	AddMemberToRotations(groupId int, userId int) error
+	RemoveMemberFromRotations(groupId int, userId int) error
+	CountOpenAssignedTasks(groupId int) (map[int]int, error)
+}
+
+type RotationRepo struct {
+	DB *sql.DB
+}
+
+func NewRotationRepository(db *sql.DB) *RotationRepo {
+	return &RotationRepo{DB: db}
+}
+
+// queryer is satisfied by both *sql.DB and *sql.Tx.
+type queryer interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+// GetRotation returns nil when the series has no rotation.
+func (m *RotationRepo) GetRotation(seriesId string) (*model.ChoreRotation, error) {
+	rotations, err := queryRotations(m.DB, sqlGetRotation, seriesId)
+	if err != nil || len(rotations) == 0 {
+		return nil, err
+	}
+	return &rotations[0], nil
+}
+
+// SaveRotation stores the rotation and assigns the open occurrence of the
+// series to the current member.
+func (m *RotationRepo) SaveRotation(rotation model.ChoreRotation) error {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = saveRotation(tx, rotation); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// AddMemberToRotations appends a new group member to the rotations of chores
+// containers open to the whole group. Rotations in containers restricted to
+// their members are left to be edited explicitly.
+func (m *RotationRepo) AddMemberToRotations(groupId int, userId int) error {
+	return m.syncRotations(sqlGetSharedRotationsByGroupIdForUpdate, groupId, func(rotation *model.ChoreRotation) bool {
+		return rotation.AddMember(userId)
+	})
+}
+
+// RemoveMemberFromRotations drops a member who left the group from every
+// rotation of the group.
+func (m *RotationRepo) RemoveMemberFromRotations(groupId int, userId int) error {
+	return m.syncRotations(sqlGetRotationsByGroupIdForUpdate, groupId, func(rotation *model.ChoreRotation) bool {
+		return rotation.RemoveMember(userId)
+	})
+}
+
+// syncRotations applies change to the rotations selected by query and saves the
+// ones it changed.
+func (m *RotationRepo) syncRotations(query string, groupId int, change func(rotation *model.ChoreRotation) bool) error {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var rotations []model.ChoreRotation
+	rotations, err = queryRotations(tx, query, groupId)
+	if err != nil {
+		return err
+	}
+	for i := range rotations {
+		if !change(&rotations[i]) {
+			continue
+		}
+		if err = saveRotation(tx, rotations[i]); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (m *RotationRepo) CountOpenAssignedTasks(groupId int) (map[int]int, error) {
+	rows, err := m.DB.Query(sqlCountOpenAssignedTasks, groupId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	load := map[int]int{}
+	for rows.Next() {
+		var userId, count int
+		if err := rows.Scan(&userId, &count); err != nil {
+			return nil, err
+		}
+		load[userId] = count
+	}
+	return load, nil
+}
+
+func saveRotation(q queryer, rotation model.ChoreRotation) error {
+	_, err := q.Exec(sqlUpsertRotation, rotation.SeriesId, rotation.UsergroupId, string(rotation.Strategy), rotation.Current)
+	if err != nil {
+		return fmt.Errorf("unable to upsert chore_rotation table : %w", err)
+	}
+	if _, err = q.Exec(sqlDeleteRotationMembers, rotation.SeriesId); err != nil {
+		return fmt.Errorf("unable to delete from chore_rotation_member table : %w", err)
+	}
+	for i, userId := range rotation.Members {
+		if _, err = q.Exec(sqlCreateRotationMember, rotation.SeriesId, userId, i); err != nil {
+			return fmt.Errorf("unable to insert into chore_rotation_member table : %w", err)
+		}
+	}
+	var assignee *int
+	if current, ok := rotation.CurrentAssignee(); ok {
+		assignee = &current
+	}
+	if _, err = q.Exec(sqlAssignOpenOccurrence, rotation.SeriesId, assignee); err != nil {
+		return fmt.Errorf("unable to assign open occurrence : %w", err)
+	}
+	return nil
+}
+
+func queryRotations(q queryer, query string, args ...any) ([]model.ChoreRotation, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	rotations := []model.ChoreRotation{}
+	for rows.Next() {
+		var rotation model.ChoreRotation
+		var strategy string
+		if err := rows.Scan(&rotation.SeriesId, &rotation.UsergroupId, &strategy, &rotation.Current); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		rotation.Strategy = model.RotationStrategy(strategy)
+		rotations = append(rotations, rotation)
+	}
+	rows.Close()
+
+	for i := range rotations {
+		members, err := q.Query(sqlGetRotationMembers, rotations[i].SeriesId)
+		if err != nil {
+			return nil, err
+		}
+		rotations[i].Members = []int{}
+		for members.Next() {
+			var userId int
+			if err := members.Scan(&userId); err != nil {
+				members.Close()
+				return nil, err
+			}
+			rotations[i].Members = append(rotations[i].Members, userId)
+		}
+		members.Close()
+		// Members may have been removed by a cascading user delete.
+		if rotations[i].Current >= len(rotations[i].Members) {
+			rotations[i].Current = 0
+		}
+	}
+	return rotations, nil
+}
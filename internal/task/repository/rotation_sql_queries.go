@@ -0,0 +1,38 @@
+package repository
+
+const (
+	sqlGetRotation = `SELECT series_id, usergroup_id, strategy, current_position
+						FROM public.chore_rotation
+						WHERE series_id = $1`
+	sqlGetRotationsByGroupIdForUpdate = `SELECT series_id, usergroup_id, strategy, current_position
+						FROM public.chore_rotation
+						WHERE usergroup_id = $1
+						FOR UPDATE`
+	sqlGetSharedRotationsByGroupIdForUpdate = `SELECT cr.series_id, cr.usergroup_id, cr.strategy, cr.current_position
+						FROM public.chore_rotation cr
+						WHERE cr.usergroup_id = $1
+						AND EXISTS (SELECT 1 FROM public.task t
+							JOIN public.taskcontainer_task tct ON tct.task_id = t.id
+							JOIN public.taskcontainer tc ON tc.id = tct.taskcontainer_id
+							WHERE t.series_id = cr.series_id AND tc.type = 'chores' AND tc.visibility = 'group')
+						FOR UPDATE OF cr`
+	sqlGetRotationMembers = `SELECT user_id FROM public.chore_rotation_member
+								WHERE series_id = $1
+								ORDER BY position`
+	sqlUpsertRotation = `INSERT INTO public.chore_rotation(series_id, usergroup_id, strategy, current_position)
+							VALUES ($1, $2, $3, $4)
+							ON CONFLICT (series_id) DO UPDATE SET strategy = EXCLUDED.strategy, current_position = EXCLUDED.current_position`
+	sqlDeleteRotationMembers = `DELETE FROM public.chore_rotation_member WHERE series_id = $1`
+	sqlCreateRotationMember  = `INSERT INTO public.chore_rotation_member(series_id, user_id, position) VALUES ($1, $2, $3)`
+	// The open occurrence of a series always belongs to the current member of its rotation.
+	sqlAssignOpenOccurrence = `UPDATE public.task SET assignee_id = $2, updated_at = now()
+								WHERE series_id = $1 AND is_completed = false
+								AND assignee_id IS DISTINCT FROM $2`
+	sqlCountOpenAssignedTasks = `SELECT t.assignee_id, COUNT(*)
+								FROM public.task t
+								WHERE t.id in (SELECT tct.task_id FROM public.taskcontainer_task tct
+									INNER JOIN public.taskcontainer tc ON tc.id = tct.taskcontainer_id
+									WHERE tc.usergroup_id = $1)
+								AND t.assignee_id IS NOT NULL AND t.is_completed = false AND t.archived_at IS NULL
+								GROUP BY t.assignee_id`
+)
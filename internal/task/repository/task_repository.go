@@ -3,7 +3,9 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/happYness-Project/taskManagementGolang/internal/task/model"
@@ -11,17 +13,57 @@ import (
 
 const dbTimeout = time.Second * 5
 
+const (
+	SortByPriority   = "priority"
+	SortByTargetDate = "target_date"
+	SortByCreatedAt  = "created_at"
+)
+
+// TaskFilter narrows and orders the task listings. The zero value returns the
+// default view in database order. Tasks that only belong to containers
+// restricted to other members are hidden from ViewerId.
+type TaskFilter struct {
+	IncludeSnoozed  bool
+	IncludeArchived bool
+	SortBy          string
+	SortDesc        bool
+	ViewerId        int
+}
+
 type TaskRepository interface {
-	GetAllTasks() ([]model.Task, error)
-	GetAllTasksByGroupId(groupId int) ([]model.Task, error)
-	GetAllTasksByGroupIdOnlyImportant(groupId int) ([]model.Task, error)
+	GetAllTasks(viewerId int) ([]model.Task, error)
+	GetAllTasksByGroupId(groupId int, filter TaskFilter) ([]model.Task, error)
+	GetAllTasksByGroupIdOnlyImportant(groupId int, filter TaskFilter) ([]model.Task, error)
 	GetTaskById(id string) (*model.Task, error)
-	GetTasksByContainerId(containerId string) ([]model.Task, error)
+	GetTasksByContainerId(containerId string, filter TaskFilter) ([]model.Task, error)
 	CreateTask(taskcontainerId string, task model.Task) (model.Task, error)
-	UpdateTask(task model.Task) error
+	CreateGroceryItem(containerId string, item model.Task) (model.Task, bool, error)
+	LinkTaskToContainer(containerId string, taskId string) error
+	GetTaskPlacements(containerId string) ([]model.TaskPlacement, error)
+	GetContainerStats(containerId string, now time.Time, loc *time.Location, days int) (*model.ContainerStats, error)
+	MoveTaskToSection(containerId string, taskId string, sectionId *string, position int) error
+	MoveTaskToSectionAndDone(containerId string, task model.Task, sectionId *string, position int, completion Completion) error
+	UnlinkTaskFromContainer(containerId string, taskId string) (bool, error)
+	UpdateTask(task model.Task, editedBy *int) error
 	UpdateImportantTask(id string, isImportant bool) error
+	UpdateTaskSnooze(task model.Task) error
 	DeleteTask(id string) error
-	DoneTask(id string, isDone bool) error
+	DoneTask(task model.Task, completion Completion) error
+	UpdateTaskAssignee(taskId string, assigneeId *int) error
+	GetArchivedTasksByGroupId(groupId int, viewerId int) ([]model.Task, error)
+	CanAccessTask(id string, userId int) (bool, error)
+	ArchiveCompletedTasks(now time.Time) (int64, error)
+	CreateTaskActivity(activity model.TaskActivity) error
+	GetTaskActivities(taskId string) ([]model.TaskActivity, error)
+	GetUserGroupIdByTaskId(taskId string) (int, error)
+	AddTaskWatcher(taskId string, userId int) error
+	RemoveTaskWatcher(taskId string, userId int) error
+	GetTaskWatchers(taskId string) ([]model.TaskWatcher, error)
+	ReplaceTaskMentions(taskId string, mentions []model.TaskMention) error
+	GetTaskMentions(taskId string) ([]model.TaskMention, error)
+	GetMentionsByTaskIds(taskIds []string) (map[string][]model.TaskMention, error)
+	GetTaskRevisions(taskId string) ([]model.TaskRevision, error)
+	GetTaskRevision(taskId string, number int) (*model.TaskRevision, error)
 }
 type TaskRepo struct {
 	DB *sql.DB
@@ -33,11 +75,11 @@ func NewTaskRepository(db *sql.DB) *TaskRepo {
 	}
 }
 
-func (m *TaskRepo) GetAllTasks() ([]model.Task, error) {
+func (m *TaskRepo) GetAllTasks(viewerId int) ([]model.Task, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
 	defer cancel()
 
-	rows, err := m.DB.QueryContext(ctx, sqlGetAllTasks)
+	rows, err := m.DB.QueryContext(ctx, sqlGetAllTasks+" WHERE "+taskVisibleSql("$1"), viewerId)
 	if err != nil {
 		return nil, err
 	}
@@ -70,8 +112,8 @@ func (m *TaskRepo) GetTaskById(id string) (*model.Task, error) {
 	return task, nil
 }
 
-func (m *TaskRepo) GetTasksByContainerId(containerId string) ([]model.Task, error) {
-	rows, err := m.DB.Query(sqlGetAllTasksByContainerId, containerId)
+func (m *TaskRepo) GetTasksByContainerId(containerId string, filter TaskFilter) ([]model.Task, error) {
+	rows, err := m.DB.Query(applyTaskFilter(sqlGetAllTasksByContainerId, filter), containerId)
 	if err != nil {
 		return nil, err
 	}
@@ -89,28 +131,374 @@ func (m *TaskRepo) GetTasksByContainerId(containerId string) ([]model.Task, erro
 }
 
 func (m *TaskRepo) CreateTask(containerId string, task model.Task) (model.Task, error) {
-	_, err := m.DB.Exec(sqlCreateTask, task.TaskId, task.TaskName, task.TaskDesc, task.TaskType, task.CreatedAt, task.UpdatedAt, task.TargetDate, task.Priority, task.Category, task.IsCompleted, task.IsImportant)
+	if err := insertTask(m.DB, containerId, task); err != nil {
+		return task, err
+	}
+	task.ContainerIds = []string{containerId}
+
+	return task, nil
+}
+
+// CreateGroceryItem adds item to the grocery container. When the container has
+// an open item of the same normalized name, the quantity is merged into it
+// instead and the returned bool is true. The container row is locked so two
+// concurrent adds of the same item cannot both insert.
+func (m *TaskRepo) CreateGroceryItem(containerId string, item model.Task) (model.Task, bool, error) {
+	tx, err := m.DB.Begin()
 	if err != nil {
-		return task, fmt.Errorf("unable to insert into task table : %w", err)
+		return item, false, err
 	}
-	_, err = m.DB.Exec(sqlCreateTaskForJoinTable,
-		containerId, task.TaskId)
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
 
+	if _, err = tx.Exec(sqlLockContainer, containerId); err != nil {
+		return item, false, err
+	}
+	var existing *model.Task
+	existing, err = queryTask(tx, sqlFindOpenTaskByItemName, containerId, model.NormalizeItemName(item.TaskName))
 	if err != nil {
-		return task, fmt.Errorf("unable to insert into taskcontainer_task table : %w", err)
+		return item, false, err
+	}
+	if existing != nil && existing.CanMerge(item) {
+		if err = existing.MergeItem(item); err != nil {
+			return item, false, err
+		}
+		if err = updateTaskTx(tx, *existing, item.CreatedBy); err != nil {
+			return item, false, err
+		}
+		if err = tx.Commit(); err != nil {
+			return item, false, err
+		}
+		return *existing, true, nil
+	}
+	if err = insertTask(tx, containerId, item); err != nil {
+		return item, false, err
 	}
+	if err = tx.Commit(); err != nil {
+		return item, false, err
+	}
+	item.ContainerIds = []string{containerId}
+	return item, false, nil
+}
 
-	return task, nil
+func insertTask(q queryer, containerId string, task model.Task) error {
+	_, err := q.Exec(sqlCreateTask, task.TaskId, task.TaskName, task.TaskDesc, task.TaskType, task.CreatedAt, task.UpdatedAt, nullTime(task.TargetDate), nullDate(task.DueDate), task.Timezone, string(task.Priority), task.Category, task.Quantity, nullString(task.Unit), nullString(task.Aisle), task.IsCompleted, task.IsImportant, task.CreatedBy, task.AssigneeId, nullString(string(task.Recurrence)), task.SeriesId, task.Points, nullString(string(task.Frequency)), nullInt(task.TargetCount))
+	if err != nil {
+		return fmt.Errorf("unable to insert into task table : %w", err)
+	}
+	_, err = q.Exec(sqlCreateTaskForJoinTable, containerId, task.TaskId)
+	if err != nil {
+		return fmt.Errorf("unable to insert into taskcontainer_task table : %w", err)
+	}
+	return nil
+}
+
+func queryTask(q queryer, query string, args ...any) (*model.Task, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var task *model.Task
+	for rows.Next() {
+		task, err = scanRowsIntoTask(rows)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return task, rows.Err()
+}
+
+func (m *TaskRepo) LinkTaskToContainer(containerId string, taskId string) error {
+	_, err := m.DB.Exec(sqlCreateTaskForJoinTable, containerId, taskId)
+	if err != nil {
+		return fmt.Errorf("unable to insert into taskcontainer_task table : %w", err)
+	}
+	return nil
+}
+
+// GetTaskPlacements returns the section and board position of every task in the
+// container.
+func (m *TaskRepo) GetTaskPlacements(containerId string) ([]model.TaskPlacement, error) {
+	rows, err := m.DB.Query(sqlGetTaskPlacements, containerId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	placements := []model.TaskPlacement{}
+	for rows.Next() {
+		var placement model.TaskPlacement
+		if err := rows.Scan(&placement.TaskId, &placement.SectionId, &placement.Position); err != nil {
+			return nil, err
+		}
+		placements = append(placements, placement)
+	}
+	return placements, nil
+}
+
+// GetContainerStats aggregates the container's tasks in the database. The daily
+// completion series covers the given number of days up to today in loc.
+func (m *TaskRepo) GetContainerStats(containerId string, now time.Time, loc *time.Location, days int) (*model.ContainerStats, error) {
+	stats := &model.ContainerStats{ByPriority: map[string]int{}, ByCategory: map[string]int{}, DailyCompletions: []model.DailyCount{}}
+	var average sql.NullFloat64
+	err := m.DB.QueryRow(sqlContainerStatsCounts, containerId, now).
+		Scan(&stats.Total, &stats.Open, &stats.Completed, &stats.Overdue, &stats.Important, &average)
+	if err != nil {
+		return nil, err
+	}
+	if average.Valid {
+		stats.AverageCompletionHours = &average.Float64
+	}
+	if err = m.scanCounts(stats.ByPriority, sqlContainerStatsByPriority, containerId); err != nil {
+		return nil, err
+	}
+	if err = m.scanCounts(stats.ByCategory, sqlContainerStatsByCategory, containerId); err != nil {
+		return nil, err
+	}
+
+	rows, err := m.DB.Query(sqlContainerDailyCompletions, containerId, now.In(loc).Format(model.DateLayout), days, loc.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var day model.DailyCount
+		if err := rows.Scan(&day.Date, &day.Count); err != nil {
+			return nil, err
+		}
+		stats.DailyCompletions = append(stats.DailyCompletions, day)
+	}
+	return stats, nil
+}
+
+func (m *TaskRepo) scanCounts(counts map[string]int, query string, args ...any) error {
+	rows, err := m.DB.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var key string
+		var count int
+		if err := rows.Scan(&key, &count); err != nil {
+			return err
+		}
+		counts[key] = count
+	}
+	return nil
+}
+
+// MoveTaskToSection places the task at position in the section, or among the
+// tasks without a section when sectionId is nil, shifting the tasks at and
+// after that position down. It returns sql.ErrNoRows when the task is not in
+// the container.
+func (m *TaskRepo) MoveTaskToSection(containerId string, taskId string, sectionId *string, position int) error {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = moveTaskTx(tx, containerId, taskId, sectionId, position); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// MoveTaskToSectionAndDone moves the task and saves its completion change in
+// one transaction, for sections that complete or reopen the tasks put in them.
+func (m *TaskRepo) MoveTaskToSectionAndDone(containerId string, task model.Task, sectionId *string, position int, completion Completion) error {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = moveTaskTx(tx, containerId, task.TaskId, sectionId, position); err != nil {
+		return err
+	}
+	if err = doneTaskTx(tx, task, completion); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
-func (m *TaskRepo) UpdateTask(task model.Task) error {
-	_, err := m.DB.Exec(sqlUpdateTask, task.TaskId, task.TaskName, task.TaskDesc, task.UpdatedAt, task.TargetDate, task.Priority, task.Category)
+func moveTaskTx(tx *sql.Tx, containerId string, taskId string, sectionId *string, position int) error {
+	if _, err := tx.Exec(sqlShiftSectionPositions, containerId, sectionId, position, taskId); err != nil {
+		return fmt.Errorf("unable to shift section positions : %w", err)
+	}
+	result, err := tx.Exec(sqlMoveTaskToSection, containerId, taskId, sectionId, position)
+	if err != nil {
+		return fmt.Errorf("unable to move task to section : %w", err)
+	}
+	affected, err := result.RowsAffected()
 	if err != nil {
 		return err
 	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
 	return nil
 }
 
+// UnlinkTaskFromContainer removes the task from the container. The task itself is
+// deleted when it was the last container holding it, which is reported by the
+// returned bool.
+func (m *TaskRepo) UnlinkTaskFromContainer(containerId string, taskId string) (bool, error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	_, err = tx.Exec(sqlDeleteTaskContainerLink, containerId, taskId)
+	if err != nil {
+		return false, fmt.Errorf("unable to delete from taskcontainer_task table : %w", err)
+	}
+	var remaining int
+	if err = tx.QueryRow(sqlCountTaskContainerLinks, taskId).Scan(&remaining); err != nil {
+		return false, err
+	}
+	if remaining == 0 {
+		if _, err = tx.Exec(sqlDeleteTask, taskId); err != nil {
+			return false, fmt.Errorf("unable to delete task : %w", err)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return false, err
+	}
+	return remaining == 0, nil
+}
+
+// UpdateTask replaces the task fields and stores the new state as a revision.
+// The first update also stores the state before it, so it can be reverted to.
+func (m *TaskRepo) UpdateTask(task model.Task, editedBy *int) error {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = updateTaskTx(tx, task, editedBy); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// updateTaskTx stores the task and a revision of its new state. A task edited
+// for the first time also gets a revision of its state before the edit.
+func updateTaskTx(tx *sql.Tx, task model.Task, editedBy *int) error {
+	if _, err := tx.Exec(sqlLockTask, task.TaskId); err != nil {
+		return err
+	}
+	var revisions int
+	if err := tx.QueryRow(sqlCountTaskRevisions, task.TaskId).Scan(&revisions); err != nil {
+		return err
+	}
+	if revisions == 0 {
+		current, err := getTaskByIdTx(tx, task.TaskId)
+		if err != nil {
+			return err
+		}
+		if current != nil {
+			if err = createTaskRevision(tx, *current, current.CreatedBy, current.UpdatedAt); err != nil {
+				return err
+			}
+		}
+	}
+	_, err := tx.Exec(sqlUpdateTask, task.TaskId, task.TaskName, task.TaskDesc, task.UpdatedAt, nullTime(task.TargetDate), nullDate(task.DueDate), task.Timezone, string(task.Priority), task.Category, task.Quantity, nullString(task.Unit), nullString(task.Aisle), nullString(string(task.Recurrence)), task.SeriesId, task.Points, nullString(string(task.Frequency)), nullInt(task.TargetCount))
+	if err != nil {
+		return err
+	}
+	return createTaskRevision(tx, task, editedBy, task.UpdatedAt)
+}
+
+func (m *TaskRepo) GetTaskRevisions(taskId string) ([]model.TaskRevision, error) {
+	rows, err := m.DB.Query(sqlGetTaskRevisions, taskId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revisions := []model.TaskRevision{}
+	for rows.Next() {
+		revision, err := scanRowsIntoTaskRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, *revision)
+	}
+	return revisions, nil
+}
+
+// GetTaskRevision returns nil when the revision does not exist.
+func (m *TaskRepo) GetTaskRevision(taskId string, number int) (*model.TaskRevision, error) {
+	rows, err := m.DB.Query(sqlGetTaskRevision, taskId, number)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revision *model.TaskRevision
+	for rows.Next() {
+		revision, err = scanRowsIntoTaskRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return revision, nil
+}
+
+func getTaskByIdTx(tx *sql.Tx, id string) (*model.Task, error) {
+	return queryTask(tx, sqlGetTaskById, id)
+}
+
+func createTaskRevision(tx *sql.Tx, task model.Task, editedBy *int, createdAt time.Time) error {
+	snapshot, err := json.Marshal(task.Snapshot())
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(sqlCreateTaskRevision, task.TaskId, snapshot, editedBy, createdAt)
+	if err != nil {
+		return fmt.Errorf("unable to insert into task_revision table : %w", err)
+	}
+	return nil
+}
+
+func scanRowsIntoTaskRevision(rows *sql.Rows) (*model.TaskRevision, error) {
+	revision := new(model.TaskRevision)
+	var snapshot []byte
+	err := rows.Scan(&revision.TaskId, &revision.Number, &snapshot, &revision.EditedBy, &revision.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(snapshot, &revision.Snapshot); err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
 func (m *TaskRepo) DeleteTask(id string) error {
 	_, err := m.DB.Exec(sqlDeleteTaskForJoinTable, id)
 	if err != nil {
@@ -123,14 +511,87 @@ func (m *TaskRepo) DeleteTask(id string) error {
 	return nil
 }
 
-func (m *TaskRepo) DoneTask(id string, isDone bool) error {
-	_, err := m.DB.Exec(sqlUpdateTaskDoneField, isDone, id)
+// Completion is saved in the same transaction as a completion change, so a
+// completed task never ends up without its points or its next occurrence.
+// Reopening a task revokes its points.
+type Completion struct {
+	// Award of the user who completed the task. It is a no-op when the task
+	// already has an award.
+	Award *model.PointAward
+	// NextOccurrence of a completed recurring task. It is created in the
+	// containers of the task unless the series still has an open occurrence.
+	NextOccurrence *model.Task
+	// Rotation that assigned NextOccurrence. It is saved only with it.
+	Rotation *model.ChoreRotation
+}
+
+func (m *TaskRepo) DoneTask(task model.Task, completion Completion) error {
+	tx, err := m.DB.Begin()
 	if err != nil {
 		return err
 	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = doneTaskTx(tx, task, completion); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func doneTaskTx(tx *sql.Tx, task model.Task, completion Completion) error {
+	// Completions of one series are serialized so only one of them spawns the
+	// next occurrence.
+	if task.SeriesId != nil {
+		if _, err := tx.Exec(sqlLockSeries, *task.SeriesId); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec(sqlUpdateTaskDoneField, task.TaskId, task.IsCompleted, task.CompletedAt, task.ArchivedAt, task.UpdatedAt); err != nil {
+		return err
+	}
+	if !task.IsCompleted {
+		if _, err := tx.Exec(sqlDeletePointAward, task.TaskId); err != nil {
+			return fmt.Errorf("unable to revoke points : %w", err)
+		}
+		return nil
+	}
+	if award := completion.Award; award != nil {
+		_, err := tx.Exec(sqlCreatePointAward, award.TaskId, award.UserId, award.UsergroupId, award.Points, award.AwardedAt, award.AwardedOn)
+		if err != nil {
+			return fmt.Errorf("unable to award points : %w", err)
+		}
+	}
+	next := completion.NextOccurrence
+	if next == nil || task.SeriesId == nil || len(task.ContainerIds) == 0 {
+		return nil
+	}
+	open, err := queryTask(tx, sqlGetOpenOccurrence, *task.SeriesId)
+	if err != nil || open != nil {
+		return err
+	}
+	if err = insertTask(tx, task.ContainerIds[0], *next); err != nil {
+		return err
+	}
+	for _, containerId := range task.ContainerIds[1:] {
+		if _, err = tx.Exec(sqlCreateTaskForJoinTable, containerId, next.TaskId); err != nil {
+			return fmt.Errorf("unable to insert into taskcontainer_task table : %w", err)
+		}
+	}
+	if completion.Rotation != nil {
+		return saveRotation(tx, *completion.Rotation)
+	}
 	return nil
 }
 
+func (m *TaskRepo) UpdateTaskAssignee(taskId string, assigneeId *int) error {
+	_, err := m.DB.Exec(sqlUpdateTaskAssignee, taskId, assigneeId, time.Now())
+	return err
+}
+
 func (m *TaskRepo) UpdateImportantTask(id string, isImportant bool) error {
 	_, err := m.DB.Exec(sqlUpdateTaskImportantField, isImportant, id)
 	if err != nil {
@@ -139,8 +600,166 @@ func (m *TaskRepo) UpdateImportantTask(id string, isImportant bool) error {
 	return nil
 }
 
-func (m *TaskRepo) GetAllTasksByGroupId(groupId int) ([]model.Task, error) {
-	rows, err := m.DB.Query(sqlGetAllTasksByGroupId, groupId)
+func (m *TaskRepo) UpdateTaskSnooze(task model.Task) error {
+	_, err := m.DB.Exec(sqlUpdateTaskSnooze, task.TaskId, task.SnoozedUntil, nullTime(task.TargetDate), nullDate(task.DueDate), task.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("unable to update snooze of task : %w", err)
+	}
+	return nil
+}
+
+func (m *TaskRepo) CreateTaskActivity(activity model.TaskActivity) error {
+	_, err := m.DB.Exec(sqlCreateTaskActivity, activity.TaskId, activity.UserId, activity.Action, activity.Detail, activity.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("unable to insert into task_activity table : %w", err)
+	}
+	return nil
+}
+
+func (m *TaskRepo) GetTaskActivities(taskId string) ([]model.TaskActivity, error) {
+	rows, err := m.DB.Query(sqlGetTaskActivities, taskId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	activities := []model.TaskActivity{}
+	for rows.Next() {
+		var activity model.TaskActivity
+		err := rows.Scan(&activity.Id, &activity.TaskId, &activity.UserId, &activity.Action, &activity.Detail, &activity.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		activities = append(activities, activity)
+	}
+	return activities, nil
+}
+
+func (m *TaskRepo) GetUserGroupIdByTaskId(taskId string) (int, error) {
+	var groupId int
+	err := m.DB.QueryRow(sqlGetUserGroupIdByTaskId, taskId).Scan(&groupId)
+	if err != nil {
+		return 0, err
+	}
+	return groupId, nil
+}
+
+func (m *TaskRepo) AddTaskWatcher(taskId string, userId int) error {
+	_, err := m.DB.Exec(sqlAddTaskWatcher, taskId, userId, time.Now())
+	if err != nil {
+		return fmt.Errorf("unable to insert into task_watcher table : %w", err)
+	}
+	return nil
+}
+
+func (m *TaskRepo) RemoveTaskWatcher(taskId string, userId int) error {
+	_, err := m.DB.Exec(sqlRemoveTaskWatcher, taskId, userId)
+	if err != nil {
+		return fmt.Errorf("unable to delete from task_watcher table : %w", err)
+	}
+	return nil
+}
+
+func (m *TaskRepo) GetTaskWatchers(taskId string) ([]model.TaskWatcher, error) {
+	rows, err := m.DB.Query(sqlGetTaskWatchers, taskId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	watchers := []model.TaskWatcher{}
+	for rows.Next() {
+		var watcher model.TaskWatcher
+		if err := rows.Scan(&watcher.TaskId, &watcher.UserId, &watcher.CreatedAt); err != nil {
+			return nil, err
+		}
+		watchers = append(watchers, watcher)
+	}
+	return watchers, nil
+}
+
+func (m *TaskRepo) ReplaceTaskMentions(taskId string, mentions []model.TaskMention) error {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	_, err = tx.Exec(sqlDeleteTaskMentions, taskId)
+	if err != nil {
+		return fmt.Errorf("unable to delete from task_mention table : %w", err)
+	}
+	for i, mention := range mentions {
+		_, err = tx.Exec(sqlCreateTaskMention, taskId, mention.UserId, i)
+		if err != nil {
+			return fmt.Errorf("unable to insert into task_mention table : %w", err)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *TaskRepo) GetTaskMentions(taskId string) ([]model.TaskMention, error) {
+	rows, err := m.DB.Query(sqlGetTaskMentions, taskId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	mentions := []model.TaskMention{}
+	for rows.Next() {
+		var mention model.TaskMention
+		if err := rows.Scan(&mention.UserId, &mention.Username); err != nil {
+			return nil, err
+		}
+		mentions = append(mentions, mention)
+	}
+	return mentions, nil
+}
+
+// GetMentionsByTaskIds loads the mentions of several tasks with one query,
+// keyed by task id.
+func (m *TaskRepo) GetMentionsByTaskIds(taskIds []string) (map[string][]model.TaskMention, error) {
+	mentions := map[string][]model.TaskMention{}
+	if len(taskIds) == 0 {
+		return mentions, nil
+	}
+	rows, err := m.DB.Query(sqlGetMentionsByTaskIds, taskIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskId string
+		var mention model.TaskMention
+		if err := rows.Scan(&taskId, &mention.UserId, &mention.Username); err != nil {
+			return nil, err
+		}
+		mentions[taskId] = append(mentions[taskId], mention)
+	}
+	return mentions, nil
+}
+
+// CanAccessTask reports whether the user may see the task, which is the case
+// when one of its containers is visible to the user or it has no container.
+func (m *TaskRepo) CanAccessTask(id string, userId int) (bool, error) {
+	var visible bool
+	err := m.DB.QueryRow(`SELECT `+taskVisibleSql("$2")+` FROM public.task t WHERE t.id = $1`, id, userId).Scan(&visible)
+	if err == sql.ErrNoRows {
+		return true, nil
+	}
+	return visible, err
+}
+
+func (m *TaskRepo) GetArchivedTasksByGroupId(groupId int, viewerId int) ([]model.Task, error) {
+	rows, err := m.DB.Query(sqlGetArchivedTasksByGroupId+" AND "+taskVisibleInGroupSql("$1", "$2")+sqlOrderByArchivedAt, groupId, viewerId)
 	if err != nil {
 		return nil, err
 	}
@@ -157,8 +776,18 @@ func (m *TaskRepo) GetAllTasksByGroupId(groupId int) ([]model.Task, error) {
 	return tasks, nil
 }
 
-func (m *TaskRepo) GetAllTasksByGroupIdOnlyImportant(groupId int) ([]model.Task, error) {
-	rows, err := m.DB.Query(sqlGetAllTasksByGroupIdAndImportant, groupId)
+// ArchiveCompletedTasks archives the completed tasks of every group whose
+// archive period has passed and returns how many tasks were archived.
+func (m *TaskRepo) ArchiveCompletedTasks(now time.Time) (int64, error) {
+	result, err := m.DB.Exec(sqlArchiveCompletedTasks, now)
+	if err != nil {
+		return 0, fmt.Errorf("unable to archive completed tasks : %w", err)
+	}
+	return result.RowsAffected()
+}
+
+func (m *TaskRepo) GetAllTasksByGroupId(groupId int, filter TaskFilter) ([]model.Task, error) {
+	rows, err := m.DB.Query(applyTaskFilter(sqlGetAllTasksByGroupId+" AND "+taskVisibleSql("$2"), filter), groupId, filter.ViewerId)
 	if err != nil {
 		return nil, err
 	}
@@ -175,8 +804,91 @@ func (m *TaskRepo) GetAllTasksByGroupIdOnlyImportant(groupId int) ([]model.Task,
 	return tasks, nil
 }
 
+func (m *TaskRepo) GetAllTasksByGroupIdOnlyImportant(groupId int, filter TaskFilter) ([]model.Task, error) {
+	rows, err := m.DB.Query(applyTaskFilter(sqlGetAllTasksByGroupIdAndImportant+" AND "+taskVisibleSql("$2"), filter), groupId, filter.ViewerId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := []model.Task{}
+	for rows.Next() {
+		task, err := scanRowsIntoTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, *task)
+	}
+	return tasks, nil
+}
+
+func applyTaskFilter(query string, filter TaskFilter) string {
+	if !filter.IncludeSnoozed {
+		query += sqlExcludeSnoozedTasks
+	}
+	if !filter.IncludeArchived {
+		query += sqlExcludeArchivedTasks
+	}
+
+	var orderBy string
+	switch filter.SortBy {
+	case SortByPriority:
+		orderBy = priorityRankSql()
+	case SortByTargetDate:
+		orderBy = sqlTaskDueInstant
+	case SortByCreatedAt:
+		orderBy = "t.created_at"
+	default:
+		return query
+	}
+	if filter.SortDesc {
+		orderBy += " DESC"
+	}
+	return query + " ORDER BY " + orderBy + ", t.created_at"
+}
+
+// taskVisibleSql matches the tasks t that the user bound to param may see: tasks
+// without a container and tasks in a container shared with the whole group or
+// restricted to members that include the user.
+func taskVisibleSql(param string) string {
+	return `(NOT EXISTS (SELECT 1 FROM public.taskcontainer_task v WHERE v.task_id = t.id)
+		OR EXISTS (SELECT 1 FROM public.taskcontainer_task v INNER JOIN public.taskcontainer tc ON tc.id = v.taskcontainer_id
+			WHERE v.task_id = t.id AND ` + containerVisibleSql(param) + `))`
+}
+
+// taskVisibleInGroupSql narrows taskVisibleSql to the containers of the group
+// bound to groupParam, so that a task shared through another group's container
+// does not show up in the listing of a group where it is restricted.
+func taskVisibleInGroupSql(groupParam string, param string) string {
+	return `EXISTS (SELECT 1 FROM public.taskcontainer_task v INNER JOIN public.taskcontainer tc ON tc.id = v.taskcontainer_id
+		WHERE v.task_id = t.id AND tc.usergroup_id = ` + groupParam + ` AND ` + containerVisibleSql(param) + `)`
+}
+
+// containerVisibleSql matches the containers tc that the user bound to param may see.
+func containerVisibleSql(param string) string {
+	return `(tc.visibility <> 'members'
+				OR (EXISTS (SELECT 1 FROM public.taskcontainer_member cm WHERE cm.container_id = tc.id AND cm.user_id = ` + param + `)
+					AND EXISTS (SELECT 1 FROM public.usergroup_user ugu WHERE ugu.usergroup_id = tc.usergroup_id AND ugu.user_id = ` + param + `)))`
+}
+
+// priorityRankSql maps the priority column onto model.Priority ranks so that
+// sorting follows urgency instead of the alphabet.
+func priorityRankSql() string {
+	var b strings.Builder
+	b.WriteString("CASE t.priority")
+	for _, p := range model.Priorities {
+		fmt.Fprintf(&b, " WHEN '%s' THEN %d", p, p.Rank())
+	}
+	b.WriteString(" ELSE 0 END")
+	return b.String()
+}
+
 func scanRowsIntoTask(rows *sql.Rows) (*model.Task, error) {
 	task := new(model.Task)
+	var containerIds string
+	var targetDate, dueDate sql.NullTime
+	var unit, aisle, recurrence, frequency sql.NullString
+	var targetCount sql.NullInt64
 	err := rows.Scan(
 		&task.TaskId,
 		&task.TaskName,
@@ -184,15 +896,63 @@ func scanRowsIntoTask(rows *sql.Rows) (*model.Task, error) {
 		&task.TaskType,
 		&task.CreatedAt,
 		&task.UpdatedAt,
-		&task.TargetDate,
+		&targetDate,
+		&dueDate,
+		&task.Timezone,
 		&task.Priority,
 		&task.Category,
+		&task.Quantity,
+		&unit,
+		&aisle,
 		&task.IsCompleted,
 		&task.IsImportant,
+		&task.CompletedAt,
+		&task.SnoozedUntil,
+		&task.ArchivedAt,
+		&task.CreatedBy,
+		&task.AssigneeId,
+		&recurrence,
+		&task.SeriesId,
+		&task.Points,
+		&frequency,
+		&targetCount,
+		&containerIds,
 	)
 	if err != nil {
 		return nil, err
 	}
+	task.Unit = unit.String
+	task.Aisle = aisle.String
+	task.Recurrence = model.Recurrence(recurrence.String)
+	task.Frequency = model.HabitFrequency(frequency.String)
+	task.TargetCount = int(targetCount.Int64)
+	if targetDate.Valid {
+		task.TargetDate = targetDate.Time
+	}
+	if dueDate.Valid {
+		task.DueDate = dueDate.Time.Format(model.DateLayout)
+	}
+	task.ContainerIds = []string{}
+	if containerIds != "" {
+		task.ContainerIds = strings.Split(containerIds, ",")
+	}
 
 	return task, nil
 }
+
+// nullTime stores a zero target date as NULL, meaning the task has no timed due instant.
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+func nullDate(date string) sql.NullString {
+	return nullString(date)
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func nullInt(i int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(i), Valid: i != 0}
+}
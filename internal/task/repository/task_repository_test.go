@@ -0,0 +1,327 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/happYness-Project/taskManagementGolang/internal/task/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskRepo_UnlinkTaskFromContainer(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+	taskRepo := NewTaskRepository(db)
+
+	t.Run("when task is still linked to another container, Then task is kept", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(sqlDeleteTaskContainerLink).WithArgs("grocery", "task-1").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(sqlCountTaskContainerLinks).WithArgs("task-1").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectCommit()
+
+		deleted, err := taskRepo.UnlinkTaskFromContainer("grocery", "task-1")
+
+		require.NoError(t, err)
+		require.False(t, deleted)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("when last link is removed, Then task is deleted", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(sqlDeleteTaskContainerLink).WithArgs("grocery", "task-1").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(sqlCountTaskContainerLinks).WithArgs("task-1").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectExec(sqlDeleteTask).WithArgs("task-1").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		deleted, err := taskRepo.UnlinkTaskFromContainer("grocery", "task-1")
+
+		require.NoError(t, err)
+		require.True(t, deleted)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestApplyTaskFilter(t *testing.T) {
+	t.Run("when default filter, Then snoozed and archived tasks are excluded without ordering", func(t *testing.T) {
+		query := applyTaskFilter(sqlGetAllTasks+" WHERE true", TaskFilter{})
+
+		require.Equal(t, sqlGetAllTasks+" WHERE true"+sqlExcludeSnoozedTasks+sqlExcludeArchivedTasks, query)
+	})
+
+	t.Run("when including archived tasks, Then only snoozed tasks are excluded", func(t *testing.T) {
+		query := applyTaskFilter(sqlGetAllTasks+" WHERE true", TaskFilter{IncludeArchived: true})
+
+		require.Equal(t, sqlGetAllTasks+" WHERE true"+sqlExcludeSnoozedTasks, query)
+	})
+
+	t.Run("when sorting by priority descending, Then order follows priority rank", func(t *testing.T) {
+		query := applyTaskFilter(sqlGetAllTasks+" WHERE true", TaskFilter{IncludeSnoozed: true, IncludeArchived: true, SortBy: SortByPriority, SortDesc: true})
+
+		require.Equal(t, sqlGetAllTasks+" WHERE true ORDER BY CASE t.priority WHEN 'low' THEN 1 WHEN 'normal' THEN 2 WHEN 'high' THEN 3 WHEN 'urgent' THEN 4 ELSE 0 END DESC, t.created_at", query)
+	})
+}
+
+func TestTaskRepo_ArchiveCompletedTasks(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+	taskRepo := NewTaskRepository(db)
+
+	t.Run("when completed tasks passed the group archive period, Then return archived count", func(t *testing.T) {
+		now := time.Date(2025, 1, 10, 3, 0, 0, 0, time.UTC)
+		mock.ExpectExec(sqlArchiveCompletedTasks).WithArgs(now).WillReturnResult(sqlmock.NewResult(0, 12))
+
+		archived, err := taskRepo.ArchiveCompletedTasks(now)
+
+		require.NoError(t, err)
+		require.Equal(t, int64(12), archived)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTaskRepo_UpdateTask(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+	taskRepo := NewTaskRepository(db)
+	editor := 2
+	task := model.Task{TaskId: "task-1", TaskName: "Apple", Priority: model.PriorityNormal, Timezone: model.DefaultTimezone, UpdatedAt: time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)}
+
+	t.Run("when task already has revisions, Then only the new state is stored as a revision", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(sqlLockTask).WithArgs("task-1").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(sqlCountTaskRevisions).WithArgs("task-1").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		mock.ExpectExec(sqlUpdateTask).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(sqlCreateTaskRevision).WithArgs("task-1", sqlmock.AnyArg(), &editor, task.UpdatedAt).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := taskRepo.UpdateTask(task, &editor)
+
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("when storing the revision fails, Then the update is rolled back", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(sqlLockTask).WithArgs("task-1").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(sqlCountTaskRevisions).WithArgs("task-1").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		mock.ExpectExec(sqlUpdateTask).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(sqlCreateTaskRevision).WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		err := taskRepo.UpdateTask(task, &editor)
+
+		require.Error(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTaskRepo_GetContainerStats(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+	taskRepo := NewTaskRepository(db)
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	now := time.Date(2025, 1, 10, 20, 0, 0, 0, time.UTC)
+
+	t.Run("when the container has tasks, Then counts and the daily series are aggregated in SQL", func(t *testing.T) {
+		mock.ExpectQuery(sqlContainerStatsCounts).WithArgs("grocery", now).
+			WillReturnRows(sqlmock.NewRows([]string{"total", "open", "completed", "overdue", "important", "average"}).AddRow(5, 3, 2, 1, 2, 12.5))
+		mock.ExpectQuery(sqlContainerStatsByPriority).WithArgs("grocery").
+			WillReturnRows(sqlmock.NewRows([]string{"priority", "count"}).AddRow("normal", 4).AddRow("high", 1))
+		mock.ExpectQuery(sqlContainerStatsByCategory).WithArgs("grocery").
+			WillReturnRows(sqlmock.NewRows([]string{"category", "count"}).AddRow("", 3).AddRow("fruit", 2))
+		mock.ExpectQuery(sqlContainerDailyCompletions).WithArgs("grocery", "2025-01-11", 2, "Asia/Tokyo").
+			WillReturnRows(sqlmock.NewRows([]string{"date", "count"}).AddRow("2025-01-10", 0).AddRow("2025-01-11", 2))
+
+		stats, err := taskRepo.GetContainerStats("grocery", now, loc, 2)
+
+		require.NoError(t, err)
+		require.Equal(t, 5, stats.Total)
+		require.Equal(t, 1, stats.Overdue)
+		require.Equal(t, 12.5, *stats.AverageCompletionHours)
+		require.Equal(t, map[string]int{"normal": 4, "high": 1}, stats.ByPriority)
+		require.Equal(t, 3, stats.ByCategory[""])
+		require.Equal(t, []model.DailyCount{{Date: "2025-01-10", Count: 0}, {Date: "2025-01-11", Count: 2}}, stats.DailyCompletions)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("when no task was completed, Then average completion time is nil", func(t *testing.T) {
+		mock.ExpectQuery(sqlContainerStatsCounts).WithArgs("grocery", now).
+			WillReturnRows(sqlmock.NewRows([]string{"total", "open", "completed", "overdue", "important", "average"}).AddRow(1, 1, 0, 0, 0, nil))
+		mock.ExpectQuery(sqlContainerStatsByPriority).WithArgs("grocery").WillReturnRows(sqlmock.NewRows([]string{"priority", "count"}))
+		mock.ExpectQuery(sqlContainerStatsByCategory).WithArgs("grocery").WillReturnRows(sqlmock.NewRows([]string{"category", "count"}))
+		mock.ExpectQuery(sqlContainerDailyCompletions).WithArgs("grocery", "2025-01-11", 1, "Asia/Tokyo").
+			WillReturnRows(sqlmock.NewRows([]string{"date", "count"}).AddRow("2025-01-11", 0))
+
+		stats, err := taskRepo.GetContainerStats("grocery", now, loc, 1)
+
+		require.NoError(t, err)
+		require.Nil(t, stats.AverageCompletionHours)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTaskRepo_GetArchivedTasksByGroupId(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+	taskRepo := NewTaskRepository(db)
+
+	t.Run("when listing archived tasks, Then tasks of the group's containers restricted to other members are excluded", func(t *testing.T) {
+		mock.ExpectQuery(sqlGetArchivedTasksByGroupId+" AND "+taskVisibleInGroupSql("$1", "$2")+sqlOrderByArchivedAt).
+			WithArgs(1, 4).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		tasks, err := taskRepo.GetArchivedTasksByGroupId(1, 4)
+
+		require.NoError(t, err)
+		require.Empty(t, tasks)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTaskRepo_CreateGroceryItem(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+	taskRepo := NewTaskRepository(db)
+	item := model.Task{TaskId: "task-1", TaskName: " Whole  Milk ", Priority: model.PriorityNormal, Timezone: model.DefaultTimezone}
+
+	t.Run("when container has no open item of the same name, Then the item is inserted under the container lock", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(sqlLockContainer).WithArgs("container-1").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(sqlFindOpenTaskByItemName).WithArgs("container-1", "whole milk").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectExec(sqlCreateTask).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(sqlCreateTaskForJoinTable).WithArgs("container-1", "task-1").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		task, merged, err := taskRepo.CreateGroceryItem("container-1", item)
+
+		require.NoError(t, err)
+		require.False(t, merged)
+		require.Equal(t, []string{"container-1"}, task.ContainerIds)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("when inserting fails, Then the transaction is rolled back", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(sqlLockContainer).WithArgs("container-1").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(sqlFindOpenTaskByItemName).WithArgs("container-1", "whole milk").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectExec(sqlCreateTask).WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		_, _, err := taskRepo.CreateGroceryItem("container-1", item)
+
+		require.Error(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTaskRepo_DoneTask(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+	taskRepo := NewTaskRepository(db)
+	seriesId := "series-1"
+	completedAt := time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)
+	task := model.Task{TaskId: "task-1", SeriesId: &seriesId, IsCompleted: true, CompletedAt: &completedAt, UpdatedAt: completedAt, ContainerIds: []string{"container-1", "container-2"}}
+	next := model.Task{TaskId: "task-2", SeriesId: &seriesId, Priority: model.PriorityNormal, Timezone: model.DefaultTimezone}
+
+	t.Run("when a recurring task is completed, Then the next occurrence is created in the same transaction", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(sqlLockSeries).WithArgs(seriesId).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(sqlUpdateTaskDoneField).WithArgs("task-1", true, &completedAt, nil, completedAt).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(sqlGetOpenOccurrence).WithArgs(seriesId).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectExec(sqlCreateTask).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(sqlCreateTaskForJoinTable).WithArgs("container-1", "task-2").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(sqlCreateTaskForJoinTable).WithArgs("container-2", "task-2").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := taskRepo.DoneTask(task, Completion{NextOccurrence: &next})
+
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("when a task is completed, Then its points are awarded in the same transaction", func(t *testing.T) {
+		plain := model.Task{TaskId: "task-3", IsCompleted: true, CompletedAt: &completedAt, UpdatedAt: completedAt}
+		award := model.PointAward{TaskId: "task-3", UserId: 7, UsergroupId: 1, Points: 2, AwardedAt: completedAt, AwardedOn: "2025-01-10"}
+		mock.ExpectBegin()
+		mock.ExpectExec(sqlUpdateTaskDoneField).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(sqlCreatePointAward).WithArgs("task-3", 7, 1, 2, completedAt, "2025-01-10").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := taskRepo.DoneTask(plain, Completion{Award: &award})
+
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("when a task is reopened, Then its points are revoked in the same transaction", func(t *testing.T) {
+		reopened := model.Task{TaskId: "task-3", UpdatedAt: completedAt}
+		mock.ExpectBegin()
+		mock.ExpectExec(sqlUpdateTaskDoneField).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(sqlDeletePointAward).WithArgs("task-3").WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		err := taskRepo.DoneTask(reopened, Completion{})
+
+		require.Error(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("when creating the next occurrence fails, Then the completion is rolled back", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(sqlLockSeries).WithArgs(seriesId).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(sqlUpdateTaskDoneField).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(sqlGetOpenOccurrence).WithArgs(seriesId).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectExec(sqlCreateTask).WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		err := taskRepo.DoneTask(task, Completion{NextOccurrence: &next})
+
+		require.Error(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTaskRepo_MoveTaskToSectionAndDone(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+	taskRepo := NewTaskRepository(db)
+	sectionId := "section-done"
+	completedAt := time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)
+	task := model.Task{TaskId: "task-1", IsCompleted: true, CompletedAt: &completedAt, UpdatedAt: completedAt}
+
+	t.Run("when the task is moved into a completing section, Then the move and the completion are committed together", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(sqlShiftSectionPositions).WithArgs("container-1", &sectionId, 0, "task-1").WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(sqlMoveTaskToSection).WithArgs("container-1", "task-1", &sectionId, 0).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(sqlUpdateTaskDoneField).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := taskRepo.MoveTaskToSectionAndDone("container-1", task, &sectionId, 0, Completion{})
+
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("when saving the completion fails, Then the move is rolled back", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(sqlShiftSectionPositions).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(sqlMoveTaskToSection).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(sqlUpdateTaskDoneField).WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		err := taskRepo.MoveTaskToSectionAndDone("container-1", task, &sectionId, 0, Completion{})
+
+		require.Error(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
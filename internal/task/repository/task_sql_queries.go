@@ -1,28 +1,142 @@
 package repository
 
+const taskColumns = `t.id, t.name, t.description, t.type, t.created_at, t.updated_at, t.target_date, t.due_date, t.timezone, t.priority, t.category, t.quantity, t.unit, t.aisle, t.is_completed, t.is_important, t.completed_at, t.snoozed_until, t.archived_at, t.created_by, t.assignee_id, t.recurrence, t.series_id, t.points, t.frequency, t.target_count,
+	COALESCE((SELECT string_agg(l.taskcontainer_id::text, ',') FROM public.taskcontainer_task l WHERE l.task_id = t.id), '')`
+
 const (
-	sqlGetAllTasks              = `SELECT * FROM public.task`
-	sqlGetTaskById              = `SELECT * FROM public.task WHERE id = $1`
-	sqlGetAllTasksByContainerId = `SELECT t.id, t.name, t.description, t.type, t.created_at, t.updated_at, t.target_date, t.priority, t.category, t.is_completed, t.is_important
+	sqlGetAllTasks              = `SELECT ` + taskColumns + ` FROM public.task t`
+	sqlGetTaskById              = `SELECT ` + taskColumns + ` FROM public.task t WHERE t.id = $1`
+	sqlGetAllTasksByContainerId = `SELECT ` + taskColumns + `
 									FROM public.task t
 									JOIN public.taskcontainer_task tct
 									ON t.id = tct.task_id
 									WHERE taskcontainer_id = $1`
-	sqlGetAllTasksByGroupId = `SELECT t.id, t.name, t.description, t.type, t.created_at, t.updated_at, t.target_date, t.priority, t.category, t.is_completed, t.is_important from public.task t
-										INNER JOIN public.taskcontainer_task tct
-										ON t.id = tct.task_id
-										WHERE tct.taskcontainer_id in (SELECT id FROM public.taskcontainer where usergroup_id = $1)`
-	sqlGetAllTasksByGroupIdAndImportant = `SELECT t.id, t.name, t.description, t.type, t.created_at, t.updated_at, t.target_date, t.priority, t.category, t.is_completed, t.is_important from public.task t
-											INNER JOIN public.taskcontainer_task tct
-											ON t.id = tct.task_id
-											WHERE tct.taskcontainer_id in (SELECT id FROM public.taskcontainer where usergroup_id = $1) AND t.is_important = true`
-
-	sqlCreateTask = `INSERT INTO public.task(id, name, description,type, created_at, updated_at, target_date, priority, category, is_completed, is_important)
-		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)`
-	sqlCreateTaskForJoinTable   = `INSERT INTO public.taskcontainer_task(taskcontainer_id, task_id) VALUES ($1, $2)`
+	// Grocery items are matched on their name with case and whitespace ignored, see model.NormalizeItemName.
+	sqlFindOpenTaskByItemName = `SELECT ` + taskColumns + `
+									FROM public.task t
+									JOIN public.taskcontainer_task tct
+									ON t.id = tct.task_id
+									WHERE tct.taskcontainer_id = $1
+									AND lower(regexp_replace(trim(t.name), '\s+', ' ', 'g')) = $2
+									AND t.is_completed = false AND t.archived_at IS NULL
+									ORDER BY t.created_at
+									LIMIT 1`
+	sqlGetAllTasksByGroupId = `SELECT ` + taskColumns + ` from public.task t
+										WHERE t.id in (SELECT tct.task_id FROM public.taskcontainer_task tct
+											INNER JOIN public.taskcontainer tc ON tc.id = tct.taskcontainer_id
+											WHERE tc.usergroup_id = $1)`
+	sqlGetAllTasksByGroupIdAndImportant = `SELECT ` + taskColumns + ` from public.task t
+											WHERE t.id in (SELECT tct.task_id FROM public.taskcontainer_task tct
+												INNER JOIN public.taskcontainer tc ON tc.id = tct.taskcontainer_id
+												WHERE tc.usergroup_id = $1) AND t.is_important = true`
+
+	sqlCreateTask = `INSERT INTO public.task(id, name, description,type, created_at, updated_at, target_date, due_date, timezone, priority, category, quantity, unit, aisle, is_completed, is_important, created_by, assignee_id, recurrence, series_id, points, frequency, target_count)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22,$23)`
+	sqlCreateTaskForJoinTable = `INSERT INTO public.taskcontainer_task(taskcontainer_id, task_id) VALUES ($1, $2)`
+	sqlGetTaskPlacements      = `SELECT task_id, section_id, position FROM public.taskcontainer_task WHERE taskcontainer_id=$1`
+	sqlShiftSectionPositions  = `UPDATE public.taskcontainer_task SET position = position + 1
+									WHERE taskcontainer_id=$1 AND section_id IS NOT DISTINCT FROM $2 AND position >= $3 AND task_id <> $4`
+	sqlMoveTaskToSection        = `UPDATE public.taskcontainer_task SET section_id=$3, position=$4 WHERE taskcontainer_id=$1 AND task_id=$2`
 	sqlDeleteTaskForJoinTable   = `DELETE FROM public.taskcontainer_task WHERE task_id=$1`
+	sqlDeleteTaskContainerLink  = `DELETE FROM public.taskcontainer_task WHERE taskcontainer_id=$1 AND task_id=$2`
+	sqlCountTaskContainerLinks  = `SELECT COUNT(*) FROM public.taskcontainer_task WHERE task_id=$1`
 	sqlDeleteTask               = `DELETE FROM public.task WHERE id=$1`
-	sqlUpdateTask               = `UPDATE public.task SET name=$2, description=$3, updated_at=$4, target_date=$5, priority=$6, category=$7 WHERE id=$1`
-	sqlUpdateTaskDoneField      = `UPDATE public.task SET is_completed=$1 WHERE id = $2;`
+	sqlUpdateTask               = `UPDATE public.task SET name=$2, description=$3, updated_at=$4, target_date=$5, due_date=$6, timezone=$7, priority=$8, category=$9, quantity=$10, unit=$11, aisle=$12, recurrence=$13, series_id=$14, points=$15, frequency=$16, target_count=$17 WHERE id=$1`
+	sqlUpdateTaskDoneField      = `UPDATE public.task SET is_completed=$2, completed_at=$3, archived_at=$4, updated_at=$5 WHERE id = $1;`
 	sqlUpdateTaskImportantField = `UPDATE public.task SET is_important=$1 WHERE id = $2;`
+	sqlUpdateTaskAssignee       = `UPDATE public.task SET assignee_id=$2, updated_at=$3 WHERE id=$1`
+	sqlGetOpenOccurrence        = `SELECT ` + taskColumns + ` FROM public.task t
+									WHERE t.series_id = $1 AND t.is_completed = false
+									ORDER BY t.created_at DESC
+									LIMIT 1`
+	sqlUpdateTaskSnooze = `UPDATE public.task SET snoozed_until=$2, target_date=$3, due_date=$4, updated_at=$5 WHERE id=$1`
+
+	sqlExcludeSnoozedTasks  = ` AND (t.snoozed_until IS NULL OR t.snoozed_until <= now())`
+	sqlExcludeArchivedTasks = ` AND t.archived_at IS NULL`
+	// All-day tasks sort at the start of their date in the task's own time zone.
+	sqlTaskDueInstant = `COALESCE(t.target_date, t.due_date::timestamp AT TIME ZONE t.timezone)`
+
+	sqlContainerTasks = `FROM public.task t INNER JOIN public.taskcontainer_task tct ON tct.task_id = t.id
+							WHERE tct.taskcontainer_id = $1`
+	// All-day tasks are overdue once their date is over in the task's own time zone.
+	sqlContainerStatsCounts = `SELECT COUNT(*),
+								COUNT(*) FILTER (WHERE NOT t.is_completed),
+								COUNT(*) FILTER (WHERE t.is_completed),
+								COUNT(*) FILTER (WHERE NOT t.is_completed
+									AND COALESCE(t.target_date, (t.due_date + 1)::timestamp AT TIME ZONE t.timezone) <= $2),
+								COUNT(*) FILTER (WHERE t.is_important),
+								AVG(EXTRACT(EPOCH FROM (t.completed_at - t.created_at)) / 3600) FILTER (WHERE t.is_completed AND t.completed_at IS NOT NULL) ` + sqlContainerTasks
+	sqlContainerStatsByPriority = `SELECT t.priority, COUNT(*) ` + sqlContainerTasks + ` GROUP BY t.priority`
+	sqlContainerStatsByCategory = `SELECT COALESCE(t.category, ''), COUNT(*) ` + sqlContainerTasks + ` GROUP BY COALESCE(t.category, '')`
+	// $2 is the last day of the series, $3 the number of days and $4 the time zone the days are taken in.
+	sqlContainerDailyCompletions = `SELECT to_char(d.day, 'YYYY-MM-DD'), COUNT(c.id)
+								FROM generate_series($2::date - ($3::int - 1), $2::date, interval '1 day') AS d(day)
+								LEFT JOIN (SELECT t.id, (t.completed_at AT TIME ZONE $4)::date AS completed_on ` + sqlContainerTasks + `
+									AND t.completed_at IS NOT NULL) c ON c.completed_on = d.day::date
+								GROUP BY d.day
+								ORDER BY d.day`
+
+	sqlGetArchivedTasksByGroupId = `SELECT ` + taskColumns + ` from public.task t
+										WHERE t.id in (SELECT tct.task_id FROM public.taskcontainer_task tct
+											INNER JOIN public.taskcontainer tc ON tc.id = tct.taskcontainer_id
+											WHERE tc.usergroup_id = $1) AND t.archived_at IS NOT NULL`
+	sqlOrderByArchivedAt = ` ORDER BY t.archived_at DESC`
+	// Completed tasks are archived once their group's archive_completed_after_days has passed.
+	// Tasks completed before completed_at was tracked fall back to updated_at.
+	sqlArchiveCompletedTasks = `UPDATE public.task t SET archived_at = $1
+								FROM public.taskcontainer_task tct
+								INNER JOIN public.taskcontainer tc ON tc.id = tct.taskcontainer_id
+								INNER JOIN public.usergroup ug ON ug.id = tc.usergroup_id
+								WHERE t.id = tct.task_id
+								AND t.is_completed = true
+								AND t.archived_at IS NULL
+								AND ug.archive_completed_after_days IS NOT NULL
+								AND COALESCE(t.completed_at, t.updated_at) <= $1 - make_interval(days => ug.archive_completed_after_days)`
+
+	sqlLockTask           = `SELECT id FROM public.task WHERE id = $1 FOR UPDATE`
+	sqlLockContainer      = `SELECT id FROM public.taskcontainer WHERE id = $1 FOR UPDATE`
+	sqlLockSeries         = `SELECT id FROM public.task WHERE series_id = $1 ORDER BY id FOR UPDATE`
+	sqlCountTaskRevisions = `SELECT COUNT(*) FROM public.task_revision WHERE task_id = $1`
+	sqlCreateTaskRevision = `INSERT INTO public.task_revision(task_id, number, snapshot, edited_by, created_at)
+		VALUES ($1, (SELECT COALESCE(MAX(number), 0) + 1 FROM public.task_revision WHERE task_id = $1), $2, $3, $4)`
+	sqlGetTaskRevisions = `SELECT task_id, number, snapshot, edited_by, created_at
+							FROM public.task_revision
+							WHERE task_id = $1
+							ORDER BY number`
+	sqlGetTaskRevision = `SELECT task_id, number, snapshot, edited_by, created_at
+							FROM public.task_revision
+							WHERE task_id = $1 AND number = $2`
+
+	sqlCreateTaskActivity = `INSERT INTO public.task_activity(task_id, user_id, action, detail, created_at)
+		VALUES ($1,$2,$3,$4,$5)`
+	sqlGetTaskActivities = `SELECT id, task_id, user_id, action, detail, created_at
+							FROM public.task_activity
+							WHERE task_id = $1
+							ORDER BY created_at DESC`
+
+	sqlGetUserGroupIdByTaskId = `SELECT tc.usergroup_id
+								FROM public.taskcontainer tc
+								INNER JOIN public.taskcontainer_task tct
+								ON tc.id = tct.taskcontainer_id
+								WHERE tct.task_id = $1
+								LIMIT 1`
+	sqlAddTaskWatcher = `INSERT INTO public.task_watcher(task_id, user_id, created_at) VALUES ($1, $2, $3)
+							ON CONFLICT (task_id, user_id) DO NOTHING`
+	sqlRemoveTaskWatcher = `DELETE FROM public.task_watcher WHERE task_id = $1 AND user_id = $2`
+	sqlGetTaskWatchers   = `SELECT task_id, user_id, created_at FROM public.task_watcher WHERE task_id = $1 ORDER BY created_at`
+
+	sqlDeleteTaskMentions = `DELETE FROM public.task_mention WHERE task_id = $1`
+	sqlCreateTaskMention  = `INSERT INTO public.task_mention(task_id, user_id, position) VALUES ($1, $2, $3)`
+	sqlGetTaskMentions    = `SELECT tm.user_id, u.username
+							FROM public.task_mention tm
+							INNER JOIN public.user u
+							ON u.id = tm.user_id
+							WHERE tm.task_id = $1
+							ORDER BY tm.position`
+	sqlGetMentionsByTaskIds = `SELECT tm.task_id, tm.user_id, u.username
+							FROM public.task_mention tm
+							INNER JOIN public.user u
+							ON u.id = tm.user_id
+							WHERE tm.task_id = ANY($1::uuid[])
+							ORDER BY tm.task_id, tm.position`
 )
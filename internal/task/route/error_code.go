@@ -16,6 +16,46 @@ const (
 	TaskUpdateServerError    = prefix + "update_server_error"
 	TaskStatusDoneError      = prefix + "status_done_error"
 	TaskUpdateImportantError = prefix + "update_important_error"
+	TaskDomainError          = prefix + "domain_validation_error"
+	TaskInvalidPriority      = prefix + "invalid_priority"
+	TaskNotGroceryContainer  = prefix + "not_grocery_container"
+	TaskContainerArchived    = prefix + "container_archived"
+
+	TaskSnoozeInvalidInput = prefix + "snooze_invalid_input"
+	TaskSnoozeServerError  = prefix + "snooze_server_error"
+
+	TaskActivityServerError = prefix + "activity_server_error"
+
+	TaskUserNotFound        = prefix + "user_get_not_found"
+	TaskNotGroupMember      = prefix + "not_group_member"
+	TaskWatcherServerError  = prefix + "watcher_server_error"
+	TaskWatcherCreatorError = prefix + "watcher_creator_implicit"
+	TaskNotifyServerError   = prefix + "notify_server_error"
+
+	TaskRevisionNotFound    = prefix + "revision_not_found"
+	TaskRevisionServerError = prefix + "revision_server_error"
+
+	TaskReminderInvalidInput = prefix + "reminder_invalid_input"
+	TaskReminderNotFound     = prefix + "reminder_not_found"
+	TaskReminderServerError  = prefix + "reminder_server_error"
+
+	TaskRotationNotFound      = prefix + "rotation_not_found"
+	TaskRotationServerError   = prefix + "rotation_server_error"
+	TaskNotChoresContainer    = prefix + "not_chores_container"
+	TaskRecurrenceServerError = prefix + "recurrence_server_error"
+
+	TaskPointsServerError = prefix + "points_server_error"
+
+	TaskHabitServerError = prefix + "habit_server_error"
+	TaskCheckInNotFound  = prefix + "check_in_not_found"
+
+	TaskBoardServerError = prefix + "board_server_error"
+	TaskSectionNotFound  = prefix + "section_not_found"
+	TaskStatsServerError = prefix + "stats_server_error"
+
+	TaskLinkServerError        = prefix + "link_server_error"
+	TaskLinkDifferentUserGroup = prefix + "link_different_usergroup"
+	TaskLinkNotFound           = prefix + "link_not_found"
 
 	TaskDeleteInvalidID         = prefix + "delete_invalid_order_id"
 	TaskDeleteNotFound          = prefix + "delete_not_found"
@@ -1,19 +1,30 @@
 package route
 
 import (
+	"database/sql"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth"
 	"github.com/google/uuid"
+	notificationModel "github.com/happYness-Project/taskManagementGolang/internal/notification/model"
+	notificationRepo "github.com/happYness-Project/taskManagementGolang/internal/notification/repository"
 	"github.com/happYness-Project/taskManagementGolang/internal/task/model"
 	taskRepo "github.com/happYness-Project/taskManagementGolang/internal/task/repository"
+	containerModel "github.com/happYness-Project/taskManagementGolang/internal/taskcontainer/model"
 	containerRepo "github.com/happYness-Project/taskManagementGolang/internal/taskcontainer/repository"
+	userModel "github.com/happYness-Project/taskManagementGolang/internal/user/model"
+	userRepo "github.com/happYness-Project/taskManagementGolang/internal/user/repository"
 	usergroupRepo "github.com/happYness-Project/taskManagementGolang/internal/usergroup/repository"
 	usergroupRoute "github.com/happYness-Project/taskManagementGolang/internal/usergroup/route"
 	"github.com/happYness-Project/taskManagementGolang/pkg/constants"
+	"github.com/happYness-Project/taskManagementGolang/pkg/errors"
 	"github.com/happYness-Project/taskManagementGolang/pkg/loggers"
 	"github.com/happYness-Project/taskManagementGolang/pkg/response"
 )
@@ -23,40 +34,106 @@ type Handler struct {
 	taskRepo      taskRepo.TaskRepository
 	containerRepo containerRepo.ContainerRepository
 	groupRepo     usergroupRepo.UserGroupRepository
+	userRepo      userRepo.UserRepository
+	notifyRepo    notificationRepo.NotificationRepository
+	reminderRepo  taskRepo.ReminderRepository
+	rotationRepo  taskRepo.RotationRepository
+	sectionRepo   containerRepo.SectionRepository
+	pointsRepo    taskRepo.PointsRepository
+	habitRepo     taskRepo.HabitRepository
 }
 
-func NewHandler(logger *loggers.AppLogger, repo taskRepo.TaskRepository, tcRepo containerRepo.ContainerRepository, ugRepo usergroupRepo.UserGroupRepository) *Handler {
-	return &Handler{logger: logger, taskRepo: repo, containerRepo: tcRepo, groupRepo: ugRepo}
+func NewHandler(logger *loggers.AppLogger, repo taskRepo.TaskRepository, tcRepo containerRepo.ContainerRepository, ugRepo usergroupRepo.UserGroupRepository, uRepo userRepo.UserRepository, nRepo notificationRepo.NotificationRepository, rRepo taskRepo.ReminderRepository, roRepo taskRepo.RotationRepository, pRepo taskRepo.PointsRepository, hRepo taskRepo.HabitRepository, sRepo containerRepo.SectionRepository) *Handler {
+	return &Handler{logger: logger, taskRepo: repo, containerRepo: tcRepo, groupRepo: ugRepo, userRepo: uRepo, notifyRepo: nRepo, reminderRepo: rRepo, rotationRepo: roRepo, pointsRepo: pRepo, habitRepo: hRepo, sectionRepo: sRepo}
 }
 func (h *Handler) RegisterRoutes(router chi.Router) {
 	router.Route("/api/tasks", func(r chi.Router) {
 		r.Get("/", h.handleGetTasks)
-		r.Get("/{taskID}", h.handleGetTask)
-		r.Put("/{taskID}", h.handleUpdateTask)
-		r.Delete("/{taskID}", h.handleDeleteTask)
-		r.Patch("/{taskID}/toggle-completion", h.handleDoneTask)
-		r.Patch("/{taskID}/toggle-important", h.handleImportantTask)
+		r.Group(func(r chi.Router) {
+			r.Use(h.requireAccess)
+			r.Get("/{taskID}", h.handleGetTask)
+			r.Put("/{taskID}", h.handleUpdateTask)
+			r.Delete("/{taskID}", h.handleDeleteTask)
+			r.Patch("/{taskID}/toggle-completion", h.handleDoneTask)
+			r.Patch("/{taskID}/toggle-important", h.handleImportantTask)
+			r.Post("/{taskID}/snooze", h.handleSnoozeTask)
+			r.Delete("/{taskID}/snooze", h.handleUnsnoozeTask)
+			r.Get("/{taskID}/activities", h.handleGetTaskActivities)
+			r.Get("/{taskID}/watchers", h.handleGetTaskWatchers)
+			r.Post("/{taskID}/watchers", h.handleWatchTask)
+			r.Delete("/{taskID}/watchers", h.handleUnwatchTask)
+			r.Get("/{taskID}/revisions", h.handleGetTaskRevisions)
+			r.Get("/{taskID}/revisions/{revisionNumber}", h.handleGetTaskRevision)
+			r.Post("/{taskID}/revisions/{revisionNumber}/revert", h.handleRevertTaskRevision)
+			r.Get("/{taskID}/reminders", h.handleGetReminders)
+			r.Post("/{taskID}/reminders", h.handleCreateReminder)
+			r.Delete("/{taskID}/reminders/{reminderID}", h.handleDeleteReminder)
+			r.Get("/{taskID}/check-ins", h.handleGetHabitCalendar)
+			r.Post("/{taskID}/check-ins", h.handleCheckInHabit)
+			r.Delete("/{taskID}/check-ins/{date}", h.handleDeleteCheckIn)
+			r.Get("/{taskID}/streak", h.handleGetHabitStreak)
+			r.Get("/{taskID}/rotation", h.handleGetRotation)
+			r.Put("/{taskID}/rotation", h.handleUpdateRotation)
+			r.Post("/{taskID}/rotation/skip", h.handleSkipRotation)
+			r.Post("/{taskID}/rotation/swap", h.handleSwapRotation)
+		})
+	})
+	router.Group(func(r chi.Router) {
+		r.Use(h.requireAccess)
+		r.Get("/api/task-containers/{containerID}/tasks", h.handleGetTasksByContainerId)
+		r.Get("/api/task-containers/{containerID}/shopping-list", h.handleGetShoppingList)
+		r.Get("/api/task-containers/{containerID}/board", h.handleGetBoard)
+		r.Get("/api/task-containers/{containerID}/stats", h.handleGetContainerStats)
+		r.Put("/api/task-containers/{containerID}/tasks/{taskID}/section", h.handleMoveTaskToSection)
+		r.Post("/api/task-containers/{containerID}/tasks", h.handleCreateTask)
+		r.Post("/api/task-containers/{containerID}/tasks/{taskID}/link", h.handleLinkTaskToContainer)
+		r.Delete("/api/task-containers/{containerID}/tasks/{taskID}/link", h.handleUnlinkTaskFromContainer)
 	})
-	router.Get("/api/task-containers/{containerID}/tasks", h.handleGetTasksByContainerId)
-	router.Post("/api/task-containers/{containerID}/tasks", h.handleCreateTask)
 	router.Get("/api/user-groups/{usergroupID}/tasks", h.handleGetTasksByGroupId)
+	router.Get("/api/user-groups/{usergroupID}/archive", h.handleGetArchivedTasksByGroupId)
+	router.Get("/api/user-groups/{usergroupID}/leaderboard", h.handleGetLeaderboard)
 }
 func (h *Handler) handleGetTasks(w http.ResponseWriter, r *http.Request) {
-	tasks, err := h.taskRepo.GetAllTasks()
+	loc, err := viewerLocation(r)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.InvalidParameter).Msg(err.Error())
+		response.ErrorResponse(w, http.StatusBadRequest, *(response.New(constants.InvalidParameter, "Invalid Parameter", err.Error())))
+		return
+	}
+	tasks, err := h.taskRepo.GetAllTasks(h.currentUserId(r))
 	if err != nil {
 		h.logger.Error().Err(err).Str("ErrorCode", TaskGetServerError).Msg(err.Error())
 		response.InternalServerError(w, "Error occurred during getting all tasks.")
 		return
 	}
+	if err = h.attachMentions(tasks); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskGetServerError).Msg("Error occurred during GetMentionsByTaskIds")
+		response.InternalServerError(w, "Failed to get task mentions")
+		return
+	}
+	evaluateDue(tasks, loc)
 	response.SuccessJson(w, tasks, "successfully get tasks", http.StatusOK)
 }
 func (h *Handler) handleGetTask(w http.ResponseWriter, r *http.Request) {
-	task, err := h.taskRepo.GetTaskById(chi.URLParam(r, "taskID"))
+	loc, err := viewerLocation(r)
 	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.InvalidParameter).Msg(err.Error())
+		response.ErrorResponse(w, http.StatusBadRequest, *(response.New(constants.InvalidParameter, "Invalid Parameter", err.Error())))
+		return
+	}
+	task, err := h.taskRepo.GetTaskById(chi.URLParam(r, "taskID"))
+	if err != nil || task == nil {
 		h.logger.Error().Err(err).Str("ErrorCode", TaskGetNotFound).Msg("Error occurred during GetTask.")
 		response.ErrorResponse(w, http.StatusNotFound, *(response.New(TaskGetNotFound, "Not Found", "task does not exist")))
 		return
 	}
+	task.Mentions, err = h.taskRepo.GetTaskMentions(task.TaskId)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskGetServerError).Msg("Error occurred during GetTaskMentions")
+		response.InternalServerError(w, "Failed to get task mentions")
+		return
+	}
+	task.EvaluateDue(time.Now(), loc)
 	response.WriteJsonWithEncode(w, http.StatusOK, task)
 }
 
@@ -67,15 +144,216 @@ func (h *Handler) handleGetTasksByContainerId(w http.ResponseWriter, r *http.Req
 		response.BadRequestMissingParameters(w)
 		return
 	}
-	tasks, err := h.taskRepo.GetTasksByContainerId(containerId)
+	filter, err := taskFilterFromQuery(r)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.InvalidParameter).Msg(err.Error())
+		response.ErrorResponse(w, http.StatusBadRequest, *(response.New(constants.InvalidParameter, "Invalid Parameter", err.Error())))
+		return
+	}
+	loc, err := viewerLocation(r)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.InvalidParameter).Msg(err.Error())
+		response.ErrorResponse(w, http.StatusBadRequest, *(response.New(constants.InvalidParameter, "Invalid Parameter", err.Error())))
+		return
+	}
+	tasks, err := h.taskRepo.GetTasksByContainerId(containerId, filter)
 	if err != nil {
 		h.logger.Error().Err(err).Str("ErrorCode", TaskGetServerError).Msg("Error occurred during GetTasksByContainerId")
 		response.ErrorResponse(w, http.StatusInternalServerError, *(response.New(TaskGetServerError, "Failed to get tasks by container id", err.Error())))
 		return
 	}
+	if err = h.attachMentions(tasks); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskGetServerError).Msg("Error occurred during GetMentionsByTaskIds")
+		response.InternalServerError(w, "Failed to get task mentions")
+		return
+	}
+	evaluateDue(tasks, loc)
 	response.WriteJsonWithEncode(w, http.StatusOK, tasks)
 }
 
+func (h *Handler) handleGetShoppingList(w http.ResponseWriter, r *http.Request) {
+	container, err := h.containerRepo.GetById(chi.URLParam(r, "containerID"))
+	if err != nil || container == nil || container.Id == "" {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskGetTaskContainerNotFound).Msg("Task container not found")
+		response.NotFound(w, TaskGetTaskContainerNotFound, "Task container not found")
+		return
+	}
+	if !container.IsGrocery() {
+		h.logger.Error().Str("ErrorCode", TaskNotGroceryContainer).Msg("shopping list requested for a non grocery container")
+		response.ErrorResponse(w, http.StatusBadRequest, *(response.New(TaskNotGroceryContainer, "Bad Request", "shopping list is only available for grocery containers")))
+		return
+	}
+	tasks, err := h.taskRepo.GetTasksByContainerId(container.Id, taskRepo.TaskFilter{})
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskGetServerError).Msg("Error occurred during GetTasksByContainerId")
+		response.InternalServerError(w, "Failed to get shopping list")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusOK, model.GroupByAisle(tasks))
+}
+
+// handleGetBoard returns the sections of the container in order, each with its
+// tasks ordered by position, followed by the tasks without a section.
+func (h *Handler) handleGetBoard(w http.ResponseWriter, r *http.Request) {
+	container, err := h.containerRepo.GetById(chi.URLParam(r, "containerID"))
+	if err != nil || container == nil || container.Id == "" {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskGetTaskContainerNotFound).Msg("Task container not found")
+		response.NotFound(w, TaskGetTaskContainerNotFound, "Task container not found")
+		return
+	}
+	if _, ok := h.groupMember(w, r, container.UsergroupId); !ok {
+		return
+	}
+	filter, err := taskFilterFromQuery(r)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.InvalidParameter).Msg(err.Error())
+		response.ErrorResponse(w, http.StatusBadRequest, *(response.New(constants.InvalidParameter, "Invalid Parameter", err.Error())))
+		return
+	}
+	loc, err := viewerLocation(r)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.InvalidParameter).Msg(err.Error())
+		response.ErrorResponse(w, http.StatusBadRequest, *(response.New(constants.InvalidParameter, "Invalid Parameter", err.Error())))
+		return
+	}
+	sections, err := h.sectionRepo.GetSections(container.Id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskBoardServerError).Msg("Error occurred during GetSections")
+		response.InternalServerError(w, "Failed to get board")
+		return
+	}
+	tasks, err := h.taskRepo.GetTasksByContainerId(container.Id, filter)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskBoardServerError).Msg("Error occurred during GetTasksByContainerId")
+		response.InternalServerError(w, "Failed to get board")
+		return
+	}
+	placements, err := h.taskRepo.GetTaskPlacements(container.Id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskBoardServerError).Msg("Error occurred during GetTaskPlacements")
+		response.InternalServerError(w, "Failed to get board")
+		return
+	}
+	if err = h.attachMentions(tasks); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskGetServerError).Msg("Error occurred during GetMentionsByTaskIds")
+		response.InternalServerError(w, "Failed to get task mentions")
+		return
+	}
+	evaluateDue(tasks, loc)
+	response.WriteJsonWithEncode(w, http.StatusOK, buildBoard(*container, sections, tasks, placements))
+}
+
+// handleGetContainerStats returns task counts and a daily completion series
+// for the last days (30 by default) in the viewer's time zone.
+func (h *Handler) handleGetContainerStats(w http.ResponseWriter, r *http.Request) {
+	days := model.DefaultStatsDays
+	if value := r.URL.Query().Get("days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > model.MaxStatsDays {
+			h.logger.Error().Err(err).Str("ErrorCode", constants.InvalidParameter).Msg(model.ErrInvalidStatsDays.Error())
+			response.ErrorResponse(w, http.StatusBadRequest, *(response.New(constants.InvalidParameter, "Invalid Parameter", model.ErrInvalidStatsDays.Error())))
+			return
+		}
+		days = parsed
+	}
+	loc, err := viewerLocation(r)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.InvalidParameter).Msg(err.Error())
+		response.ErrorResponse(w, http.StatusBadRequest, *(response.New(constants.InvalidParameter, "Invalid Parameter", err.Error())))
+		return
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	container, err := h.containerRepo.GetById(chi.URLParam(r, "containerID"))
+	if err != nil || container == nil || container.Id == "" {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskGetTaskContainerNotFound).Msg("Task container not found")
+		response.NotFound(w, TaskGetTaskContainerNotFound, "Task container not found")
+		return
+	}
+	if _, ok := h.groupMember(w, r, container.UsergroupId); !ok {
+		return
+	}
+	stats, err := h.taskRepo.GetContainerStats(container.Id, time.Now(), loc, days)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskStatsServerError).Msg("Error occurred during GetContainerStats")
+		response.InternalServerError(w, "Failed to get container stats")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusOK, stats)
+}
+
+func (h *Handler) handleMoveTaskToSection(w http.ResponseWriter, r *http.Request) {
+	var moveDto MoveTaskDto
+	if err := response.ParseJson(r, &moveDto); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.RequestBodyError).Msg("Invalid JSON body for MoveTaskDto")
+		response.InvalidJsonBody(w, "Invalid json body for moving task")
+		return
+	}
+	if moveDto.Position < 0 {
+		h.logger.Error().Str("ErrorCode", constants.InvalidParameter).Msg("negative board position")
+		response.ErrorResponse(w, http.StatusBadRequest, *(response.New(constants.InvalidParameter, "Invalid Parameter", "position cannot be negative")))
+		return
+	}
+	containerId := chi.URLParam(r, "containerID")
+	task, ok := h.findTaskForMember(w, r)
+	if !ok {
+		return
+	}
+	if !task.IsInContainer(containerId) {
+		h.logger.Error().Str("ErrorCode", TaskLinkNotFound).Msg("task is not in the container")
+		response.NotFound(w, TaskLinkNotFound, "Task is not in the container")
+		return
+	}
+
+	completes, changeStatus := false, false
+	if moveDto.SectionId != nil {
+		section, err := h.sectionRepo.GetSection(containerId, *moveDto.SectionId)
+		if err != nil {
+			h.logger.Error().Err(err).Str("ErrorCode", TaskBoardServerError).Msg("Error occurred during GetSection")
+			response.InternalServerError(w, "Failed to move task")
+			return
+		}
+		if section == nil {
+			h.logger.Error().Str("ErrorCode", TaskSectionNotFound).Msg("Cannot find section of the container")
+			response.NotFound(w, TaskSectionNotFound, "Cannot find section")
+			return
+		}
+		completes, changeStatus = section.Completes()
+		changeStatus = changeStatus && moveDto.ApplyStatus && completes != task.IsCompleted
+	}
+	if changeStatus {
+		var err error
+		if task.IsHabit() {
+			err = model.ErrHabitNotCompletable
+		} else if completes {
+			err = task.Complete(time.Now())
+		} else {
+			err = task.Reopen(time.Now())
+		}
+		if err != nil {
+			h.domainError(w, err)
+			return
+		}
+	}
+
+	var err error
+	if changeStatus {
+		err = h.taskRepo.MoveTaskToSectionAndDone(containerId, *task, moveDto.SectionId, moveDto.Position, h.completion(r, *task))
+	} else {
+		err = h.taskRepo.MoveTaskToSection(containerId, task.TaskId, moveDto.SectionId, moveDto.Position)
+	}
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskBoardServerError).Msg("Error occurred during MoveTaskToSection")
+		response.InternalServerError(w, "Failed to move task")
+		return
+	}
+	if changeStatus {
+		h.completionChanged(r, *task)
+	}
+	response.WriteJsonWithEncode(w, http.StatusOK, model.TaskPlacement{TaskId: task.TaskId, SectionId: moveDto.SectionId, Position: moveDto.Position})
+}
+
 func (h *Handler) handleCreateTask(w http.ResponseWriter, r *http.Request) {
 	containerId := chi.URLParam(r, "containerID")
 	if containerId == "" {
@@ -90,32 +368,111 @@ func (h *Handler) handleCreateTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	container, err := h.containerRepo.GetById(containerId)
-	if err != nil {
+	if err != nil || container == nil || container.Id == "" {
 		h.logger.Error().Err(err).Str("ErrorCode", TaskGetTaskContainerNotFound).Msg("Task container not found")
-		response.ErrorResponse(w, http.StatusNotFound, *(response.New(TaskGetTaskContainerNotFound, "Task container not found", err.Error())))
+		response.NotFound(w, TaskGetTaskContainerNotFound, "Task container not found")
+		return
+	}
+	if container.IsArchived() {
+		h.logger.Error().Str("ErrorCode", TaskContainerArchived).Msg("Task container is archived")
+		response.ErrorResponse(w, http.StatusUnprocessableEntity, *response.New(TaskContainerArchived, "Domain Validation Error", containerModel.ErrContainerArchived.Error()))
+		return
+	}
+	priority, err := model.ParsePriority(createDto.Priority)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskInvalidPriority).Msg(err.Error())
+		response.ErrorResponse(w, http.StatusUnprocessableEntity, *response.New(TaskInvalidPriority, "Domain Validation Error", err.Error()))
+		return
+	}
+
+	recurrence, err := model.ParseRecurrence(createDto.Recurrence)
+	if err != nil {
+		h.domainError(w, err)
 		return
 	}
 
-	task := model.Task{
-		TaskId:     uuid.New().String(),
-		TaskName:   createDto.TaskName,
-		TaskDesc:   createDto.TaskDesc,
-		TaskType:   "",
-		CreatedAt:  time.Now(),
-		UpdatedAt:  time.Now(),
-		TargetDate: createDto.TargetDate,
-		Priority:   createDto.Priority,
-		Category:   createDto.Category,
+	if createDto.DueDate != "" && !createDto.TargetDate.IsZero() {
+		h.domainError(w, model.ErrDueDateConflict)
+		return
+	}
+	task, err := model.NewTask(createDto.TaskName, createDto.TaskDesc, createDto.Category, priority, createDto.TargetDate, createDto.AllowPastTargetDate)
+	if err != nil {
+		h.domainError(w, err)
+		return
+	}
+	if err = task.SetPoints(createDto.Points); err != nil {
+		h.domainError(w, err)
+		return
+	}
+	user := h.currentUser(r)
+	timezone := createDto.Timezone
+	if timezone == "" && user != nil {
+		timezone = user.Timezone
+	}
+	if err = task.SetTimezone(timezone); err != nil {
+		h.domainError(w, err)
+		return
+	}
+	if createDto.DueDate != "" {
+		if err = task.RescheduleAllDay(createDto.DueDate, createDto.AllowPastTargetDate); err != nil {
+			h.domainError(w, err)
+			return
+		}
+	}
+	if container.IsHabit() {
+		frequency, err := model.ParseHabitFrequency(createDto.Frequency)
+		if err != nil {
+			h.domainError(w, err)
+			return
+		}
+		if err = task.SetHabitTarget(frequency, createDto.TargetCount); err != nil {
+			h.domainError(w, err)
+			return
+		}
+	}
+	if container.IsGrocery() {
+		if err = task.SetQuantity(createDto.Quantity, createDto.Unit); err != nil {
+			h.domainError(w, err)
+			return
+		}
+		if err = task.SetAisle(createDto.Aisle); err != nil {
+			h.domainError(w, err)
+			return
+		}
+	}
+	task.TaskId = uuid.New().String()
+	task.SetRecurrence(recurrence)
+	if user != nil {
+		task.CreatedBy = &user.Id
+	}
+	var newTask model.Task
+	if container.IsGrocery() {
+		var merged bool
+		newTask, merged, err = h.taskRepo.CreateGroceryItem(container.Id, *task)
+		if err == nil && merged {
+			h.recordActivity(r, newTask.TaskId, model.ActivityMerged, fmt.Sprintf("merged %s", quantityText(*task)))
+			response.WriteJsonWithEncode(w, http.StatusOK, newTask)
+			return
+		}
+	} else {
+		newTask, err = h.taskRepo.CreateTask(container.Id, *task)
 	}
-	newTask, err := h.taskRepo.CreateTask(container.Id, task)
 	if err != nil {
 		h.logger.Error().Err(err).Str("ErrorCode", TaskCreateServerError).Msg("Error occurred during CreateTask")
 		response.ErrorResponse(w, http.StatusBadRequest, *(response.New(TaskCreateServerError, "Failed to create task", err.Error())))
 		return
 	}
+	newTask.Mentions = h.saveMentions(r, newTask, container.UsergroupId, nil)
 	response.WriteJsonWithEncode(w, http.StatusCreated, newTask)
 }
 
+func quantityText(item model.Task) string {
+	if item.Quantity == nil {
+		return "1"
+	}
+	return strings.TrimSpace(strconv.FormatFloat(*item.Quantity, 'f', -1, 64) + " " + item.Unit)
+}
+
 func (h *Handler) handleUpdateTask(w http.ResponseWriter, r *http.Request) {
 	var updateDto UpdateTaskDto
 	if err := response.ParseJson(r, &updateDto); err != nil {
@@ -130,19 +487,116 @@ func (h *Handler) handleUpdateTask(w http.ResponseWriter, r *http.Request) {
 		response.NotFound(w, TaskGetNotFound, "Cannot find task")
 		return
 	}
+	priority, err := model.ParsePriority(updateDto.Priority)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskInvalidPriority).Msg(err.Error())
+		response.ErrorResponse(w, http.StatusUnprocessableEntity, *response.New(TaskInvalidPriority, "Domain Validation Error", err.Error()))
+		return
+	}
+	recurrence, err := model.ParseRecurrence(updateDto.Recurrence)
+	if err != nil {
+		h.domainError(w, err)
+		return
+	}
 
-	task.TaskName = updateDto.TaskName
-	task.TaskDesc = updateDto.TaskDesc
-	task.TargetDate = updateDto.TargetDate
-	task.Priority = updateDto.Priority
-	task.Category = updateDto.Category
-	err = h.taskRepo.UpdateTask(*task)
+	if err = task.Rename(updateDto.TaskName); err != nil {
+		h.domainError(w, err)
+		return
+	}
+	if err = task.Describe(updateDto.TaskDesc); err != nil {
+		h.domainError(w, err)
+		return
+	}
+	if err = task.Categorize(updateDto.Category); err != nil {
+		h.domainError(w, err)
+		return
+	}
+	if updateDto.DueDate != "" && !updateDto.TargetDate.IsZero() {
+		h.domainError(w, model.ErrDueDateConflict)
+		return
+	}
+	if updateDto.Timezone != "" && updateDto.Timezone != task.Timezone {
+		if err = task.SetTimezone(updateDto.Timezone); err != nil {
+			h.domainError(w, err)
+			return
+		}
+	}
+	if updateDto.DueDate != "" {
+		if updateDto.DueDate != task.DueDate {
+			if err = task.RescheduleAllDay(updateDto.DueDate, updateDto.AllowPastTargetDate); err != nil {
+				h.domainError(w, err)
+				return
+			}
+		}
+	} else if !task.TargetDate.Equal(updateDto.TargetDate) || task.IsAllDay() {
+		if err = task.Reschedule(updateDto.TargetDate, updateDto.AllowPastTargetDate); err != nil {
+			h.domainError(w, err)
+			return
+		}
+	}
+	task.Priority = priority
+	task.SetRecurrence(recurrence)
+	if err = task.SetPoints(updateDto.Points); err != nil {
+		h.domainError(w, err)
+		return
+	}
+	if task.IsHabit() && updateDto.Frequency != "" {
+		frequency, err := model.ParseHabitFrequency(updateDto.Frequency)
+		if err != nil {
+			h.domainError(w, err)
+			return
+		}
+		if err = task.SetHabitTarget(frequency, updateDto.TargetCount); err != nil {
+			h.domainError(w, err)
+			return
+		}
+	}
+	grocery, err := h.isGroceryTask(*task)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskGetTaskContainerNotFound).Msg("Task container not found")
+		response.NotFound(w, TaskGetTaskContainerNotFound, "Task container not found")
+		return
+	}
+	if grocery {
+		if err = task.SetQuantity(updateDto.Quantity, updateDto.Unit); err != nil {
+			h.domainError(w, err)
+			return
+		}
+		if err = task.SetAisle(updateDto.Aisle); err != nil {
+			h.domainError(w, err)
+			return
+		}
+	}
+	var editedBy *int
+	if user := h.currentUser(r); user != nil {
+		editedBy = &user.Id
+	}
+	err = h.taskRepo.UpdateTask(*task, editedBy)
 	if err != nil {
 		h.logger.Error().Err(err).Str("ErrorCode", TaskUpdateServerError).Msg("Not able to update task")
 		response.ErrorResponse(w, http.StatusBadRequest, *(response.New(TaskUpdateServerError, "Failed to update task", err.Error())))
 		return
 	}
-	response.WriteJsonWithEncode(w, http.StatusOK, "task updated successfully")
+	h.rescheduleReminders(*task)
+	h.notifyWatchers(r, *task, notificationModel.TypeTaskUpdated, fmt.Sprintf("Task '%s' has been updated.", task.TaskName))
+	if groupId, err := h.taskRepo.GetUserGroupIdByTaskId(task.TaskId); err == nil {
+		previous, _ := h.taskRepo.GetTaskMentions(task.TaskId)
+		task.Mentions = h.saveMentions(r, *task, groupId, previous)
+	}
+	response.WriteJsonWithEncode(w, http.StatusOK, task)
+}
+
+// isGroceryTask reports whether the task's own container is a grocery list,
+// the only place quantities and aisles apply.
+func (h *Handler) isGroceryTask(task model.Task) (bool, error) {
+	if len(task.ContainerIds) == 0 {
+		return false, nil
+	}
+	container, err := h.containerRepo.GetById(task.ContainerIds[0])
+	if err != nil || container == nil {
+		return false, err
+	}
+	return container.IsGrocery(), nil
 }
 
 func (h *Handler) handleDeleteTask(w http.ResponseWriter, r *http.Request) {
@@ -182,12 +636,31 @@ func (h *Handler) handleDoneTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.taskRepo.DoneTask(taskId, toggleBody.IsCompleted)
+	if task.IsHabit() {
+		h.domainError(w, model.ErrHabitNotCompletable)
+		return
+	}
+	// Toggling to the current state stays a no-op, as clients retry it.
+	if task.IsCompleted == toggleBody.IsCompleted {
+		response.WriteJsonWithEncode(w, http.StatusOK, "task is changed to Done.")
+		return
+	}
+	if toggleBody.IsCompleted {
+		err = task.Complete(time.Now())
+	} else {
+		err = task.Reopen(time.Now())
+	}
+	if err != nil {
+		h.domainError(w, err)
+		return
+	}
+	err = h.taskRepo.DoneTask(*task, h.completion(r, *task))
 	if err != nil {
 		h.logger.Error().Err(err).Str("ErrorCode", TaskStatusDoneError).Msg("Error occurred during done task")
 		response.ErrorResponse(w, http.StatusNotFound, *(response.New(TaskStatusDoneError, "Failed to toggle done")))
 		return
 	}
+	h.completionChanged(r, *task)
 	response.WriteJsonWithEncode(w, http.StatusOK, "task is changed to Done.")
 }
 
@@ -244,10 +717,23 @@ func (h *Handler) handleGetTasksByGroupId(w http.ResponseWriter, r *http.Request
 		response.NotFound(w, usergroupRoute.UserGroupGetNotFound, "usergroup cannot be found")
 		return
 	}
+	filter, err := taskFilterFromQuery(r)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.InvalidParameter).Msg(err.Error())
+		response.ErrorResponse(w, http.StatusBadRequest, *(response.New(constants.InvalidParameter, "Invalid Parameter", err.Error())))
+		return
+	}
+	loc, err := viewerLocation(r)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.InvalidParameter).Msg(err.Error())
+		response.ErrorResponse(w, http.StatusBadRequest, *(response.New(constants.InvalidParameter, "Invalid Parameter", err.Error())))
+		return
+	}
+	filter.ViewerId = h.currentUserId(r)
 	var tasks []model.Task
 
 	if r.URL.Query().Get("important") == "true" {
-		tasks, err = h.taskRepo.GetAllTasksByGroupIdOnlyImportant(usergroup.GroupId)
+		tasks, err = h.taskRepo.GetAllTasksByGroupIdOnlyImportant(usergroup.GroupId, filter)
 		if err != nil {
 			h.logger.Error().Err(err).Msg("error occurred during getting important tasks")
 			response.ErrorResponse(w, http.StatusBadRequest, *(response.New(TaskGetServerError, "Failed to get important tasks", err.Error())))
@@ -258,12 +744,1230 @@ func (h *Handler) handleGetTasksByGroupId(w http.ResponseWriter, r *http.Request
 		response.ErrorResponse(w, http.StatusBadRequest, *(response.New(TaskGetServerError, "Not implemented for important=false case")))
 		return
 	} else {
-		tasks, err = h.taskRepo.GetAllTasksByGroupId(usergroup.GroupId)
+		tasks, err = h.taskRepo.GetAllTasksByGroupId(usergroup.GroupId, filter)
 		if err != nil {
 			h.logger.Error().Err(err).Msg("error occurred during getting tasks")
 			response.ErrorResponse(w, http.StatusBadRequest, *(response.New(TaskGetServerError, "Failed to get tasks")))
 			return
 		}
 	}
+	if err = h.attachMentions(tasks); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskGetServerError).Msg("Error occurred during GetMentionsByTaskIds")
+		response.InternalServerError(w, "Failed to get task mentions")
+		return
+	}
+	evaluateDue(tasks, loc)
+	response.WriteJsonWithEncode(w, http.StatusOK, tasks)
+}
+
+func (h *Handler) handleGetArchivedTasksByGroupId(w http.ResponseWriter, r *http.Request) {
+	groupId, err := strconv.Atoi(chi.URLParam(r, "usergroupID"))
+	if err != nil {
+		h.logger.Error().Err(err).Msg("invalid Group ID")
+		response.ErrorResponse(w, http.StatusBadRequest, *(response.New(constants.InvalidParameter, "Invalid Group ID")))
+		return
+	}
+	usergroup, err := h.groupRepo.GetById(groupId)
+	if err != nil || usergroup == nil || usergroup.GroupId == 0 {
+		h.logger.Error().Err(err).Msg("usergroup cannot be found")
+		response.NotFound(w, usergroupRoute.UserGroupGetNotFound, "usergroup cannot be found")
+		return
+	}
+	user, ok := h.groupMember(w, r, usergroup.GroupId)
+	if !ok {
+		return
+	}
+	loc, err := viewerLocation(r)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.InvalidParameter).Msg(err.Error())
+		response.ErrorResponse(w, http.StatusBadRequest, *(response.New(constants.InvalidParameter, "Invalid Parameter", err.Error())))
+		return
+	}
+	tasks, err := h.taskRepo.GetArchivedTasksByGroupId(usergroup.GroupId, user.Id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskGetServerError).Msg("Error occurred during GetArchivedTasksByGroupId")
+		response.InternalServerError(w, "Failed to get archived tasks")
+		return
+	}
+	if err = h.attachMentions(tasks); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskGetServerError).Msg("Error occurred during GetMentionsByTaskIds")
+		response.InternalServerError(w, "Failed to get task mentions")
+		return
+	}
+	evaluateDue(tasks, loc)
 	response.WriteJsonWithEncode(w, http.StatusOK, tasks)
 }
+
+// handleGetLeaderboard ranks the group members by the points earned in the
+// period given by the period query parameter (week by default). Streaks are
+// counted up to today in the viewer's time zone.
+func (h *Handler) handleGetLeaderboard(w http.ResponseWriter, r *http.Request) {
+	groupId, err := strconv.Atoi(chi.URLParam(r, "usergroupID"))
+	if err != nil {
+		h.logger.Error().Err(err).Msg("invalid Group ID")
+		response.ErrorResponse(w, http.StatusBadRequest, *(response.New(constants.InvalidParameter, "Invalid Group ID")))
+		return
+	}
+	period, err := model.ParseLeaderboardPeriod(r.URL.Query().Get("period"))
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.InvalidParameter).Msg(err.Error())
+		response.ErrorResponse(w, http.StatusBadRequest, *(response.New(constants.InvalidParameter, "Invalid Parameter", err.Error())))
+		return
+	}
+	loc, err := viewerLocation(r)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.InvalidParameter).Msg(err.Error())
+		response.ErrorResponse(w, http.StatusBadRequest, *(response.New(constants.InvalidParameter, "Invalid Parameter", err.Error())))
+		return
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	usergroup, err := h.groupRepo.GetById(groupId)
+	if err != nil || usergroup == nil || usergroup.GroupId == 0 {
+		h.logger.Error().Err(err).Msg("usergroup cannot be found")
+		response.NotFound(w, usergroupRoute.UserGroupGetNotFound, "usergroup cannot be found")
+		return
+	}
+	user := h.currentUser(r)
+	if user == nil {
+		h.logger.Error().Str("ErrorCode", TaskUserNotFound).Msg("Not able to find user from token")
+		response.NotFound(w, TaskUserNotFound, "Not able to find a user")
+		return
+	}
+	if !h.isGroupMember(w, usergroup.GroupId, user.Id) {
+		return
+	}
+
+	now := time.Now()
+	var since *time.Time
+	if start, ok := period.Since(now, loc); ok {
+		since = &start
+	}
+	entries, err := h.pointsRepo.GetLeaderboard(usergroup.GroupId, since)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskPointsServerError).Msg("Error occurred during GetLeaderboard")
+		response.InternalServerError(w, "Failed to get leaderboard")
+		return
+	}
+	days, err := h.pointsRepo.GetAwardDays(usergroup.GroupId)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskPointsServerError).Msg("Error occurred during GetAwardDays")
+		response.InternalServerError(w, "Failed to get leaderboard")
+		return
+	}
+	// Streaks are counted up to today in each user's own time zone, the zone
+	// their awards were dated in.
+	for i := range entries {
+		userLoc, err := model.LoadTimezone(entries[i].Timezone)
+		if err != nil {
+			userLoc = time.UTC
+		}
+		entries[i].Streak = model.Streak(days[entries[i].UserId], now.In(userLoc).Format(model.DateLayout))
+	}
+	model.SortLeaderboard(entries)
+	response.WriteJsonWithEncode(w, http.StatusOK, entries)
+}
+
+func (h *Handler) handleSnoozeTask(w http.ResponseWriter, r *http.Request) {
+	var snoozeDto SnoozeTaskDto
+	if err := response.ParseJson(r, &snoozeDto); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.RequestBodyError).Msg("Invalid JSON body for SnoozeTaskDto")
+		response.InvalidJsonBody(w, "Invalid json body for snooze task")
+		return
+	}
+	task, ok := h.findTaskForMember(w, r)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	var until time.Time
+	switch {
+	case snoozeDto.Duration != "" && snoozeDto.Until != nil:
+		h.logger.Error().Str("ErrorCode", TaskSnoozeInvalidInput).Msg("both duration and until are provided")
+		response.ErrorResponse(w, http.StatusBadRequest, *(response.New(TaskSnoozeInvalidInput, "Invalid snooze input", "provide either duration or until, not both")))
+		return
+	case snoozeDto.Duration != "":
+		duration, err := time.ParseDuration(snoozeDto.Duration)
+		if err != nil {
+			h.logger.Error().Err(err).Str("ErrorCode", TaskSnoozeInvalidInput).Msg("invalid snooze duration")
+			response.ErrorResponse(w, http.StatusBadRequest, *(response.New(TaskSnoozeInvalidInput, "Invalid snooze input", "duration must be a valid duration such as 30m or 2h")))
+			return
+		}
+		until = now.Add(duration)
+	case snoozeDto.Until != nil:
+		until = *snoozeDto.Until
+	default:
+		h.logger.Error().Str("ErrorCode", TaskSnoozeInvalidInput).Msg("missing duration and until")
+		response.ErrorResponse(w, http.StatusBadRequest, *(response.New(TaskSnoozeInvalidInput, "Invalid snooze input", "either duration or until is required")))
+		return
+	}
+
+	if err := task.Snooze(until, now, snoozeDto.MoveTargetDate); err != nil {
+		h.domainError(w, err)
+		return
+	}
+	if err := h.taskRepo.UpdateTaskSnooze(*task); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskSnoozeServerError).Msg("Error occurred during snooze task")
+		response.InternalServerError(w, "Failed to snooze task")
+		return
+	}
+	if snoozeDto.MoveTargetDate {
+		h.rescheduleReminders(*task)
+	}
+	h.recordActivity(r, task.TaskId, model.ActivitySnoozed, "snoozed until "+until.UTC().Format(time.RFC3339))
+	response.WriteJsonWithEncode(w, http.StatusOK, task)
+}
+
+func (h *Handler) handleUnsnoozeTask(w http.ResponseWriter, r *http.Request) {
+	task, ok := h.findTaskForMember(w, r)
+	if !ok {
+		return
+	}
+	if err := task.Unsnooze(time.Now()); err != nil {
+		h.domainError(w, err)
+		return
+	}
+	if err := h.taskRepo.UpdateTaskSnooze(*task); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskSnoozeServerError).Msg("Error occurred during unsnooze task")
+		response.InternalServerError(w, "Failed to unsnooze task")
+		return
+	}
+	h.recordActivity(r, task.TaskId, model.ActivityUnsnoozed, "")
+	response.WriteJsonWithEncode(w, http.StatusOK, task)
+}
+
+func (h *Handler) handleGetTaskActivities(w http.ResponseWriter, r *http.Request) {
+	task, ok := h.findTaskForMember(w, r)
+	if !ok {
+		return
+	}
+	activities, err := h.taskRepo.GetTaskActivities(task.TaskId)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskActivityServerError).Msg("Error occurred during GetTaskActivities")
+		response.InternalServerError(w, "Failed to get task activities")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusOK, activities)
+}
+
+func (h *Handler) handleLinkTaskToContainer(w http.ResponseWriter, r *http.Request) {
+	container, err := h.containerRepo.GetById(chi.URLParam(r, "containerID"))
+	if err != nil || container == nil || container.Id == "" {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskGetTaskContainerNotFound).Msg("Task container not found")
+		response.NotFound(w, TaskGetTaskContainerNotFound, "Task container not found")
+		return
+	}
+	task, err := h.taskRepo.GetTaskById(chi.URLParam(r, "taskID"))
+	if err != nil || task == nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskGetNotFound).Msg("Cannot find task to link")
+		response.NotFound(w, TaskGetNotFound, "Cannot find task")
+		return
+	}
+	if task.IsInContainer(container.Id) {
+		response.WriteJsonWithEncode(w, http.StatusOK, task)
+		return
+	}
+	if container.IsArchived() {
+		h.logger.Error().Str("ErrorCode", TaskContainerArchived).Msg("Task container is archived")
+		response.ErrorResponse(w, http.StatusUnprocessableEntity, *response.New(TaskContainerArchived, "Domain Validation Error", containerModel.ErrContainerArchived.Error()))
+		return
+	}
+
+	groupId, err := h.taskRepo.GetUserGroupIdByTaskId(task.TaskId)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskGetTaskContainerNotFound).Msg("Cannot find user group of task")
+		response.NotFound(w, TaskGetTaskContainerNotFound, "Task container not found")
+		return
+	}
+	if groupId != container.UsergroupId {
+		h.logger.Error().Str("ErrorCode", TaskLinkDifferentUserGroup).Msg("container belongs to another user group")
+		response.ErrorResponse(w, http.StatusUnprocessableEntity, *response.New(TaskLinkDifferentUserGroup, "Domain Validation Error", "task can only be linked to containers of the same user group"))
+		return
+	}
+	user := h.currentUser(r)
+	if user == nil {
+		h.logger.Error().Str("ErrorCode", TaskUserNotFound).Msg("Not able to find user from token")
+		response.NotFound(w, TaskUserNotFound, "Not able to find a user")
+		return
+	}
+	if !h.isTaskGroupMember(w, task.TaskId, user.Id) {
+		return
+	}
+
+	if err = h.taskRepo.LinkTaskToContainer(container.Id, task.TaskId); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskLinkServerError).Msg("Error occurred during LinkTaskToContainer")
+		response.InternalServerError(w, "Failed to link task to container")
+		return
+	}
+	task.ContainerIds = append(task.ContainerIds, container.Id)
+	response.WriteJsonWithEncode(w, http.StatusCreated, task)
+}
+
+func (h *Handler) handleUnlinkTaskFromContainer(w http.ResponseWriter, r *http.Request) {
+	containerId := chi.URLParam(r, "containerID")
+	task, err := h.taskRepo.GetTaskById(chi.URLParam(r, "taskID"))
+	if err != nil || task == nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskGetNotFound).Msg("Cannot find task to unlink")
+		response.NotFound(w, TaskGetNotFound, "Cannot find task")
+		return
+	}
+	if !task.IsInContainer(containerId) {
+		h.logger.Error().Str("ErrorCode", TaskLinkNotFound).Msg("task is not linked to the container")
+		response.NotFound(w, TaskLinkNotFound, "Task is not linked to the container")
+		return
+	}
+	user := h.currentUser(r)
+	if user == nil {
+		h.logger.Error().Str("ErrorCode", TaskUserNotFound).Msg("Not able to find user from token")
+		response.NotFound(w, TaskUserNotFound, "Not able to find a user")
+		return
+	}
+	if !h.isTaskGroupMember(w, task.TaskId, user.Id) {
+		return
+	}
+
+	deleted, err := h.taskRepo.UnlinkTaskFromContainer(containerId, task.TaskId)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskLinkServerError).Msg("Error occurred during UnlinkTaskFromContainer")
+		response.InternalServerError(w, "Failed to unlink task from container")
+		return
+	}
+	response.SuccessJson(w, map[string]bool{"task_deleted": deleted}, "task is unlinked from the container.", http.StatusOK)
+}
+
+func (h *Handler) handleGetTaskWatchers(w http.ResponseWriter, r *http.Request) {
+	task, err := h.taskRepo.GetTaskById(chi.URLParam(r, "taskID"))
+	if err != nil || task == nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskGetNotFound).Msg("Cannot find task for watchers")
+		response.NotFound(w, TaskGetNotFound, "Cannot find task")
+		return
+	}
+	watchers, err := h.taskWatchers(*task)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskWatcherServerError).Msg("Error occurred during GetTaskWatchers")
+		response.InternalServerError(w, "Failed to get task watchers")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusOK, watchers)
+}
+
+func (h *Handler) handleWatchTask(w http.ResponseWriter, r *http.Request) {
+	task, err := h.taskRepo.GetTaskById(chi.URLParam(r, "taskID"))
+	if err != nil || task == nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskGetNotFound).Msg("Cannot find task to watch")
+		response.NotFound(w, TaskGetNotFound, "Cannot find task")
+		return
+	}
+	user := h.currentUser(r)
+	if user == nil {
+		h.logger.Error().Str("ErrorCode", TaskUserNotFound).Msg("Not able to find user from token")
+		response.NotFound(w, TaskUserNotFound, "Not able to find a user")
+		return
+	}
+	if !h.isTaskGroupMember(w, task.TaskId, user.Id) {
+		return
+	}
+
+	if err = h.taskRepo.AddTaskWatcher(task.TaskId, user.Id); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskWatcherServerError).Msg("Error occurred during AddTaskWatcher")
+		response.InternalServerError(w, "Failed to watch task")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusCreated, "task is being watched.")
+}
+
+func (h *Handler) handleUnwatchTask(w http.ResponseWriter, r *http.Request) {
+	task, err := h.taskRepo.GetTaskById(chi.URLParam(r, "taskID"))
+	if err != nil || task == nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskGetNotFound).Msg("Cannot find task to unwatch")
+		response.NotFound(w, TaskGetNotFound, "Cannot find task")
+		return
+	}
+	user := h.currentUser(r)
+	if user == nil {
+		h.logger.Error().Str("ErrorCode", TaskUserNotFound).Msg("Not able to find user from token")
+		response.NotFound(w, TaskUserNotFound, "Not able to find a user")
+		return
+	}
+	if task.CreatedBy != nil && *task.CreatedBy == user.Id {
+		h.logger.Error().Str("ErrorCode", TaskWatcherCreatorError).Msg("creator cannot unwatch own task")
+		response.ErrorResponse(w, http.StatusUnprocessableEntity, *response.New(TaskWatcherCreatorError, "Domain Validation Error", "the creator of a task is always watching it"))
+		return
+	}
+
+	if err = h.taskRepo.RemoveTaskWatcher(task.TaskId, user.Id); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskWatcherServerError).Msg("Error occurred during RemoveTaskWatcher")
+		response.InternalServerError(w, "Failed to unwatch task")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusNoContent, "task is no longer watched.")
+}
+
+func (h *Handler) handleGetTaskRevisions(w http.ResponseWriter, r *http.Request) {
+	taskId := chi.URLParam(r, "taskID")
+	if _, ok := h.taskMember(w, r, taskId); !ok {
+		return
+	}
+	revisions, err := h.taskRepo.GetTaskRevisions(taskId)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskRevisionServerError).Msg("Error occurred during GetTaskRevisions")
+		response.InternalServerError(w, "Failed to get task revisions")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusOK, revisions)
+}
+
+func (h *Handler) handleGetTaskRevision(w http.ResponseWriter, r *http.Request) {
+	if _, ok := h.taskMember(w, r, chi.URLParam(r, "taskID")); !ok {
+		return
+	}
+	revision, ok := h.findRevision(w, r)
+	if !ok {
+		return
+	}
+	var previous *model.TaskSnapshot
+	if revision.Number > 1 {
+		prev, err := h.taskRepo.GetTaskRevision(revision.TaskId, revision.Number-1)
+		if err != nil {
+			h.logger.Error().Err(err).Str("ErrorCode", TaskRevisionServerError).Msg("Error occurred during GetTaskRevision")
+			response.InternalServerError(w, "Failed to get task revision")
+			return
+		}
+		if prev != nil {
+			previous = &prev.Snapshot
+		}
+	}
+	response.WriteJsonWithEncode(w, http.StatusOK, TaskRevisionDto{
+		TaskRevision: *revision,
+		Changes:      revision.Snapshot.Diff(previous),
+	})
+}
+
+func (h *Handler) handleRevertTaskRevision(w http.ResponseWriter, r *http.Request) {
+	user, ok := h.taskMember(w, r, chi.URLParam(r, "taskID"))
+	if !ok {
+		return
+	}
+	revision, ok := h.findRevision(w, r)
+	if !ok {
+		return
+	}
+	task, err := h.taskRepo.GetTaskById(revision.TaskId)
+	if err != nil || task == nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskGetNotFound).Msg("Cannot find task for revert")
+		response.NotFound(w, TaskGetNotFound, "Cannot find task")
+		return
+	}
+	if err = task.Restore(revision.Snapshot); err != nil {
+		h.domainError(w, err)
+		return
+	}
+	if err = h.taskRepo.UpdateTask(*task, &user.Id); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskUpdateServerError).Msg("Not able to revert task")
+		response.InternalServerError(w, "Failed to revert task")
+		return
+	}
+	h.rescheduleReminders(*task)
+	h.recordActivity(r, task.TaskId, model.ActivityReverted, fmt.Sprintf("reverted to revision %d", revision.Number))
+	response.WriteJsonWithEncode(w, http.StatusOK, task)
+}
+
+// findRevision loads the revision named in the URL and writes 400/404/500 when
+// it cannot be found.
+func (h *Handler) findRevision(w http.ResponseWriter, r *http.Request) (*model.TaskRevision, bool) {
+	number, err := strconv.Atoi(chi.URLParam(r, "revisionNumber"))
+	if err != nil || number < 1 {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.InvalidParameter).Msg("invalid revision number")
+		response.ErrorResponse(w, http.StatusBadRequest, *(response.New(constants.InvalidParameter, "Invalid Parameter", "revision number must be a positive integer")))
+		return nil, false
+	}
+	revision, err := h.taskRepo.GetTaskRevision(chi.URLParam(r, "taskID"), number)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskRevisionServerError).Msg("Error occurred during GetTaskRevision")
+		response.InternalServerError(w, "Failed to get task revision")
+		return nil, false
+	}
+	if revision == nil {
+		h.logger.Error().Str("ErrorCode", TaskRevisionNotFound).Msg("Cannot find task revision")
+		response.NotFound(w, TaskRevisionNotFound, "Cannot find task revision")
+		return nil, false
+	}
+	return revision, true
+}
+
+func (h *Handler) handleGetReminders(w http.ResponseWriter, r *http.Request) {
+	taskId := chi.URLParam(r, "taskID")
+	user := h.currentUser(r)
+	if user == nil {
+		h.logger.Error().Str("ErrorCode", TaskUserNotFound).Msg("Not able to find user from token")
+		response.NotFound(w, TaskUserNotFound, "Not able to find a user")
+		return
+	}
+	if !h.isTaskGroupMember(w, taskId, user.Id) {
+		return
+	}
+	reminders, err := h.reminderRepo.GetRemindersByTaskId(taskId, user.Id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskReminderServerError).Msg("Error occurred during GetRemindersByTaskId")
+		response.InternalServerError(w, "Failed to get reminders")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusOK, reminders)
+}
+
+func (h *Handler) handleCreateReminder(w http.ResponseWriter, r *http.Request) {
+	var reminderDto CreateReminderDto
+	if err := response.ParseJson(r, &reminderDto); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.RequestBodyError).Msg("Invalid JSON body for CreateReminderDto")
+		response.InvalidJsonBody(w, "Invalid json body for reminder")
+		return
+	}
+	if (reminderDto.RemindAt == nil) == (reminderDto.OffsetMinutes == nil) {
+		h.logger.Error().Str("ErrorCode", TaskReminderInvalidInput).Msg("remind_at and offset_minutes are both provided or both missing")
+		response.ErrorResponse(w, http.StatusBadRequest, *(response.New(TaskReminderInvalidInput, "Invalid reminder input", "provide either remind_at or offset_minutes")))
+		return
+	}
+	task, err := h.taskRepo.GetTaskById(chi.URLParam(r, "taskID"))
+	if err != nil || task == nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskGetNotFound).Msg("Cannot find task for reminder")
+		response.NotFound(w, TaskGetNotFound, "Cannot find task")
+		return
+	}
+	user := h.currentUser(r)
+	if user == nil {
+		h.logger.Error().Str("ErrorCode", TaskUserNotFound).Msg("Not able to find user from token")
+		response.NotFound(w, TaskUserNotFound, "Not able to find a user")
+		return
+	}
+	if !h.isTaskGroupMember(w, task.TaskId, user.Id) {
+		return
+	}
+
+	channel := reminderDto.Channel
+	if channel == "" {
+		channel = model.ReminderChannelInApp
+	}
+	now := time.Now()
+	var reminder *model.TaskReminder
+	if reminderDto.RemindAt != nil {
+		reminder, err = model.NewAbsoluteReminder(task.TaskId, user.Id, channel, reminderDto.Target, *reminderDto.RemindAt, now)
+	} else {
+		reminder, err = model.NewOffsetReminder(*task, user.Id, channel, reminderDto.Target, time.Duration(*reminderDto.OffsetMinutes)*time.Minute, now)
+	}
+	if err != nil {
+		h.domainError(w, err)
+		return
+	}
+	created, err := h.reminderRepo.CreateReminder(*reminder)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskReminderServerError).Msg("Error occurred during CreateReminder")
+		response.InternalServerError(w, "Failed to create reminder")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusCreated, created)
+}
+
+func (h *Handler) handleDeleteReminder(w http.ResponseWriter, r *http.Request) {
+	user := h.currentUser(r)
+	if user == nil {
+		h.logger.Error().Str("ErrorCode", TaskUserNotFound).Msg("Not able to find user from token")
+		response.NotFound(w, TaskUserNotFound, "Not able to find a user")
+		return
+	}
+	err := h.reminderRepo.DeleteReminder(chi.URLParam(r, "taskID"), chi.URLParam(r, "reminderID"), user.Id)
+	if err == sql.ErrNoRows {
+		h.logger.Error().Str("ErrorCode", TaskReminderNotFound).Msg("Cannot find reminder to delete")
+		response.NotFound(w, TaskReminderNotFound, "Cannot find reminder")
+		return
+	}
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskReminderServerError).Msg("Error occurred during DeleteReminder")
+		response.InternalServerError(w, "Failed to delete reminder")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusNoContent, "reminder has been removed.")
+}
+
+// completionChanged notifies the watchers once a completion change is saved.
+func (h *Handler) completionChanged(r *http.Request, task model.Task) {
+	if task.IsCompleted {
+		h.notifyWatchers(r, task, notificationModel.TypeTaskCompleted, fmt.Sprintf("Task '%s' has been completed.", task.TaskName))
+		return
+	}
+	h.notifyWatchers(r, task, notificationModel.TypeTaskReopened, fmt.Sprintf("Task '%s' has been reopened.", task.TaskName))
+}
+
+// pointAward credits the caller with the points of the completed task. The
+// award day is taken in the caller's profile time zone.
+func (h *Handler) pointAward(r *http.Request, task model.Task) *model.PointAward {
+	user := h.currentUser(r)
+	if user == nil || task.CompletedAt == nil {
+		return nil
+	}
+	groupId, err := h.taskRepo.GetUserGroupIdByTaskId(task.TaskId)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskPointsServerError).Msg("Cannot find user group of task to award points")
+		return nil
+	}
+	loc, err := model.LoadTimezone(user.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	award := model.NewPointAward(task, user.Id, groupId, *task.CompletedAt, loc)
+	return &award
+}
+
+// completion prepares what is saved together with the completion change of
+// task: the points of the caller and the next occurrence of a completed
+// recurring task.
+func (h *Handler) completion(r *http.Request, task model.Task) taskRepo.Completion {
+	if !task.IsCompleted {
+		return taskRepo.Completion{}
+	}
+	completion := taskRepo.Completion{Award: h.pointAward(r, task)}
+	completion.NextOccurrence, completion.Rotation = h.nextOccurrence(task)
+	return completion
+}
+
+// nextOccurrence builds the next occurrence of a completed recurring task and
+// hands it to the next member of the series rotation, if any. Failures are
+// logged and leave the series without a next occurrence.
+func (h *Handler) nextOccurrence(task model.Task) (*model.Task, *model.ChoreRotation) {
+	if !task.IsRecurring() || task.SeriesId == nil || len(task.ContainerIds) == 0 {
+		return nil, nil
+	}
+	next, err := task.NextOccurrence(time.Now())
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskRecurrenceServerError).Msg("Not able to compute next occurrence")
+		return nil, nil
+	}
+	next.TaskId = uuid.New().String()
+
+	rotation, err := h.rotationRepo.GetRotation(*task.SeriesId)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskRotationServerError).Msg("Error occurred during GetRotation")
+		return nil, nil
+	}
+	if rotation != nil {
+		load, err := h.rotationRepo.CountOpenAssignedTasks(rotation.UsergroupId)
+		if err != nil {
+			h.logger.Error().Err(err).Str("ErrorCode", TaskRotationServerError).Msg("Error occurred during CountOpenAssignedTasks")
+			return nil, nil
+		}
+		next.AssigneeId = nil
+		if assignee, ok := rotation.Advance(load); ok {
+			next.AssigneeId = &assignee
+		}
+	}
+	return next, rotation
+}
+
+// rescheduleReminders moves pending offset reminders along with the task's due
+// date. Reminders that can no longer be computed keep their previous time.
+func (h *Handler) rescheduleReminders(task model.Task) {
+	reminders, err := h.reminderRepo.GetOffsetRemindersByTaskId(task.TaskId)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskReminderServerError).Msg("Error occurred during GetOffsetRemindersByTaskId")
+		return
+	}
+	for _, reminder := range reminders {
+		previous := reminder.RemindAt
+		if err := reminder.Reschedule(task); err != nil || reminder.RemindAt.Equal(previous) {
+			continue
+		}
+		if err := h.reminderRepo.UpdateReminderRemindAt(reminder.Id, reminder.RemindAt); err != nil {
+			h.logger.Error().Err(err).Str("ErrorCode", TaskReminderServerError).Msg("Error occurred during UpdateReminderRemindAt")
+		}
+	}
+}
+
+// handleGetHabitCalendar lists the days between the from and to query
+// parameters with whether the habit was checked in. It defaults to the last 30
+// days up to today in the task's time zone.
+func (h *Handler) handleGetHabitCalendar(w http.ResponseWriter, r *http.Request) {
+	task, ok := h.findHabit(w, r)
+	if !ok {
+		return
+	}
+	to := r.URL.Query().Get("to")
+	if to == "" {
+		to = task.Today(time.Now())
+	}
+	from := r.URL.Query().Get("from")
+	if from == "" {
+		end, err := time.Parse(model.DateLayout, to)
+		if err != nil {
+			h.domainError(w, model.ErrInvalidDueDate)
+			return
+		}
+		from = end.AddDate(0, 0, -29).Format(model.DateLayout)
+	}
+	if _, err := model.HabitCalendar(from, to, nil); err != nil {
+		h.domainError(w, err)
+		return
+	}
+	checkIns, err := h.habitRepo.GetCheckInDatesBetween(task.TaskId, from, to)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskHabitServerError).Msg("Error occurred during GetCheckInDatesBetween")
+		response.InternalServerError(w, "Failed to get habit calendar")
+		return
+	}
+	days, _ := model.HabitCalendar(from, to, checkIns)
+	response.WriteJsonWithEncode(w, http.StatusOK, days)
+}
+
+func (h *Handler) handleCheckInHabit(w http.ResponseWriter, r *http.Request) {
+	var checkInDto CheckInDto
+	if err := response.ParseJson(r, &checkInDto); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.RequestBodyError).Msg("Invalid JSON body for CheckInDto")
+		response.InvalidJsonBody(w, "Invalid json body for check-in")
+		return
+	}
+	task, ok := h.findHabit(w, r)
+	if !ok {
+		return
+	}
+	var userId *int
+	if user := h.currentUser(r); user != nil {
+		userId = &user.Id
+	}
+	checkIn, err := model.NewCheckIn(*task, checkInDto.Date, userId, time.Now())
+	if err != nil {
+		h.domainError(w, err)
+		return
+	}
+	created, err := h.habitRepo.CreateCheckIn(*checkIn)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskHabitServerError).Msg("Error occurred during CreateCheckIn")
+		response.InternalServerError(w, "Failed to check in habit")
+		return
+	}
+	if !created {
+		response.WriteJsonWithEncode(w, http.StatusOK, checkIn)
+		return
+	}
+	h.recordActivity(r, task.TaskId, model.ActivityCheckedIn, checkIn.Date)
+	response.WriteJsonWithEncode(w, http.StatusCreated, checkIn)
+}
+
+func (h *Handler) handleDeleteCheckIn(w http.ResponseWriter, r *http.Request) {
+	task, ok := h.findHabit(w, r)
+	if !ok {
+		return
+	}
+	date, err := time.Parse(model.DateLayout, chi.URLParam(r, "date"))
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.InvalidParameter).Msg("invalid check-in date")
+		response.ErrorResponse(w, http.StatusBadRequest, *(response.New(constants.InvalidParameter, "Invalid Parameter", "date must be formatted as YYYY-MM-DD")))
+		return
+	}
+	err = h.habitRepo.DeleteCheckIn(task.TaskId, date.Format(model.DateLayout))
+	if err == sql.ErrNoRows {
+		h.logger.Error().Str("ErrorCode", TaskCheckInNotFound).Msg("Cannot find check-in to delete")
+		response.NotFound(w, TaskCheckInNotFound, "Cannot find check-in")
+		return
+	}
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskHabitServerError).Msg("Error occurred during DeleteCheckIn")
+		response.InternalServerError(w, "Failed to delete check-in")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusNoContent, "check-in has been removed.")
+}
+
+func (h *Handler) handleGetHabitStreak(w http.ResponseWriter, r *http.Request) {
+	task, ok := h.findHabit(w, r)
+	if !ok {
+		return
+	}
+	checkIns, err := h.habitRepo.GetCheckInDates(task.TaskId)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskHabitServerError).Msg("Error occurred during GetCheckInDates")
+		response.InternalServerError(w, "Failed to get habit streak")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusOK, task.Streak(checkIns, task.Today(time.Now())))
+}
+
+func (h *Handler) findHabit(w http.ResponseWriter, r *http.Request) (*model.Task, bool) {
+	task, ok := h.findTaskForMember(w, r)
+	if !ok {
+		return nil, false
+	}
+	if !task.IsHabit() {
+		h.domainError(w, model.ErrTaskNotHabit)
+		return nil, false
+	}
+	return task, true
+}
+
+func (h *Handler) handleGetRotation(w http.ResponseWriter, r *http.Request) {
+	rotation, ok := h.findRotation(w, r)
+	if !ok {
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusOK, rotation)
+}
+
+func (h *Handler) handleUpdateRotation(w http.ResponseWriter, r *http.Request) {
+	var rotationDto UpdateRotationDto
+	if err := response.ParseJson(r, &rotationDto); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.RequestBodyError).Msg("Invalid JSON body for UpdateRotationDto")
+		response.InvalidJsonBody(w, "Invalid json body for rotation")
+		return
+	}
+	task, ok := h.findTaskForMember(w, r)
+	if !ok {
+		return
+	}
+	if !task.IsRecurring() || task.SeriesId == nil {
+		h.domainError(w, model.ErrTaskNotRecurring)
+		return
+	}
+	if len(task.ContainerIds) == 0 {
+		h.logger.Error().Str("ErrorCode", TaskGetTaskContainerNotFound).Msg("Task container not found")
+		response.NotFound(w, TaskGetTaskContainerNotFound, "Task container not found")
+		return
+	}
+	container, err := h.containerRepo.GetById(task.ContainerIds[0])
+	if err != nil || container == nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskGetTaskContainerNotFound).Msg("Task container not found")
+		response.NotFound(w, TaskGetTaskContainerNotFound, "Task container not found")
+		return
+	}
+	if !container.IsChores() {
+		h.logger.Error().Str("ErrorCode", TaskNotChoresContainer).Msg("rotation requested outside of a chores container")
+		response.ErrorResponse(w, http.StatusUnprocessableEntity, *response.New(TaskNotChoresContainer, "Domain Validation Error", "rotations are only available in chores containers"))
+		return
+	}
+	for _, memberId := range rotationDto.Members {
+		isMember, err := h.groupRepo.IsUserInGroup(container.UsergroupId, memberId)
+		if err != nil {
+			h.logger.Error().Err(err).Str("ErrorCode", TaskGetServerError).Msg("Error occurred during IsUserInGroup")
+			response.InternalServerError(w)
+			return
+		}
+		if !isMember {
+			h.domainError(w, model.ErrRotationMemberNotInGroup)
+			return
+		}
+	}
+
+	rotation, err := model.NewChoreRotation(*task.SeriesId, container.UsergroupId, model.RotationStrategy(rotationDto.Strategy), rotationDto.Members)
+	if err != nil {
+		h.domainError(w, err)
+		return
+	}
+	h.saveRotation(w, *rotation)
+}
+
+func (h *Handler) handleSkipRotation(w http.ResponseWriter, r *http.Request) {
+	rotation, ok := h.findRotation(w, r)
+	if !ok {
+		return
+	}
+	load, err := h.rotationRepo.CountOpenAssignedTasks(rotation.UsergroupId)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskRotationServerError).Msg("Error occurred during CountOpenAssignedTasks")
+		response.InternalServerError(w, "Failed to skip rotation")
+		return
+	}
+	rotation.Skip(load)
+	h.saveRotation(w, *rotation)
+}
+
+func (h *Handler) handleSwapRotation(w http.ResponseWriter, r *http.Request) {
+	var swapDto SwapRotationDto
+	if err := response.ParseJson(r, &swapDto); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.RequestBodyError).Msg("Invalid JSON body for SwapRotationDto")
+		response.InvalidJsonBody(w, "Invalid json body for rotation swap")
+		return
+	}
+	rotation, ok := h.findRotation(w, r)
+	if !ok {
+		return
+	}
+	if err := rotation.Swap(swapDto.UserId, swapDto.OtherUserId); err != nil {
+		h.domainError(w, err)
+		return
+	}
+	h.saveRotation(w, *rotation)
+}
+
+// findTaskForMember loads the task of the request and checks that the caller
+// belongs to its user group. It returns false when the response has been written.
+func (h *Handler) findTaskForMember(w http.ResponseWriter, r *http.Request) (*model.Task, bool) {
+	task, err := h.taskRepo.GetTaskById(chi.URLParam(r, "taskID"))
+	if err != nil || task == nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskGetNotFound).Msg("Cannot find task")
+		response.NotFound(w, TaskGetNotFound, "Cannot find task")
+		return nil, false
+	}
+	user := h.currentUser(r)
+	if user == nil {
+		h.logger.Error().Str("ErrorCode", TaskUserNotFound).Msg("Not able to find user from token")
+		response.NotFound(w, TaskUserNotFound, "Not able to find a user")
+		return nil, false
+	}
+	if !h.isTaskGroupMember(w, task.TaskId, user.Id) {
+		return nil, false
+	}
+	return task, true
+}
+
+func (h *Handler) findRotation(w http.ResponseWriter, r *http.Request) (*model.ChoreRotation, bool) {
+	task, ok := h.findTaskForMember(w, r)
+	if !ok {
+		return nil, false
+	}
+	var rotation *model.ChoreRotation
+	var err error
+	if task.SeriesId != nil {
+		rotation, err = h.rotationRepo.GetRotation(*task.SeriesId)
+	}
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskRotationServerError).Msg("Error occurred during GetRotation")
+		response.InternalServerError(w, "Failed to get rotation")
+		return nil, false
+	}
+	if rotation == nil {
+		h.logger.Error().Str("ErrorCode", TaskRotationNotFound).Msg("Cannot find rotation of task")
+		response.NotFound(w, TaskRotationNotFound, "Cannot find rotation")
+		return nil, false
+	}
+	return rotation, true
+}
+
+func (h *Handler) saveRotation(w http.ResponseWriter, rotation model.ChoreRotation) {
+	if err := h.rotationRepo.SaveRotation(rotation); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskRotationServerError).Msg("Error occurred during SaveRotation")
+		response.InternalServerError(w, "Failed to save rotation")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusOK, rotation)
+}
+
+// taskMember resolves the caller and checks that they belong to the user group
+// owning the task. It writes the error response and returns false otherwise.
+func (h *Handler) taskMember(w http.ResponseWriter, r *http.Request, taskId string) (*userModel.User, bool) {
+	user := h.currentUser(r)
+	if user == nil {
+		h.logger.Error().Str("ErrorCode", TaskUserNotFound).Msg("Not able to find user from token")
+		response.NotFound(w, TaskUserNotFound, "Not able to find a user")
+		return nil, false
+	}
+	if !h.isTaskGroupMember(w, taskId, user.Id) {
+		return nil, false
+	}
+	return user, true
+}
+
+// groupMember resolves the caller, who must belong to the user group.
+func (h *Handler) groupMember(w http.ResponseWriter, r *http.Request, groupId int) (*userModel.User, bool) {
+	user := h.currentUser(r)
+	if user == nil {
+		h.logger.Error().Str("ErrorCode", TaskUserNotFound).Msg("Not able to find user from token")
+		response.NotFound(w, TaskUserNotFound, "Not able to find a user")
+		return nil, false
+	}
+	if !h.isGroupMember(w, groupId, user.Id) {
+		return nil, false
+	}
+	return user, true
+}
+
+// isTaskGroupMember writes the error response and returns false when the user
+// does not belong to the user group owning the task.
+func (h *Handler) isTaskGroupMember(w http.ResponseWriter, taskId string, userId int) bool {
+	groupId, err := h.taskRepo.GetUserGroupIdByTaskId(taskId)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskGetTaskContainerNotFound).Msg("Cannot find user group of task")
+		response.NotFound(w, TaskGetTaskContainerNotFound, "Task container not found")
+		return false
+	}
+	return h.isGroupMember(w, groupId, userId)
+}
+
+// isGroupMember writes the error response and returns false when the user does
+// not belong to the user group.
+func (h *Handler) isGroupMember(w http.ResponseWriter, groupId int, userId int) bool {
+	isMember, err := h.groupRepo.IsUserInGroup(groupId, userId)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskGetServerError).Msg("Error occurred during IsUserInGroup")
+		response.InternalServerError(w)
+		return false
+	}
+	if !isMember {
+		h.logger.Error().Str("ErrorCode", TaskNotGroupMember).Msg("user is not a member of the user group")
+		response.ErrorResponse(w, http.StatusForbidden, *response.New(TaskNotGroupMember, errors.PermissionDenied, "user is not a member of the user group"))
+		return false
+	}
+	return true
+}
+
+// notifyWatchers sends an in-app notification to everyone watching the task
+// except the user who made the change.
+func (h *Handler) notifyWatchers(r *http.Request, task model.Task, notificationType string, message string) {
+	watchers, err := h.taskWatchers(task)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskNotifyServerError).Msg("Not able to get task watchers")
+		return
+	}
+	actorId := 0
+	if user := h.currentUser(r); user != nil {
+		actorId = user.Id
+	}
+	for _, watcher := range watchers {
+		if watcher.UserId == actorId {
+			continue
+		}
+		notification, err := notificationModel.NewNotification(watcher.UserId, notificationType, message, &task.TaskId)
+		if err == nil {
+			err = h.notifyRepo.CreateNotification(*notification)
+		}
+		if err != nil {
+			h.logger.Error().Err(err).Str("ErrorCode", TaskNotifyServerError).Msg("Not able to notify task watcher")
+		}
+	}
+}
+
+// taskWatchers lists the watchers of the task. The creator only counts while
+// they still belong to the task's user group.
+func (h *Handler) taskWatchers(task model.Task) ([]model.TaskWatcher, error) {
+	explicit, err := h.taskRepo.GetTaskWatchers(task.TaskId)
+	if err != nil {
+		return nil, err
+	}
+	creatorIsMember := false
+	if task.CreatedBy != nil {
+		groupId, err := h.taskRepo.GetUserGroupIdByTaskId(task.TaskId)
+		switch {
+		case err == sql.ErrNoRows:
+			// A task outside any container has no group to leave.
+			creatorIsMember = true
+		case err != nil:
+			return nil, err
+		default:
+			if creatorIsMember, err = h.groupRepo.IsUserInGroup(groupId, *task.CreatedBy); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return task.Watchers(explicit, creatorIsMember), nil
+}
+
+// resolveMentions looks up the @username tokens of the text among the members of
+// the user group. Unknown usernames and non-members are left as plain text.
+func (h *Handler) resolveMentions(groupId int, text string) []model.TaskMention {
+	mentions := []model.TaskMention{}
+	for _, username := range model.ParseMentions(text) {
+		user, err := h.userRepo.GetUserByUsername(username)
+		if err != nil || user == nil || user.Id == 0 {
+			continue
+		}
+		isMember, err := h.groupRepo.IsUserInGroup(groupId, user.Id)
+		if err != nil || !isMember {
+			continue
+		}
+		mentions = append(mentions, model.TaskMention{UserId: user.Id, Username: user.UserName})
+	}
+	return mentions
+}
+
+// saveMentions stores the mentions of the task description and notifies the
+// users who were not mentioned before. It returns the resolved mentions.
+func (h *Handler) saveMentions(r *http.Request, task model.Task, groupId int, previous []model.TaskMention) []model.TaskMention {
+	mentions := h.resolveMentions(groupId, task.TaskDesc)
+	if err := h.taskRepo.ReplaceTaskMentions(task.TaskId, mentions); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskUpdateServerError).Msg("Not able to save task mentions")
+		return mentions
+	}
+
+	actorId := 0
+	if user := h.currentUser(r); user != nil {
+		actorId = user.Id
+	}
+	for _, mention := range model.NewMentions(previous, mentions) {
+		if mention.UserId == actorId {
+			continue
+		}
+		notification, err := notificationModel.NewNotification(mention.UserId, notificationModel.TypeTaskMentioned, fmt.Sprintf("You were mentioned in task '%s'.", task.TaskName), &task.TaskId)
+		if err == nil {
+			err = h.notifyRepo.CreateNotification(*notification)
+		}
+		if err != nil {
+			h.logger.Error().Err(err).Str("ErrorCode", TaskNotifyServerError).Msg("Not able to notify mentioned user")
+		}
+	}
+	return mentions
+}
+
+func (h *Handler) domainError(w http.ResponseWriter, err error) {
+	h.logger.Error().Err(err).Str("ErrorCode", TaskDomainError).Msg(err.Error())
+	response.ErrorResponse(w, http.StatusUnprocessableEntity, *response.New(TaskDomainError, "Domain Validation Error", err.Error()))
+}
+
+// recordActivity appends an entry to the task history. Failing to record history
+// should not fail the request that triggered it, so errors are only logged.
+func (h *Handler) recordActivity(r *http.Request, taskId string, action string, detail string) {
+	var userId *int
+	if user := h.currentUser(r); user != nil {
+		userId = &user.Id
+	}
+	activity, err := model.NewTaskActivity(taskId, userId, action, detail)
+	if err == nil {
+		err = h.taskRepo.CreateTaskActivity(*activity)
+	}
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskActivityServerError).Msg("Not able to record task activity")
+	}
+}
+
+// requireAccess answers not found for the container or task of the request when
+// it is restricted to other members, so that it does not reveal that it exists.
+func (h *Handler) requireAccess(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userId := h.currentUserId(r)
+		if containerId := chi.URLParam(r, "containerID"); containerId != "" {
+			ok, err := h.containerRepo.CanAccessContainer(containerId, userId)
+			if err != nil {
+				h.logger.Error().Err(err).Str("ErrorCode", TaskGetServerError).Msg("Error occurred during CanAccessContainer")
+				response.InternalServerError(w, "Failed to check container access")
+				return
+			}
+			if !ok {
+				h.logger.Error().Str("ErrorCode", TaskGetTaskContainerNotFound).Msg("Task container is restricted to other members")
+				response.NotFound(w, TaskGetTaskContainerNotFound, "Task container not found")
+				return
+			}
+		}
+		if taskId := chi.URLParam(r, "taskID"); taskId != "" {
+			ok, err := h.taskRepo.CanAccessTask(taskId, userId)
+			if err != nil {
+				h.logger.Error().Err(err).Str("ErrorCode", TaskGetServerError).Msg("Error occurred during CanAccessTask")
+				response.InternalServerError(w, "Failed to check task access")
+				return
+			}
+			if !ok {
+				h.logger.Error().Str("ErrorCode", TaskGetNotFound).Msg("Task is restricted to other members")
+				response.NotFound(w, TaskGetNotFound, "Cannot find task")
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// currentUserId is 0 when the caller is unknown, which only grants access to
+// containers shared with the whole group.
+func (h *Handler) currentUserId(r *http.Request) int {
+	if user := h.currentUser(r); user != nil {
+		return user.Id
+	}
+	return 0
+}
+
+func (h *Handler) currentUser(r *http.Request) *userModel.User {
+	_, claims, _ := jwtauth.FromContext(r.Context())
+	user, err := h.userRepo.GetUserByUserId(fmt.Sprintf("%v", claims["nameid"]))
+	if err != nil {
+		return nil
+	}
+	return user
+}
+
+// buildBoard groups the tasks by section. Tasks keep their board position and
+// fall back to the listing order for equal positions.
+func buildBoard(container containerModel.TaskContainer, sections []containerModel.ContainerSection, tasks []model.Task, placements []model.TaskPlacement) BoardDto {
+	byTask := make(map[string]model.TaskPlacement, len(placements))
+	for _, placement := range placements {
+		byTask[placement.TaskId] = placement
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return byTask[tasks[i].TaskId].Position < byTask[tasks[j].TaskId].Position
+	})
+
+	board := BoardDto{Container: container, Sections: []BoardSectionDto{}, Unsectioned: []model.Task{}}
+	index := make(map[string]int, len(sections))
+	for i, section := range sections {
+		index[section.Id] = i
+		board.Sections = append(board.Sections, BoardSectionDto{ContainerSection: section, Tasks: []model.Task{}})
+	}
+	for _, task := range tasks {
+		sectionId := byTask[task.TaskId].SectionId
+		if sectionId == nil {
+			board.Unsectioned = append(board.Unsectioned, task)
+			continue
+		}
+		i, ok := index[*sectionId]
+		if !ok {
+			board.Unsectioned = append(board.Unsectioned, task)
+			continue
+		}
+		board.Sections[i].Tasks = append(board.Sections[i].Tasks, task)
+	}
+	return board
+}
+
+// viewerLocation reads the caller's IANA time zone from the tz query parameter
+// or the X-Timezone header. A nil location means each task is evaluated in the
+// time zone it was created in.
+func viewerLocation(r *http.Request) (*time.Location, error) {
+	name := r.URL.Query().Get("tz")
+	if name == "" {
+		name = r.Header.Get("X-Timezone")
+	}
+	if name == "" {
+		return nil, nil
+	}
+	return model.LoadTimezone(name)
+}
+
+// attachMentions resolves the mentions of the listed tasks with one query.
+func (h *Handler) attachMentions(tasks []model.Task) error {
+	ids := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		ids = append(ids, t.TaskId)
+	}
+	mentions, err := h.taskRepo.GetMentionsByTaskIds(ids)
+	if err != nil {
+		return err
+	}
+	for i := range tasks {
+		tasks[i].Mentions = mentions[tasks[i].TaskId]
+	}
+	return nil
+}
+
+func evaluateDue(tasks []model.Task, loc *time.Location) {
+	now := time.Now()
+	for i := range tasks {
+		tasks[i].EvaluateDue(now, loc)
+	}
+}
+
+// taskFilterFromQuery reads the listing options. The sort parameter accepts
+// priority, target_date or created_at, prefixed with '-' for descending order.
+func taskFilterFromQuery(r *http.Request) (taskRepo.TaskFilter, error) {
+	filter := taskRepo.TaskFilter{
+		IncludeSnoozed:  r.URL.Query().Get("include_snoozed") == "true",
+		IncludeArchived: r.URL.Query().Get("include_archived") == "true",
+	}
+	sort := r.URL.Query().Get("sort")
+	if sort == "" {
+		return filter, nil
+	}
+	if sort[0] == '-' {
+		filter.SortDesc = true
+		sort = sort[1:]
+	}
+	switch sort {
+	case taskRepo.SortByPriority, taskRepo.SortByTargetDate, taskRepo.SortByCreatedAt:
+		filter.SortBy = sort
+	default:
+		return filter, fmt.Errorf("invalid sort field: %s", sort)
+	}
+	return filter, nil
+}
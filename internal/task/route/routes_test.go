@@ -0,0 +1,213 @@
+package route
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth"
+	"github.com/happYness-Project/taskManagementGolang/internal/mocks"
+	"github.com/happYness-Project/taskManagementGolang/internal/task/model"
+	"github.com/happYness-Project/taskManagementGolang/internal/task/repository"
+	containerModel "github.com/happYness-Project/taskManagementGolang/internal/taskcontainer/model"
+	userModel "github.com/happYness-Project/taskManagementGolang/internal/user/model"
+	groupModel "github.com/happYness-Project/taskManagementGolang/internal/usergroup/model"
+	"github.com/happYness-Project/taskManagementGolang/pkg/configs"
+	"github.com/happYness-Project/taskManagementGolang/pkg/loggers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockTaskRepo only implements the calls these tests reach; any other call
+// panics on the nil embedded repository.
+type mockTaskRepo struct {
+	mock.Mock
+	repository.TaskRepository
+}
+
+func (m *mockTaskRepo) CanAccessTask(id string, userId int) (bool, error) {
+	args := m.Called(id, userId)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockTaskRepo) GetTaskById(id string) (*model.Task, error) {
+	args := m.Called(id)
+	return args.Get(0).(*model.Task), args.Error(1)
+}
+
+func (m *mockTaskRepo) GetTaskMentions(taskId string) ([]model.TaskMention, error) {
+	args := m.Called(taskId)
+	return args.Get(0).([]model.TaskMention), args.Error(1)
+}
+
+func (m *mockTaskRepo) GetUserGroupIdByTaskId(taskId string) (int, error) {
+	args := m.Called(taskId)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *mockTaskRepo) UpdateTask(task model.Task, editedBy *int) error {
+	args := m.Called(task, editedBy)
+	return args.Error(0)
+}
+
+func TestTaskHandler_RequireAccess(t *testing.T) {
+	logger := loggers.Setup(configs.Env{})
+	mockTaskRepo := new(mockTaskRepo)
+	mockContainerRepo := new(mocks.MockContainerRepo)
+	mockUserRepo := new(mocks.MockUserRepo)
+	handler := NewHandler(logger, mockTaskRepo, mockContainerRepo, new(mocks.MockUserGroupRepo), mockUserRepo, nil, nil, nil, nil, nil, new(mocks.MockSectionRepo))
+	router := chi.NewRouter()
+	handler.RegisterRoutes(router)
+	mockUserRepo.On("GetUserByUserId", "user-a").Return(&userModel.User{Id: 1, UserId: "user-a"}, nil)
+
+	t.Run("when task only belongs to containers restricted to other members, Then return status code 404", func(t *testing.T) {
+		// Arrange
+		mockTaskRepo.On("CanAccessTask", "secret", 1).Return(false, nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodGet, "/api/tasks/secret", nil), "user-a")
+		rr := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockTaskRepo.AssertNotCalled(t, "GetTaskById", "secret")
+	})
+
+	t.Run("when container is restricted to other members, Then return status code 404", func(t *testing.T) {
+		mockContainerRepo.On("CanAccessContainer", "party", 1).Return(false, nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodGet, "/api/task-containers/party/tasks", nil), "user-a")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockContainerRepo.AssertExpectations(t)
+	})
+
+	t.Run("when caller can access the task, Then the request reaches the handler", func(t *testing.T) {
+		mockTaskRepo.On("CanAccessTask", "shared", 1).Return(true, nil).Once()
+		mockTaskRepo.On("GetTaskById", "shared").Return(&model.Task{TaskId: "shared", TaskName: "Dishes"}, nil).Once()
+		mockTaskRepo.On("GetTaskMentions", "shared").Return([]model.TaskMention{}, nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodGet, "/api/tasks/shared", nil), "user-a")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockTaskRepo.AssertExpectations(t)
+	})
+}
+
+func TestTaskHandler_GroupMembership(t *testing.T) {
+	logger := loggers.Setup(configs.Env{})
+	mockTaskRepo := new(mockTaskRepo)
+	mockContainerRepo := new(mocks.MockContainerRepo)
+	mockUserRepo := new(mocks.MockUserRepo)
+	mockGroupRepo := new(mocks.MockUserGroupRepo)
+	mockSectionRepo := new(mocks.MockSectionRepo)
+	handler := NewHandler(logger, mockTaskRepo, mockContainerRepo, mockGroupRepo, mockUserRepo, nil, nil, nil, nil, nil, mockSectionRepo)
+	router := chi.NewRouter()
+	handler.RegisterRoutes(router)
+	mockUserRepo.On("GetUserByUserId", "outsider").Return(&userModel.User{Id: 9, UserId: "outsider"}, nil)
+	mockGroupRepo.On("IsUserInGroup", 7, 9).Return(false, nil)
+	mockContainerRepo.On("CanAccessContainer", "chores", 9).Return(true, nil)
+	mockContainerRepo.On("GetById", "chores").Return(&containerModel.TaskContainer{Id: "chores", UsergroupId: 7, Visibility: containerModel.VisibilityGroup}, nil)
+	mockTaskRepo.On("CanAccessTask", "dishes", 9).Return(true, nil)
+	mockTaskRepo.On("GetTaskById", "dishes").Return(&model.Task{TaskId: "dishes", TaskName: "Dishes"}, nil)
+	mockTaskRepo.On("GetUserGroupIdByTaskId", "dishes").Return(7, nil)
+
+	t.Run("when a user outside the group reads the board, Then return status code 403", func(t *testing.T) {
+		// Arrange
+		req := withCaller(t, httptest.NewRequest(http.MethodGet, "/api/task-containers/chores/board", nil), "outsider")
+		rr := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockSectionRepo.AssertNotCalled(t, "GetSections", "chores")
+	})
+
+	t.Run("when a user outside the group reads the container stats, Then return status code 403", func(t *testing.T) {
+		req := withCaller(t, httptest.NewRequest(http.MethodGet, "/api/task-containers/chores/stats", nil), "outsider")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockTaskRepo.AssertNotCalled(t, "GetContainerStats", "chores", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when a user outside the group lists its archived tasks, Then return status code 403", func(t *testing.T) {
+		mockGroupRepo.On("GetById", 7).Return(&groupModel.UserGroup{GroupId: 7}, nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodGet, "/api/user-groups/7/archive", nil), "outsider")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockTaskRepo.AssertNotCalled(t, "GetArchivedTasksByGroupId", 7, mock.Anything)
+	})
+
+	t.Run("when a user outside the group snoozes a task, Then return status code 403", func(t *testing.T) {
+		req := withCaller(t, httptest.NewRequest(http.MethodPost, "/api/tasks/dishes/snooze", strings.NewReader(`{"duration":"1h"}`)), "outsider")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockTaskRepo.AssertNotCalled(t, "UpdateTaskSnooze", mock.Anything)
+	})
+
+	t.Run("when a user outside the group reads the task activities, Then return status code 403", func(t *testing.T) {
+		req := withCaller(t, httptest.NewRequest(http.MethodGet, "/api/tasks/dishes/activities", nil), "outsider")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockTaskRepo.AssertNotCalled(t, "GetTaskActivities", "dishes")
+	})
+}
+
+func withCaller(t *testing.T, req *http.Request, userId string) *http.Request {
+	token, _, err := jwtauth.New("HS256", []byte("secret"), nil).Encode(map[string]interface{}{"nameid": userId})
+	require.NoError(t, err)
+	return req.WithContext(jwtauth.NewContext(req.Context(), token, nil))
+}
+
+func TestTaskHandler_UpdateTask(t *testing.T) {
+	logger := loggers.Setup(configs.Env{})
+	mockTaskRepo := new(mockTaskRepo)
+	mockContainerRepo := new(mocks.MockContainerRepo)
+	mockUserRepo := new(mocks.MockUserRepo)
+	handler := NewHandler(logger, mockTaskRepo, mockContainerRepo, new(mocks.MockUserGroupRepo), mockUserRepo, nil, nil, nil, nil, nil, new(mocks.MockSectionRepo))
+	router := chi.NewRouter()
+	handler.RegisterRoutes(router)
+	mockUserRepo.On("GetUserByUserId", "user-a").Return(&userModel.User{Id: 1, UserId: "user-a"}, nil)
+	mockTaskRepo.On("CanAccessTask", "laundry", 1).Return(true, nil)
+	mockContainerRepo.On("GetById", "chores").Return(&containerModel.TaskContainer{Id: "chores", UsergroupId: 7, Type: containerModel.TypeNormal}, nil)
+
+	t.Run("when a task outside a grocery container is updated with a quantity and aisle, Then they are not stored", func(t *testing.T) {
+		// Arrange
+		mockTaskRepo.On("GetTaskById", "laundry").Return(&model.Task{TaskId: "laundry", TaskName: "Laundry", ContainerIds: []string{"chores"}}, nil).Once()
+		mockTaskRepo.On("UpdateTask", mock.MatchedBy(func(task model.Task) bool {
+			return task.Quantity == nil && task.Aisle == ""
+		}), mock.Anything).Return(assert.AnError).Once()
+		body := `{"name":"Laundry","priority":"normal","quantity":2,"unit":"kg","aisle":"Cleaning"}`
+		req := withCaller(t, httptest.NewRequest(http.MethodPut, "/api/tasks/laundry", strings.NewReader(body)), "user-a")
+		rr := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockTaskRepo.AssertExpectations(t)
+	})
+}
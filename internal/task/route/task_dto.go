@@ -1,19 +1,93 @@
 package route
 
-import "time"
+import (
+	"time"
+
+	"github.com/happYness-Project/taskManagementGolang/internal/task/model"
+	containerModel "github.com/happYness-Project/taskManagementGolang/internal/taskcontainer/model"
+)
 
 type CreateTaskDto struct {
-	TaskName   string    `json:"name"`
-	TaskDesc   string    `json:"description"`
-	TargetDate time.Time `json:"target_date"`
-	Priority   string    `json:"priority"`
-	Category   string    `json:"category"`
+	TaskName            string    `json:"name"`
+	TaskDesc            string    `json:"description"`
+	TargetDate          time.Time `json:"target_date"`
+	DueDate             string    `json:"due_date"`
+	Timezone            string    `json:"timezone"`
+	Priority            string    `json:"priority"`
+	Category            string    `json:"category"`
+	Quantity            *float64  `json:"quantity"`
+	Unit                string    `json:"unit"`
+	Aisle               string    `json:"aisle"`
+	Recurrence          string    `json:"recurrence"`
+	Points              *int      `json:"points"`
+	Frequency           string    `json:"frequency"`
+	TargetCount         int       `json:"target_count"`
+	AllowPastTargetDate bool      `json:"allow_past_target_date"`
 }
 
 type UpdateTaskDto struct {
-	TaskName   string    `json:"name"`
-	TaskDesc   string    `json:"description"`
-	TargetDate time.Time `json:"target_date"`
-	Priority   string    `json:"priority"`
-	Category   string    `json:"category"`
+	TaskName            string    `json:"name"`
+	TaskDesc            string    `json:"description"`
+	TargetDate          time.Time `json:"target_date"`
+	DueDate             string    `json:"due_date"`
+	Timezone            string    `json:"timezone"`
+	Priority            string    `json:"priority"`
+	Category            string    `json:"category"`
+	Quantity            *float64  `json:"quantity"`
+	Unit                string    `json:"unit"`
+	Aisle               string    `json:"aisle"`
+	Recurrence          string    `json:"recurrence"`
+	Points              *int      `json:"points"`
+	Frequency           string    `json:"frequency"`
+	TargetCount         int       `json:"target_count"`
+	AllowPastTargetDate bool      `json:"allow_past_target_date"`
+}
+
+type SnoozeTaskDto struct {
+	Duration       string     `json:"duration"`
+	Until          *time.Time `json:"until"`
+	MoveTargetDate bool       `json:"move_target_date"`
+}
+
+type CreateReminderDto struct {
+	Channel       string     `json:"channel"`
+	Target        string     `json:"target"`
+	RemindAt      *time.Time `json:"remind_at"`
+	OffsetMinutes *int       `json:"offset_minutes"`
+}
+
+type CheckInDto struct {
+	Date string `json:"date"`
+}
+
+type UpdateRotationDto struct {
+	Strategy string `json:"strategy"`
+	Members  []int  `json:"members"`
+}
+
+type SwapRotationDto struct {
+	UserId      int `json:"user_id"`
+	OtherUserId int `json:"other_user_id"`
+}
+
+type TaskRevisionDto struct {
+	model.TaskRevision
+	Changes []model.FieldChange `json:"changes"`
+}
+
+type MoveTaskDto struct {
+	SectionId   *string `json:"section_id"`
+	Position    int     `json:"position"`
+	ApplyStatus bool    `json:"apply_status"`
+}
+
+type BoardSectionDto struct {
+	containerModel.ContainerSection
+	Tasks []model.Task `json:"tasks"`
+}
+
+type BoardDto struct {
+	Container   containerModel.TaskContainer `json:"container"`
+	Sections    []BoardSectionDto            `json:"sections"`
+	Unsectioned []model.Task                 `json:"unsectioned"`
 }
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// ActivityWindow is how far back events are counted in a container's activity level.
+const ActivityWindow = 7 * 24 * time.Hour
+
+// Weights of the events counted in a container's activity level. The level is
+// the weighted number of events on the container's tasks within the window.
+// Task activity entries cover edits, snoozes, merges and check-ins.
+const (
+	ActivityWeightCreated   = 1
+	ActivityWeightCompleted = 2
+	ActivityWeightUpdated   = 1
+)
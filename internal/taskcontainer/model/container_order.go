@@ -0,0 +1,38 @@
+package model
+
+import "errors"
+
+var (
+	ErrContainerNotInGroup       = errors.New("container does not belong to the user group")
+	ErrDuplicateContainerInOrder = errors.New("container appears more than once in the order")
+)
+
+// ContainerOrder is one user's placement of a container in a group listing.
+// Pinned containers are listed first, each part in ascending position.
+type ContainerOrder struct {
+	ContainerId string `json:"container_id"`
+	Position    int    `json:"position"`
+	IsPinned    bool   `json:"is_pinned"`
+}
+
+// NumberContainerOrders sets the positions in the given order. Every container
+// must belong to the group; containers left out fall back to the default order.
+func NumberContainerOrders(orders []ContainerOrder, groupContainers []TaskContainer) ([]ContainerOrder, error) {
+	inGroup := make(map[string]bool, len(groupContainers))
+	for _, c := range groupContainers {
+		inGroup[c.Id] = true
+	}
+	seen := make(map[string]bool, len(orders))
+	numbered := make([]ContainerOrder, 0, len(orders))
+	for i, order := range orders {
+		if !inGroup[order.ContainerId] {
+			return nil, ErrContainerNotInGroup
+		}
+		if seen[order.ContainerId] {
+			return nil, ErrDuplicateContainerInOrder
+		}
+		seen[order.ContainerId] = true
+		numbered = append(numbered, ContainerOrder{ContainerId: order.ContainerId, Position: i, IsPinned: order.IsPinned})
+	}
+	return numbered, nil
+}
@@ -0,0 +1,41 @@
+package model
+
+import "errors"
+
+// MaxDateOffsetDays bounds how far the dates of duplicated tasks can be shifted.
+const MaxDateOffsetDays = 3650
+
+var ErrInvalidDateOffset = errors.New("date offset must be between -3650 and 3650 days")
+
+// DuplicateOptions controls how the tasks of a container are copied.
+type DuplicateOptions struct {
+	ResetCompletion bool
+	DateOffsetDays  int
+}
+
+func NewDuplicateOptions(resetCompletion bool, dateOffsetDays int) (*DuplicateOptions, error) {
+	if dateOffsetDays < -MaxDateOffsetDays || dateOffsetDays > MaxDateOffsetDays {
+		return nil, ErrInvalidDateOffset
+	}
+	return &DuplicateOptions{ResetCompletion: resetCompletion, DateOffsetDays: dateOffsetDays}, nil
+}
+
+// Duplicate returns an active copy of the container in the given user group.
+// The copy keeps the name unless another one is given, and its visibility.
+func (c TaskContainer) Duplicate(id string, groupId int, name string) (*TaskContainer, error) {
+	duplicate := TaskContainer{
+		Id:          id,
+		Name:        c.Name,
+		Description: c.Description,
+		Type:        c.Type,
+		Visibility:  c.Visibility,
+		IsActive:    true,
+		UsergroupId: groupId,
+	}
+	if name != "" {
+		if err := duplicate.Rename(name); err != nil {
+			return nil, err
+		}
+	}
+	return &duplicate, nil
+}
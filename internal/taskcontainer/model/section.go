@@ -0,0 +1,97 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// A section status maps moving a task into the section to a completion change.
+const (
+	SectionStatusNone = ""
+	SectionStatusOpen = "open"
+	SectionStatusDone = "done"
+)
+
+const MaxSectionNameLength = 50
+
+var (
+	ErrInvalidSectionName   = errors.New("section name must be between 1 and 50 characters")
+	ErrInvalidSectionStatus = errors.New("section status must be empty, open or done")
+	ErrSectionOrderMismatch = errors.New("section order must list every section of the container exactly once")
+)
+
+// ContainerSection is an ordered column of a container's board.
+type ContainerSection struct {
+	Id          string    `json:"id"`
+	ContainerId string    `json:"container_id"`
+	Name        string    `json:"name"`
+	Position    int       `json:"position"`
+	Status      string    `json:"status,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func NewSection(id string, containerId string, name string, status string) (*ContainerSection, error) {
+	section := &ContainerSection{Id: id, ContainerId: containerId, CreatedAt: time.Now()}
+	if err := section.Rename(name); err != nil {
+		return nil, err
+	}
+	if err := section.SetStatus(status); err != nil {
+		return nil, err
+	}
+	return section, nil
+}
+
+func (s *ContainerSection) Rename(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > MaxSectionNameLength {
+		return ErrInvalidSectionName
+	}
+	s.Name = name
+	return nil
+}
+
+func (s *ContainerSection) SetStatus(status string) error {
+	status = strings.ToLower(strings.TrimSpace(status))
+	switch status {
+	case SectionStatusNone, SectionStatusOpen, SectionStatusDone:
+		s.Status = status
+		return nil
+	}
+	return ErrInvalidSectionStatus
+}
+
+// Completes reports whether moving a task into the section completes it (true)
+// or reopens it (false). ok is false when the section has no status.
+func (s ContainerSection) Completes() (completes bool, ok bool) {
+	switch s.Status {
+	case SectionStatusDone:
+		return true, true
+	case SectionStatusOpen:
+		return false, true
+	}
+	return false, false
+}
+
+// ReorderSections returns the sections in the order of ids with their positions
+// renumbered from zero.
+func ReorderSections(sections []ContainerSection, ids []string) ([]ContainerSection, error) {
+	if len(ids) != len(sections) {
+		return nil, ErrSectionOrderMismatch
+	}
+	byId := make(map[string]ContainerSection, len(sections))
+	for _, section := range sections {
+		byId[section.Id] = section
+	}
+	ordered := make([]ContainerSection, 0, len(ids))
+	for i, id := range ids {
+		section, ok := byId[id]
+		if !ok {
+			return nil, ErrSectionOrderMismatch
+		}
+		delete(byId, id)
+		section.Position = i
+		ordered = append(ordered, section)
+	}
+	return ordered, nil
+}
@@ -1,5 +1,10 @@
 package model
 
+import (
+	"errors"
+	"strings"
+)
+
 type TaskContainer struct {
 	Id             string `json:"id"`
 	Name           string `json:"name"`
@@ -8,4 +13,136 @@ type TaskContainer struct {
 	IsActive       bool   `json:"is_active"`
 	Activity_level int    `json:"activity_level"`
 	UsergroupId    int    `json:"usergroup_id"`
+	Visibility     string `json:"visibility"`
+	// IsPinned is only set when listing containers for a user.
+	IsPinned bool `json:"is_pinned"`
+}
+
+const (
+	TypeNormal  = "normal"
+	TypeGrocery = "grocery"
+	TypeChores  = "chores"
+	TypeHabit   = "habit"
+)
+
+// A container is visible to the whole user group or only to its member list.
+const (
+	VisibilityGroup   = "group"
+	VisibilityMembers = "members"
+)
+
+const (
+	MaxContainerNameLength        = 100
+	MaxContainerDescriptionLength = 255
+)
+
+var (
+	ErrInvalidContainerName        = errors.New("container name must be between 1 and 100 characters")
+	ErrContainerDescriptionTooLong = errors.New("container description cannot exceed 255 characters")
+	ErrInvalidContainerType        = errors.New("container type must be one of normal, grocery, chores, habit")
+	ErrContainerArchived           = errors.New("container is archived")
+	ErrInvalidVisibility           = errors.New("container visibility must be one of group, members")
+	ErrMemberNotInGroup            = errors.New("container members must belong to the user group")
+	ErrLastContainerMember         = errors.New("a restricted container must keep at least one member")
+)
+
+// Types lists every container type.
+var Types = []string{TypeNormal, TypeGrocery, TypeChores, TypeHabit}
+
+func (c *TaskContainer) Rename(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > MaxContainerNameLength {
+		return ErrInvalidContainerName
+	}
+	c.Name = name
+	return nil
+}
+
+func (c *TaskContainer) Describe(description string) error {
+	if len(description) > MaxContainerDescriptionLength {
+		return ErrContainerDescriptionTooLong
+	}
+	c.Description = description
+	return nil
+}
+
+// ChangeType switches the container to another type. An empty type means normal.
+func (c *TaskContainer) ChangeType(containerType string) error {
+	containerType = strings.ToLower(strings.TrimSpace(containerType))
+	if containerType == "" {
+		containerType = TypeNormal
+	}
+	for _, t := range Types {
+		if t == containerType {
+			c.Type = containerType
+			return nil
+		}
+	}
+	return ErrInvalidContainerType
+}
+
+// SetVisibility restricts the container to its members or shares it with the
+// whole group. An empty visibility means group.
+func (c *TaskContainer) SetVisibility(visibility string) error {
+	visibility = strings.ToLower(strings.TrimSpace(visibility))
+	switch visibility {
+	case "":
+		c.Visibility = VisibilityGroup
+	case VisibilityGroup, VisibilityMembers:
+		c.Visibility = visibility
+	default:
+		return ErrInvalidVisibility
+	}
+	return nil
+}
+
+// IsRestricted reports whether only the container's members can see it.
+func (c TaskContainer) IsRestricted() bool {
+	return c.Visibility == VisibilityMembers
+}
+
+// CanRemoveMember reports whether the user can leave the member list without
+// leaving a restricted container that nobody can see.
+func (c TaskContainer) CanRemoveMember(members []int, userId int) error {
+	if !c.IsRestricted() {
+		return nil
+	}
+	for _, member := range members {
+		if member != userId {
+			return nil
+		}
+	}
+	return ErrLastContainerMember
+}
+
+// IsArchived reports whether the container has been deactivated. Archived
+// containers keep their tasks but accept no new ones.
+func (c TaskContainer) IsArchived() bool {
+	return !c.IsActive
+}
+
+func (c *TaskContainer) Archive() {
+	c.IsActive = false
+}
+
+func (c *TaskContainer) Unarchive() {
+	c.IsActive = true
+}
+
+// IsGrocery reports whether tasks in the container are grocery items with
+// quantities, units and aisles.
+func (c TaskContainer) IsGrocery() bool {
+	return c.Type == TypeGrocery
+}
+
+// IsChores reports whether recurring tasks in the container can rotate between
+// the members of the user group.
+func (c TaskContainer) IsChores() bool {
+	return c.Type == TypeChores
+}
+
+// IsHabit reports whether tasks in the container are habits that are checked in
+// per date instead of being completed once.
+func (c TaskContainer) IsHabit() bool {
+	return c.Type == TypeHabit
 }
@@ -6,16 +6,36 @@ import (
 	"fmt"
 	"time"
 
+	chatModel "github.com/happYness-Project/taskManagementGolang/internal/chat/model"
 	"github.com/happYness-Project/taskManagementGolang/internal/taskcontainer/model"
 )
 
 const dbTimeout = time.Second * 5
 
+// ContainerFilter narrows container listings. Archived containers are hidden
+// unless IncludeArchived is set. Containers restricted to their members are
+// only listed for those members. With a UserId the containers that user pinned
+// come first, followed by the order the user set unless SortByActivity is set.
+type ContainerFilter struct {
+	IncludeArchived bool
+	SortByActivity  bool
+	UserId          int
+}
+
 type ContainerRepository interface {
-	AllTaskContainers() ([]*model.TaskContainer, error)
+	AllTaskContainers(userId int) ([]*model.TaskContainer, error)
 	GetById(id string) (*model.TaskContainer, error)
-	GetContainersByGroupId(groupId int) ([]model.TaskContainer, error)
-	CreateContainer(container model.TaskContainer) error
+	GetContainersByGroupId(groupId int, filter ContainerFilter) ([]model.TaskContainer, error)
+	CreateContainer(container model.TaskContainer, createdBy int, chat chatModel.Chat, participants []chatModel.ChatParticipant) error
+	UpdateContainer(container model.TaskContainer) error
+	SetContainerActive(id string, active bool) error
+	CanAccessContainer(id string, userId int) (bool, error)
+	GetContainerMembers(id string) ([]int, error)
+	AddContainerMember(id string, userId int) error
+	RemoveContainerMember(id string, userId int) error
+	ReplaceContainerOrders(userId int, groupId int, orders []model.ContainerOrder) error
+	DuplicateContainer(sourceId string, duplicate model.TaskContainer, options model.DuplicateOptions, createdBy int, now time.Time, chat chatModel.Chat, participants []chatModel.ChatParticipant) (int, error)
+	RefreshActivityLevels(since time.Time) (int64, error)
 	DeleteContainer(id string) error
 	RemoveContainerByUsergroupId(groupId int) error
 }
@@ -30,11 +50,12 @@ func NewContainerRepository(db *sql.DB) *ContainerRepo {
 	}
 }
 
-func (m *ContainerRepo) AllTaskContainers() ([]*model.TaskContainer, error) {
+// AllTaskContainers lists every container visible to the user.
+func (m *ContainerRepo) AllTaskContainers(userId int) ([]*model.TaskContainer, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
 	defer cancel()
 
-	rows, err := m.DB.QueryContext(ctx, sqlGetAllContainers)
+	rows, err := m.DB.QueryContext(ctx, sqlGetAllContainers+" WHERE "+visibleToSql("$1"), userId)
 	if err != nil {
 		return nil, err
 	}
@@ -68,11 +89,30 @@ func (m *ContainerRepo) GetById(id string) (*model.TaskContainer, error) {
 	return container, err
 }
 
-func (m *ContainerRepo) GetContainersByGroupId(groupId int) ([]model.TaskContainer, error) {
+func (m *ContainerRepo) GetContainersByGroupId(groupId int, filter ContainerFilter) ([]model.TaskContainer, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
 	defer cancel()
 
-	rows, err := m.DB.QueryContext(ctx, sqlGetContainersByGroupId, groupId)
+	query := sqlGetContainersByGroupId
+	args := []any{groupId}
+	if filter.UserId != 0 {
+		query = sqlGetContainersByGroupIdForUser + " AND " + visibleToSql("$2")
+		args = append(args, filter.UserId)
+	} else {
+		query += sqlExcludeRestrictedContainers
+	}
+	if !filter.IncludeArchived {
+		query += sqlExcludeArchivedContainers
+	}
+	switch {
+	case filter.UserId != 0 && filter.SortByActivity:
+		query += sqlOrderPinnedContainersByActivity
+	case filter.UserId != 0:
+		query += sqlOrderContainersByUserPosition
+	case filter.SortByActivity:
+		query += sqlOrderContainersByActivity
+	}
+	rows, err := m.DB.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -80,24 +120,310 @@ func (m *ContainerRepo) GetContainersByGroupId(groupId int) ([]model.TaskContain
 
 	containers := []model.TaskContainer{}
 	for rows.Next() {
-		container, err := scanRowsIntoContainer(rows)
+		var pinned bool
+		var container *model.TaskContainer
+		if filter.UserId != 0 {
+			container, err = scanRowsIntoContainer(rows, &pinned)
+		} else {
+			container, err = scanRowsIntoContainer(rows)
+		}
 		if err != nil {
 			return nil, err
 		}
+		container.IsPinned = pinned
 
 		containers = append(containers, *container)
 	}
 	return containers, nil
 }
 
-func (m *ContainerRepo) CreateContainer(c model.TaskContainer) error {
-	_, err := m.DB.Exec(sqlCreateContainer, c.Id, c.Name, c.Description, c.IsActive, c.Activity_level, c.Type, c.UsergroupId)
+// CreateContainer stores the container together with its chat and the chat
+// participants. The creator becomes the first member of a restricted container.
+func (m *ContainerRepo) CreateContainer(c model.TaskContainer, createdBy int, chat chatModel.Chat, participants []chatModel.ChatParticipant) error {
+	tx, err := m.DB.Begin()
 	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.Exec(sqlCreateContainer, c.Id, c.Name, c.Description, c.IsActive, c.Activity_level, c.Type, c.UsergroupId, c.Visibility); err != nil {
 		return fmt.Errorf("unable to insert into taskcontainer table : %w", err)
 	}
+	if c.IsRestricted() {
+		if _, err = tx.Exec(sqlAddContainerMember, c.Id, createdBy); err != nil {
+			return fmt.Errorf("unable to insert into taskcontainer_member table : %w", err)
+		}
+	}
+	if _, err = tx.Exec(sqlCreateContainerChat, chat.Id, chat.Type, chat.UserGroupId, chat.ContainerId, chat.CreatedAt); err != nil {
+		return fmt.Errorf("unable to insert into chat table : %w", err)
+	}
+	for _, p := range participants {
+		if _, err = tx.Exec(sqlCreateChatParticipant, p.ChatId, p.UserId, p.JoinedAt, p.Role, p.Status); err != nil {
+			return fmt.Errorf("unable to insert into chat_participant table : %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+func (m *ContainerRepo) UpdateContainer(c model.TaskContainer) error {
+	_, err := m.DB.Exec(sqlUpdateContainer, c.Id, c.Name, c.Description, c.Type, c.Visibility)
+	if err != nil {
+		return fmt.Errorf("unable to update taskcontainer table : %w", err)
+	}
+	return nil
+}
+
+func (m *ContainerRepo) SetContainerActive(id string, active bool) error {
+	_, err := m.DB.Exec(sqlUpdateContainerActive, id, active)
+	if err != nil {
+		return fmt.Errorf("unable to update taskcontainer is_active : %w", err)
+	}
 	return nil
 }
 
+// CanAccessContainer reports whether the user may see the container. Unknown
+// containers are reported as accessible so that callers answer with not found.
+func (m *ContainerRepo) CanAccessContainer(id string, userId int) (bool, error) {
+	var visible bool
+	err := m.DB.QueryRow(`SELECT `+visibleToSql("$2")+` FROM public.taskcontainer tc WHERE tc.id = $1`, id, userId).Scan(&visible)
+	if err == sql.ErrNoRows {
+		return true, nil
+	}
+	return visible, err
+}
+
+func (m *ContainerRepo) GetContainerMembers(id string) ([]int, error) {
+	rows, err := m.DB.Query(sqlGetContainerMembers, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := []int{}
+	for rows.Next() {
+		var userId int
+		if err := rows.Scan(&userId); err != nil {
+			return nil, err
+		}
+		members = append(members, userId)
+	}
+	return members, rows.Err()
+}
+
+// AddContainerMember also makes the user a participant of the container chat.
+func (m *ContainerRepo) AddContainerMember(id string, userId int) error {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.Exec(sqlAddContainerMember, id, userId); err != nil {
+		return fmt.Errorf("unable to insert into taskcontainer_member table : %w", err)
+	}
+	if _, err = tx.Exec(sqlAddContainerChatParticipant, id, userId, chatModel.RoleMember, chatModel.StatusActive); err != nil {
+		return fmt.Errorf("unable to insert into chat_participant table : %w", err)
+	}
+	return tx.Commit()
+}
+
+// RemoveContainerMember also removes the user from the container chat. It
+// returns sql.ErrNoRows when the user is not a member.
+func (m *ContainerRepo) RemoveContainerMember(id string, userId int) error {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	result, err := tx.Exec(sqlRemoveContainerMember, id, userId)
+	if err != nil {
+		return fmt.Errorf("unable to delete from taskcontainer_member table : %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		err = sql.ErrNoRows
+		return err
+	}
+	if _, err = tx.Exec(sqlRemoveContainerChatParticipant, id, userId); err != nil {
+		return fmt.Errorf("unable to delete from chat_participant table : %w", err)
+	}
+	return tx.Commit()
+}
+
+// ReplaceContainerOrders replaces the user's order of the group's containers.
+func (m *ContainerRepo) ReplaceContainerOrders(userId int, groupId int, orders []model.ContainerOrder) error {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.Exec(sqlDeleteContainerOrders, userId, groupId); err != nil {
+		return fmt.Errorf("unable to delete from user_container_order table : %w", err)
+	}
+	for _, order := range orders {
+		if _, err = tx.Exec(sqlCreateContainerOrder, userId, order.ContainerId, order.Position, order.IsPinned); err != nil {
+			return fmt.Errorf("unable to insert into user_container_order table : %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+type taskLink struct {
+	taskId    string
+	sectionId *string
+	position  int
+}
+
+// DuplicateContainer creates the duplicate and its chat with a copy of every
+// section and unarchived task of the source container in one transaction and
+// returns the number of copied tasks. A restricted duplicate keeps the source
+// members that belong to its user group.
+func (m *ContainerRepo) DuplicateContainer(sourceId string, duplicate model.TaskContainer, options model.DuplicateOptions, createdBy int, now time.Time, chat chatModel.Chat, participants []chatModel.ChatParticipant) (int, error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	c := duplicate
+	if _, err = tx.Exec(sqlCreateContainer, c.Id, c.Name, c.Description, c.IsActive, c.Activity_level, c.Type, c.UsergroupId, c.Visibility); err != nil {
+		return 0, fmt.Errorf("unable to insert into taskcontainer table : %w", err)
+	}
+	if c.IsRestricted() {
+		if _, err = tx.Exec(sqlDuplicateContainerMembers, sourceId, c.Id, c.UsergroupId); err != nil {
+			return 0, fmt.Errorf("unable to duplicate container members : %w", err)
+		}
+		if _, err = tx.Exec(sqlAddContainerMember, c.Id, createdBy); err != nil {
+			return 0, fmt.Errorf("unable to insert into taskcontainer_member table : %w", err)
+		}
+	}
+	if _, err = tx.Exec(sqlCreateContainerChat, chat.Id, chat.Type, chat.UserGroupId, chat.ContainerId, chat.CreatedAt); err != nil {
+		return 0, fmt.Errorf("unable to insert into chat table : %w", err)
+	}
+	for _, p := range participants {
+		if _, err = tx.Exec(sqlCreateChatParticipant, p.ChatId, p.UserId, p.JoinedAt, p.Role, p.Status); err != nil {
+			return 0, fmt.Errorf("unable to insert into chat_participant table : %w", err)
+		}
+	}
+	if c.IsRestricted() {
+		if _, err = tx.Exec(sqlDuplicateContainerChatParticipants, c.Id, chat.Id, chatModel.RoleMember, chatModel.StatusActive); err != nil {
+			return 0, fmt.Errorf("unable to insert into chat_participant table : %w", err)
+		}
+	}
+
+	var sectionIds []string
+	if sectionIds, err = queryIds(tx, sqlGetContainerSectionIds, sourceId); err != nil {
+		return 0, err
+	}
+	sections := make(map[string]string, len(sectionIds))
+	for _, sectionId := range sectionIds {
+		var copyId string
+		if err = tx.QueryRow(sqlDuplicateSection, sectionId, c.Id).Scan(&copyId); err != nil {
+			return 0, fmt.Errorf("unable to duplicate section : %w", err)
+		}
+		sections[sectionId] = copyId
+	}
+
+	var links []taskLink
+	if links, err = queryTaskLinks(tx, sourceId); err != nil {
+		return 0, err
+	}
+	for _, link := range links {
+		var copyId string
+		err = tx.QueryRow(sqlDuplicateTask, link.taskId, now, options.DateOffsetDays, options.ResetCompletion, createdBy, c.UsergroupId, sourceId).Scan(&copyId)
+		if err != nil {
+			return 0, fmt.Errorf("unable to duplicate task : %w", err)
+		}
+		var sectionId *string
+		if link.sectionId != nil {
+			copySectionId := sections[*link.sectionId]
+			sectionId = &copySectionId
+		}
+		if _, err = tx.Exec(sqlLinkDuplicatedTask, c.Id, copyId, sectionId, link.position); err != nil {
+			return 0, fmt.Errorf("unable to insert into taskcontainer_task table : %w", err)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(links), nil
+}
+
+func queryIds(tx *sql.Tx, query string, args ...any) ([]string, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func queryTaskLinks(tx *sql.Tx, containerId string) ([]taskLink, error) {
+	rows, err := tx.Query(sqlGetContainerTaskLinks, containerId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := []taskLink{}
+	for rows.Next() {
+		var link taskLink
+		var sectionId sql.NullString
+		if err := rows.Scan(&link.taskId, &sectionId, &link.position); err != nil {
+			return nil, err
+		}
+		if sectionId.Valid {
+			link.sectionId = &sectionId.String
+		}
+		links = append(links, link)
+	}
+	return links, rows.Err()
+}
+
+// RefreshActivityLevels recomputes the activity level of every container from
+// the events since the given time and returns how many levels changed.
+func (m *ContainerRepo) RefreshActivityLevels(since time.Time) (int64, error) {
+	result, err := m.DB.Exec(sqlRefreshActivityLevels, since, model.ActivityWeightCreated, model.ActivityWeightCompleted, model.ActivityWeightUpdated)
+	if err != nil {
+		return 0, fmt.Errorf("unable to refresh taskcontainer activity levels : %w", err)
+	}
+	return result.RowsAffected()
+}
+
 func (m *ContainerRepo) DeleteContainer(id string) error {
 	_, err := m.DB.Exec(sqlDeleteContainer, id)
 	if err != nil {
@@ -114,18 +440,34 @@ func (m *ContainerRepo) RemoveContainerByUsergroupId(groupId int) error {
 	return nil
 }
 
-func scanRowsIntoContainer(rows *sql.Rows) (*model.TaskContainer, error) {
+// visibleToSql matches the containers tc that the user bound to param may see:
+// containers shared with the whole group, and restricted containers listing the
+// user as a member while the user still belongs to the group.
+func visibleToSql(param string) string {
+	return `(tc.visibility <> 'members' OR (EXISTS (SELECT 1 FROM public.taskcontainer_member cm WHERE cm.container_id = tc.id AND cm.user_id = ` + param + `)
+		AND EXISTS (SELECT 1 FROM public.usergroup_user ugu WHERE ugu.usergroup_id = tc.usergroup_id AND ugu.user_id = ` + param + `)))`
+}
+
+// scanRowsIntoContainer scans the container columns followed by any extra
+// columns of the query.
+func scanRowsIntoContainer(rows *sql.Rows, extra ...any) (*model.TaskContainer, error) {
 	container := new(model.TaskContainer)
-	err := rows.Scan(
+	var containerType sql.NullString
+	dest := []any{
 		&container.Id,
 		&container.Name,
 		&container.Description,
+		&containerType,
 		&container.IsActive,
+		&container.Activity_level,
 		&container.UsergroupId,
-	)
+		&container.Visibility,
+	}
+	err := rows.Scan(append(dest, extra...)...)
 	if err != nil {
 		return nil, err
 	}
+	container.Type = containerType.String
 
 	return container, nil
 }
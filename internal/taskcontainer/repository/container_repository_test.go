@@ -4,9 +4,11 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
+	chatModel "github.com/happYness-Project/taskManagementGolang/internal/chat/model"
 	"github.com/happYness-Project/taskManagementGolang/internal/taskcontainer/model"
 	"github.com/stretchr/testify/require"
 )
@@ -21,18 +23,18 @@ func TestContainerRepo_AllTaskContainers(t *testing.T) {
 	t.Run("Container Exists", func(t *testing.T) {
 		mockContainer := mockContainerObj()
 		rows := mockContainerRows(mockContainer)
-		mock.ExpectQuery(sqlGetAllContainers).WillReturnRows(rows)
+		mock.ExpectQuery(sqlGetAllContainers + " WHERE " + visibleToSql("$1")).WithArgs(0).WillReturnRows(rows)
 
-		containers, err := containerRepo.AllTaskContainers()
+		containers, err := containerRepo.AllTaskContainers(0)
 
 		require.Nil(t, err)
 		require.Equal(t, mockContainer, *containers[0])
 	})
 
 	t.Run("Container does not exist, then should return nothing with no result error msg", func(t *testing.T) {
-		mock.ExpectQuery(sqlGetAllContainers).WillReturnError(sql.ErrNoRows)
+		mock.ExpectQuery(sqlGetAllContainers + " WHERE " + visibleToSql("$1")).WillReturnError(sql.ErrNoRows)
 
-		containers, err := containerRepo.AllTaskContainers()
+		containers, err := containerRepo.AllTaskContainers(0)
 
 		require.NotNil(t, err)
 		require.Equal(t, "sql: no rows in result set", err.Error())
@@ -87,26 +89,283 @@ func TestContainerRepo_GetContainersByGroupId(t *testing.T) {
 	t.Run("Containers exist for the given group id", func(t *testing.T) {
 		mockContainer := mockContainerObj()
 		rows := mockContainerRows(mockContainer)
-		mock.ExpectQuery(sqlGetContainersByGroupId).
+		mock.ExpectQuery(sqlGetContainersByGroupId + sqlExcludeRestrictedContainers + sqlExcludeArchivedContainers).
 			WithArgs(mockContainer.UsergroupId).
 			WillReturnRows(rows)
 
-		_, err := containerRepo.GetContainersByGroupId(mockContainer.UsergroupId)
+		_, err := containerRepo.GetContainersByGroupId(mockContainer.UsergroupId, ContainerFilter{})
 
 		require.Nil(t, err)
 	})
+
+	t.Run("when include archived, Then archived containers are not excluded", func(t *testing.T) {
+		mockContainer := mockContainerObj()
+		mockContainer.IsActive = false
+		mock.ExpectQuery(sqlGetContainersByGroupId + sqlExcludeRestrictedContainers).
+			WithArgs(mockContainer.UsergroupId).
+			WillReturnRows(mockContainerRows(mockContainer))
+
+		containers, err := containerRepo.GetContainersByGroupId(mockContainer.UsergroupId, ContainerFilter{IncludeArchived: true})
+
+		require.Nil(t, err)
+		require.Len(t, containers, 1)
+		require.False(t, containers[0].IsActive)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("when listing for a user, Then pinned containers and the user's order come first", func(t *testing.T) {
+		mockContainer := mockContainerObj()
+		mock.ExpectQuery(sqlGetContainersByGroupIdForUser+" AND "+visibleToSql("$2")+sqlExcludeArchivedContainers+sqlOrderContainersByUserPosition).
+			WithArgs(mockContainer.UsergroupId, 5).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "type", "is_active", "activity_level", "usergroup_id", "visibility", "is_pinned"}).
+				AddRow(mockContainer.Id, mockContainer.Name, mockContainer.Description, mockContainer.Type, true, 0, mockContainer.UsergroupId, model.VisibilityGroup, true))
+
+		containers, err := containerRepo.GetContainersByGroupId(mockContainer.UsergroupId, ContainerFilter{UserId: 5})
+
+		require.NoError(t, err)
+		require.True(t, containers[0].IsPinned)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestContainerRepo_CanAccessContainer(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+	containerRepo := NewContainerRepository(db)
+	query := `SELECT ` + visibleToSql("$2") + ` FROM public.taskcontainer tc WHERE tc.id = $1`
+
+	t.Run("when the container is restricted to other members, Then access is denied", func(t *testing.T) {
+		mock.ExpectQuery(query).WithArgs("party", 3).WillReturnRows(sqlmock.NewRows([]string{"visible"}).AddRow(false))
+
+		ok, err := containerRepo.CanAccessContainer("party", 3)
+
+		require.NoError(t, err)
+		require.False(t, ok)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("when the container does not exist, Then access is granted so callers answer not found", func(t *testing.T) {
+		mock.ExpectQuery(query).WithArgs("missing", 3).WillReturnRows(sqlmock.NewRows([]string{"visible"}))
+
+		ok, err := containerRepo.CanAccessContainer("missing", 3)
+
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestContainerRepo_CreateContainer(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+	containerRepo := NewContainerRepository(db)
+	chat, err := chatModel.NewContainerChat(7, "party")
+	require.NoError(t, err)
+	chat.Id = "chat-1"
+	admin, err := chatModel.NewChatParticipant("chat-1", 1, chatModel.RoleAdmin)
+	require.NoError(t, err)
+
+	t.Run("when creating a restricted container, Then the creator membership and chat are stored in the same transaction", func(t *testing.T) {
+		c := model.TaskContainer{Id: "party", Name: "Party", Type: model.TypeNormal, IsActive: true, UsergroupId: 7, Visibility: model.VisibilityMembers}
+		mock.ExpectBegin()
+		mock.ExpectExec(sqlCreateContainer).WithArgs("party", "Party", "", true, 0, model.TypeNormal, 7, model.VisibilityMembers).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(sqlAddContainerMember).WithArgs("party", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(sqlCreateContainerChat).WithArgs("chat-1", chatModel.ChatTypeContainer, chat.UserGroupId, chat.ContainerId, chat.CreatedAt).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(sqlCreateChatParticipant).WithArgs("chat-1", 1, admin.JoinedAt, chatModel.RoleAdmin, chatModel.StatusActive).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := containerRepo.CreateContainer(c, 1, *chat, []chatModel.ChatParticipant{*admin})
+
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("when the chat cannot be stored, Then the container is rolled back", func(t *testing.T) {
+		c := model.TaskContainer{Id: "grocery", Name: "Grocery", Type: model.TypeGrocery, IsActive: true, UsergroupId: 7, Visibility: model.VisibilityGroup}
+		mock.ExpectBegin()
+		mock.ExpectExec(sqlCreateContainer).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(sqlCreateContainerChat).WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		err := containerRepo.CreateContainer(c, 1, *chat, []chatModel.ChatParticipant{*admin})
+
+		require.Error(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestContainerRepo_ContainerMembers(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+	containerRepo := NewContainerRepository(db)
+
+	t.Run("when adding a member, Then the user joins the container chat in the same transaction", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(sqlAddContainerMember).WithArgs("party", 3).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(sqlAddContainerChatParticipant).WithArgs("party", 3, chatModel.RoleMember, chatModel.StatusActive).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := containerRepo.AddContainerMember("party", 3)
+
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("when removing a member, Then the user leaves the container chat in the same transaction", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(sqlRemoveContainerMember).WithArgs("party", 3).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(sqlRemoveContainerChatParticipant).WithArgs("party", 3).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := containerRepo.RemoveContainerMember("party", 3)
+
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("when removing a user who is not a member, Then return sql.ErrNoRows and leave the chat untouched", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(sqlRemoveContainerMember).WithArgs("party", 4).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := containerRepo.RemoveContainerMember("party", 4)
+
+		require.ErrorIs(t, err, sql.ErrNoRows)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestContainerRepo_ReplaceContainerOrders(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+	containerRepo := NewContainerRepository(db)
+
+	t.Run("when order is replaced, Then the user's previous order in the group is removed first", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(sqlDeleteContainerOrders).WithArgs(5, 1).WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(sqlCreateContainerOrder).WithArgs(5, "chores", 0, true).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := containerRepo.ReplaceContainerOrders(5, 1, []model.ContainerOrder{{ContainerId: "chores", Position: 0, IsPinned: true}})
+
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestContainerRepo_RefreshActivityLevels(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+	containerRepo := NewContainerRepository(db)
+
+	t.Run("when refreshed, Then count events since the window start with the model weights", func(t *testing.T) {
+		since := time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+		mock.ExpectExec(sqlRefreshActivityLevels).
+			WithArgs(since, model.ActivityWeightCreated, model.ActivityWeightCompleted, model.ActivityWeightUpdated).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		changed, err := containerRepo.RefreshActivityLevels(since)
+
+		require.NoError(t, err)
+		require.Equal(t, int64(2), changed)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
 }
 
 func mockContainerObj() model.TaskContainer {
 	return model.TaskContainer{
-		Id:          uuid.New().String(),
-		Name:        "testuser",
-		Description: "testdesc",
-		IsActive:    true,
-		UsergroupId: 1,
+		Id:             uuid.New().String(),
+		Name:           "testuser",
+		Description:    "testdesc",
+		Type:           model.TypeGrocery,
+		IsActive:       true,
+		Activity_level: 7,
+		UsergroupId:    1,
+		Visibility:     model.VisibilityGroup,
 	}
 }
 func mockContainerRows(c model.TaskContainer) *sqlmock.Rows {
-	return sqlmock.NewRows([]string{"id", "name", "description", "is_active", "usergroup_id"}).
-		AddRow(c.Id, c.Name, c.Description, c.IsActive, c.UsergroupId)
+	return sqlmock.NewRows([]string{"id", "name", "description", "type", "is_active", "activity_level", "usergroup_id", "visibility"}).
+		AddRow(c.Id, c.Name, c.Description, c.Type, c.IsActive, c.Activity_level, c.UsergroupId, c.Visibility)
+}
+
+func TestContainerRepo_DuplicateContainer(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+	containerRepo := NewContainerRepository(db)
+	duplicate := model.TaskContainer{Id: "copy", Name: "Grocery", Type: model.TypeGrocery, IsActive: true, UsergroupId: 2}
+	options := model.DuplicateOptions{ResetCompletion: true, DateOffsetDays: 7}
+	now := time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)
+	chat, err := chatModel.NewContainerChat(2, "copy")
+	require.NoError(t, err)
+	chat.Id = "chat-1"
+	admin, err := chatModel.NewChatParticipant("chat-1", 5, chatModel.RoleAdmin)
+	require.NoError(t, err)
+	participants := []chatModel.ChatParticipant{*admin}
+
+	t.Run("when source has sections and tasks, Then they are copied into the duplicate", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(sqlCreateContainer).WithArgs("copy", "Grocery", "", true, 0, model.TypeGrocery, 2, "").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(sqlCreateContainerChat).WithArgs("chat-1", chatModel.ChatTypeContainer, 2, "copy", chat.CreatedAt).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(sqlCreateChatParticipant).WithArgs("chat-1", 5, admin.JoinedAt, chatModel.RoleAdmin, chatModel.StatusActive).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(sqlGetContainerSectionIds).WithArgs("grocery").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("s-1"))
+		mock.ExpectQuery(sqlDuplicateSection).WithArgs("s-1", "copy").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("s-2"))
+		mock.ExpectQuery(sqlGetContainerTaskLinks).WithArgs("grocery").
+			WillReturnRows(sqlmock.NewRows([]string{"task_id", "section_id", "position"}).AddRow("t-1", "s-1", 0).AddRow("t-2", nil, 3))
+		mock.ExpectQuery(sqlDuplicateTask).WithArgs("t-1", now, 7, true, 5, 2, "grocery").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("t-3"))
+		mock.ExpectExec(sqlLinkDuplicatedTask).WithArgs("copy", "t-3", "s-2", 0).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(sqlDuplicateTask).WithArgs("t-2", now, 7, true, 5, 2, "grocery").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("t-4"))
+		mock.ExpectExec(sqlLinkDuplicatedTask).WithArgs("copy", "t-4", nil, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		copied, err := containerRepo.DuplicateContainer("grocery", duplicate, options, 5, now, *chat, participants)
+
+		require.NoError(t, err)
+		require.Equal(t, 2, copied)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("when duplicating a restricted container, Then only members of the target group are copied into it and its chat", func(t *testing.T) {
+		restricted := duplicate
+		restricted.Visibility = model.VisibilityMembers
+		mock.ExpectBegin()
+		mock.ExpectExec(sqlCreateContainer).WithArgs("copy", "Grocery", "", true, 0, model.TypeGrocery, 2, model.VisibilityMembers).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(sqlDuplicateContainerMembers).WithArgs("grocery", "copy", 2).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(sqlAddContainerMember).WithArgs("copy", 5).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(sqlCreateContainerChat).WithArgs("chat-1", chatModel.ChatTypeContainer, 2, "copy", chat.CreatedAt).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(sqlCreateChatParticipant).WithArgs("chat-1", 5, admin.JoinedAt, chatModel.RoleAdmin, chatModel.StatusActive).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(sqlDuplicateContainerChatParticipants).WithArgs("copy", "chat-1", chatModel.RoleMember, chatModel.StatusActive).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectQuery(sqlGetContainerSectionIds).WithArgs("grocery").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(sqlGetContainerTaskLinks).WithArgs("grocery").WillReturnRows(sqlmock.NewRows([]string{"task_id", "section_id", "position"}))
+		mock.ExpectCommit()
+
+		copied, err := containerRepo.DuplicateContainer("grocery", restricted, options, 5, now, *chat, participants)
+
+		require.NoError(t, err)
+		require.Equal(t, 0, copied)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("when copying a task fails, Then nothing is created", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(sqlCreateContainer).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(sqlCreateContainerChat).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(sqlCreateChatParticipant).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(sqlGetContainerSectionIds).WithArgs("grocery").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(sqlGetContainerTaskLinks).WithArgs("grocery").
+			WillReturnRows(sqlmock.NewRows([]string{"task_id", "section_id", "position"}).AddRow("t-1", nil, 0))
+		mock.ExpectQuery(sqlDuplicateTask).WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		_, err := containerRepo.DuplicateContainer("grocery", duplicate, options, 5, now, *chat, participants)
+
+		require.ErrorIs(t, err, sql.ErrConnDone)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
 }
@@ -1,11 +1,78 @@
 package repository
 
 const (
-	sqlGetAllContainers       = `SELECT id,name,description,is_active,usergroup_id FROM public.taskcontainer`
-	sqlGetById                = `SELECT id,name,description,is_active,usergroup_id FROM public.taskcontainer WHERE id = $1`
-	sqlGetContainersByGroupId = `SELECT id,name,description,is_active,usergroup_id FROM public.taskcontainer WHERE usergroup_id = $1`
-	sqlCreateContainer        = `INSERT INTO public.taskcontainer(id, name, description, is_active, activity_level, type, usergroup_id)
-								VALUES ($1,$2,$3,$4,$5,$6,$7);`
+	sqlGetAllContainers              = `SELECT id,name,description,TRIM(type),is_active,COALESCE(activity_level, 0),usergroup_id,visibility FROM public.taskcontainer tc`
+	sqlGetById                       = `SELECT id,name,description,TRIM(type),is_active,COALESCE(activity_level, 0),usergroup_id,visibility FROM public.taskcontainer WHERE id = $1`
+	sqlGetContainersByGroupId        = `SELECT id,name,description,TRIM(type),is_active,COALESCE(activity_level, 0),usergroup_id,visibility FROM public.taskcontainer tc WHERE usergroup_id = $1`
+	sqlGetContainersByGroupIdForUser = `SELECT id,name,description,TRIM(type),is_active,COALESCE(activity_level, 0),usergroup_id,visibility,COALESCE(o.is_pinned, false)
+								FROM public.taskcontainer tc LEFT JOIN public.user_container_order o ON o.container_id = tc.id AND o.user_id = $2
+								WHERE usergroup_id = $1`
+	sqlCreateContainer = `INSERT INTO public.taskcontainer(id, name, description, is_active, activity_level, type, usergroup_id, visibility)
+								VALUES ($1,$2,$3,$4,$5,$6,$7,$8);`
+	sqlCreateContainerChat       = `INSERT INTO public.chat(id, type, usergroup_id, container_id, created_at) VALUES ($1, $2, $3, $4, $5)`
+	sqlCreateChatParticipant     = `INSERT INTO public.chat_participant(chat_id, user_id, joined_at, role, status) VALUES ($1, $2, $3, $4, $5)`
+	sqlUpdateContainer           = `UPDATE public.taskcontainer SET name=$2, description=$3, type=$4, visibility=$5 WHERE id=$1`
+	sqlUpdateContainerActive     = `UPDATE public.taskcontainer SET is_active=$2 WHERE id=$1`
+	sqlExcludeArchivedContainers = ` AND is_active IS NOT false`
+	sqlOrderContainersByActivity = ` ORDER BY activity_level DESC NULLS LAST, name`
+	// Containers the user has not placed keep the default order after the placed ones.
+	sqlOrderContainersByUserPosition   = ` ORDER BY o.is_pinned IS TRUE DESC, o.position NULLS LAST`
+	sqlOrderPinnedContainersByActivity = ` ORDER BY o.is_pinned IS TRUE DESC, activity_level DESC NULLS LAST, name`
+	sqlExcludeRestrictedContainers     = ` AND visibility <> 'members'`
+	sqlGetContainerMembers             = `SELECT user_id FROM public.taskcontainer_member WHERE container_id = $1 ORDER BY user_id`
+	sqlAddContainerMember              = `INSERT INTO public.taskcontainer_member(container_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	sqlRemoveContainerMember           = `DELETE FROM public.taskcontainer_member WHERE container_id = $1 AND user_id = $2`
+	// Only members of the duplicate's user group $3 are copied.
+	sqlDuplicateContainerMembers = `INSERT INTO public.taskcontainer_member(container_id, user_id)
+								SELECT $2, m.user_id FROM public.taskcontainer_member m
+								INNER JOIN public.usergroup_user ug ON ug.user_id = m.user_id AND ug.usergroup_id = $3
+								WHERE m.container_id = $1 ON CONFLICT DO NOTHING`
+	// The chat $2 of a duplicated restricted container starts with its copied members: $3 and $4 are their role and status.
+	sqlDuplicateContainerChatParticipants = `INSERT INTO public.chat_participant(chat_id, user_id, role, status)
+								SELECT $2, m.user_id, $3, $4 FROM public.taskcontainer_member m WHERE m.container_id = $1
+								AND NOT EXISTS (SELECT 1 FROM public.chat_participant p WHERE p.chat_id = $2 AND p.user_id = m.user_id)`
+	// The container chat follows its members: $3 and $4 are the role and status of a joining participant.
+	sqlAddContainerChatParticipant = `INSERT INTO public.chat_participant(chat_id, user_id, role, status)
+								SELECT c.id, $2, $3, $4 FROM public.chat c WHERE c.container_id = $1
+								AND NOT EXISTS (SELECT 1 FROM public.chat_participant p WHERE p.chat_id = c.id AND p.user_id = $2)`
+	sqlRemoveContainerChatParticipant = `DELETE FROM public.chat_participant p USING public.chat c
+								WHERE p.chat_id = c.id AND c.container_id = $1 AND p.user_id = $2`
+	sqlDeleteContainerOrders = `DELETE FROM public.user_container_order o USING public.taskcontainer c
+								WHERE o.container_id = c.id AND o.user_id = $1 AND c.usergroup_id = $2`
+	sqlCreateContainerOrder = `INSERT INTO public.user_container_order(user_id, container_id, position, is_pinned) VALUES ($1, $2, $3, $4)`
+	// $1 is the start of the activity window, $2..$4 the weights of created, completed and updated events.
+	sqlRefreshActivityLevels = `UPDATE public.taskcontainer tc SET activity_level = s.level
+		FROM (SELECT c.id,
+				(SELECT COUNT(*) FROM public.taskcontainer_task tct INNER JOIN public.task t ON t.id = tct.task_id
+					WHERE tct.taskcontainer_id = c.id AND t.created_at >= $1) * $2
+				+ (SELECT COUNT(*) FROM public.taskcontainer_task tct INNER JOIN public.task t ON t.id = tct.task_id
+					WHERE tct.taskcontainer_id = c.id AND t.completed_at >= $1) * $3
+				+ (SELECT COUNT(*) FROM public.taskcontainer_task tct INNER JOIN public.task_activity a ON a.task_id = tct.task_id
+					WHERE tct.taskcontainer_id = c.id AND a.created_at >= $1) * $4 AS level
+			FROM public.taskcontainer c) s
+		WHERE s.id = tc.id AND tc.activity_level IS DISTINCT FROM s.level`
+	sqlGetContainerSectionIds = `SELECT id FROM public.container_section WHERE container_id = $1`
+	sqlDuplicateSection       = `INSERT INTO public.container_section(id, container_id, name, position, status)
+								SELECT public.uuid_generate_v7(), $2, name, position, status FROM public.container_section WHERE id = $1
+								RETURNING id`
+	sqlGetContainerTaskLinks = `SELECT tct.task_id, tct.section_id, tct.position FROM public.taskcontainer_task tct
+								INNER JOIN public.task t ON t.id = tct.task_id
+								WHERE tct.taskcontainer_id = $1 AND t.archived_at IS NULL`
+	// $2 is the creation time, $3 the date offset in days, $4 whether completion is reset, $5 the user copying,
+	// $6 the user group of the duplicate and $7 the source container.
+	// Target dates move in the task's own time zone so the local time survives DST changes.
+	// Recurring copies start a series of their own. Copies into another group lose their assignee.
+	sqlDuplicateTask = `INSERT INTO public.task(id, name, description, type, created_at, updated_at, target_date, due_date, timezone, priority, category,
+									quantity, unit, aisle, is_completed, is_important, completed_at, created_by, assignee_id, recurrence, series_id, points, frequency, target_count)
+								SELECT n.id, name, description, type, $2, $2,
+									((target_date AT TIME ZONE timezone) + make_interval(days => $3)) AT TIME ZONE timezone,
+									due_date + $3, timezone, priority, category, quantity, unit, aisle,
+									is_completed AND NOT $4, is_important, CASE WHEN $4 THEN NULL ELSE completed_at END, $5,
+									CASE WHEN (SELECT usergroup_id FROM public.taskcontainer WHERE id = $7) = $6 THEN assignee_id END, recurrence,
+									CASE WHEN recurrence IS NOT NULL THEN n.id END, points, frequency, target_count
+								FROM public.task, (SELECT public.uuid_generate_v7() AS id) n WHERE task.id = $1
+								RETURNING id`
+	sqlLinkDuplicatedTask           = `INSERT INTO public.taskcontainer_task(taskcontainer_id, task_id, section_id, position) VALUES ($1, $2, $3, $4)`
 	sqlDeleteContainer              = `DELETE FROM public.taskcontainer WHERE id = $1;`
 	sqlDeleteContainerByUsergroupId = `DELETE FROM taskcontainer WHERE usergroup_id = $1;`
 )
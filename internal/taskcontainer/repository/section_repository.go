@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/happYness-Project/taskManagementGolang/internal/taskcontainer/model"
+)
+
+type SectionRepository interface {
+	GetSections(containerId string) ([]model.ContainerSection, error)
+	GetSection(containerId string, sectionId string) (*model.ContainerSection, error)
+	CreateSection(section model.ContainerSection) (model.ContainerSection, error)
+	UpdateSection(section model.ContainerSection) error
+	UpdateSectionPositions(containerId string, sections []model.ContainerSection) error
+	DeleteSection(containerId string, sectionId string) error
+}
+
+type SectionRepo struct {
+	DB *sql.DB
+}
+
+func NewSectionRepository(db *sql.DB) *SectionRepo {
+	return &SectionRepo{DB: db}
+}
+
+func (m *SectionRepo) GetSections(containerId string) ([]model.ContainerSection, error) {
+	rows, err := m.DB.Query(sqlGetSections, containerId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sections := []model.ContainerSection{}
+	for rows.Next() {
+		section, err := scanRowsIntoSection(rows)
+		if err != nil {
+			return nil, err
+		}
+		sections = append(sections, *section)
+	}
+	return sections, nil
+}
+
+// GetSection returns nil when the container has no such section.
+func (m *SectionRepo) GetSection(containerId string, sectionId string) (*model.ContainerSection, error) {
+	rows, err := m.DB.Query(sqlGetSection, containerId, sectionId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var section *model.ContainerSection
+	for rows.Next() {
+		section, err = scanRowsIntoSection(rows)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return section, nil
+}
+
+// CreateSection appends the section after the last section of its container.
+func (m *SectionRepo) CreateSection(section model.ContainerSection) (model.ContainerSection, error) {
+	err := m.DB.QueryRow(sqlCreateSection, section.Id, section.ContainerId, section.Name, nullString(section.Status), section.CreatedAt).Scan(&section.Position)
+	if err != nil {
+		return section, fmt.Errorf("unable to insert into container_section table : %w", err)
+	}
+	return section, nil
+}
+
+func (m *SectionRepo) UpdateSection(section model.ContainerSection) error {
+	_, err := m.DB.Exec(sqlUpdateSection, section.ContainerId, section.Id, section.Name, nullString(section.Status))
+	if err != nil {
+		return fmt.Errorf("unable to update container_section table : %w", err)
+	}
+	return nil
+}
+
+func (m *SectionRepo) UpdateSectionPositions(containerId string, sections []model.ContainerSection) error {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	for _, section := range sections {
+		if _, err = tx.Exec(sqlUpdateSectionPosition, containerId, section.Id, section.Position); err != nil {
+			return fmt.Errorf("unable to update container_section position : %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// DeleteSection removes the section; its tasks stay in the container without a section.
+func (m *SectionRepo) DeleteSection(containerId string, sectionId string) error {
+	result, err := m.DB.Exec(sqlDeleteSection, containerId, sectionId)
+	if err != nil {
+		return fmt.Errorf("unable to remove container section : %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func scanRowsIntoSection(rows *sql.Rows) (*model.ContainerSection, error) {
+	section := new(model.ContainerSection)
+	err := rows.Scan(
+		&section.Id,
+		&section.ContainerId,
+		&section.Name,
+		&section.Position,
+		&section.Status,
+		&section.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return section, nil
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,17 @@
+package repository
+
+const (
+	sqlGetSections = `SELECT id, container_id, name, position, COALESCE(status, ''), created_at
+						FROM public.container_section
+						WHERE container_id = $1
+						ORDER BY position`
+	sqlGetSection = `SELECT id, container_id, name, position, COALESCE(status, ''), created_at
+						FROM public.container_section
+						WHERE container_id = $1 AND id = $2`
+	sqlCreateSection = `INSERT INTO public.container_section(id, container_id, name, position, status, created_at)
+						VALUES ($1, $2, $3, (SELECT COALESCE(MAX(position) + 1, 0) FROM public.container_section WHERE container_id = $2), $4, $5)
+						RETURNING position`
+	sqlUpdateSection         = `UPDATE public.container_section SET name = $3, status = $4 WHERE container_id = $1 AND id = $2`
+	sqlUpdateSectionPosition = `UPDATE public.container_section SET position = $3 WHERE container_id = $1 AND id = $2`
+	sqlDeleteSection         = `DELETE FROM public.container_section WHERE container_id = $1 AND id = $2`
+)
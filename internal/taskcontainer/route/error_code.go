@@ -9,6 +9,20 @@ const (
 
 	TaskContainerGetNotFound = prefix + "get_not_found"
 	DeleteTaskContainerError = prefix + "delete_server_error"
+	TaskContainerUpdateError = prefix + "update_server_error"
+	TaskContainerCreateError = prefix + "create_server_error"
+
+	TaskContainerUserNotFound   = prefix + "user_not_found"
+	TaskContainerGroupNotFound  = prefix + "group_not_found"
+	TaskContainerNotGroupMember = prefix + "not_group_member"
+	TaskContainerNotGroupAdmin  = prefix + "not_group_admin"
+	TaskContainerDuplicateError = prefix + "duplicate_server_error"
+	TaskContainerOrderForbidden = prefix + "order_forbidden"
+	TaskContainerMemberError    = prefix + "member_server_error"
+	TaskContainerMemberNotFound = prefix + "member_not_found"
+
+	TaskContainerSectionNotFound = prefix + "section_not_found"
+	TaskContainerSectionError    = prefix + "section_server_error"
 	// UserGroupGetRateLimitedExceeded = prefix + "get_rate_limited_exceeded"
 	// UserNotFound                    = prefix + "user_get_not_found"
 	// UserGroupCreationFailure        = prefix + "create_error"
@@ -1,15 +1,24 @@
 package route
 
 import (
+	"database/sql"
+	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth"
 	"github.com/google/uuid"
+	chatModel "github.com/happYness-Project/taskManagementGolang/internal/chat/model"
 	"github.com/happYness-Project/taskManagementGolang/internal/taskcontainer/model"
 	container "github.com/happYness-Project/taskManagementGolang/internal/taskcontainer/repository"
+	userModel "github.com/happYness-Project/taskManagementGolang/internal/user/model"
 	user "github.com/happYness-Project/taskManagementGolang/internal/user/repository"
+	usergroup "github.com/happYness-Project/taskManagementGolang/internal/usergroup/repository"
 	"github.com/happYness-Project/taskManagementGolang/pkg/constants"
+	"github.com/happYness-Project/taskManagementGolang/pkg/errors"
 	"github.com/happYness-Project/taskManagementGolang/pkg/loggers"
 	"github.com/happYness-Project/taskManagementGolang/pkg/response"
 )
@@ -18,22 +27,41 @@ type Handler struct {
 	logger        *loggers.AppLogger
 	containerRepo container.ContainerRepository
 	userRepo      user.UserRepository
+	sectionRepo   container.SectionRepository
+	groupRepo     usergroup.UserGroupRepository
 }
 
-func NewHandler(logger *loggers.AppLogger, repo container.ContainerRepository, userRepo user.UserRepository) *Handler {
-	return &Handler{logger: logger, containerRepo: repo, userRepo: userRepo}
+func NewHandler(logger *loggers.AppLogger, repo container.ContainerRepository, userRepo user.UserRepository, sectionRepo container.SectionRepository, groupRepo usergroup.UserGroupRepository) *Handler {
+	return &Handler{logger: logger, containerRepo: repo, userRepo: userRepo, sectionRepo: sectionRepo, groupRepo: groupRepo}
 }
 func (h *Handler) RegisterRoutes(router chi.Router) {
 	router.Route("/api/task-containers", func(r chi.Router) {
 		r.Post("/", h.handleCreateTaskContainer)
 		r.Get("/", h.handleGetTaskContainers)
-		r.Get("/{containerID}", h.handleGetTaskContainerById)
-		r.Delete("/{containerID}", h.handleDeleteTaskContainer)
+		r.Group(func(r chi.Router) {
+			r.Use(h.requireContainerAccess)
+			r.Get("/{containerID}", h.handleGetTaskContainerById)
+			r.Put("/{containerID}", h.handleUpdateTaskContainer)
+			r.Patch("/{containerID}", h.handlePatchTaskContainer)
+			r.Delete("/{containerID}", h.handleDeleteTaskContainer)
+			r.Post("/{containerID}/archive", h.handleArchiveTaskContainer)
+			r.Post("/{containerID}/unarchive", h.handleUnarchiveTaskContainer)
+			r.Post("/{containerID}/duplicate", h.handleDuplicateTaskContainer)
+			r.Get("/{containerID}/sections", h.handleGetSections)
+			r.Post("/{containerID}/sections", h.handleCreateSection)
+			r.Put("/{containerID}/sections/order", h.handleReorderSections)
+			r.Put("/{containerID}/sections/{sectionID}", h.handleUpdateSection)
+			r.Delete("/{containerID}/sections/{sectionID}", h.handleDeleteSection)
+			r.Get("/{containerID}/members", h.handleGetContainerMembers)
+			r.Post("/{containerID}/members", h.handleAddContainerMember)
+			r.Delete("/{containerID}/members/{userID}", h.handleRemoveContainerMember)
+		})
 	})
 	router.Get("/api/user-groups/{usergroupID}/task-containers", h.handleGetTaskContainersByGroupId)
+	router.Put("/api/users/{userID}/user-groups/{groupID}/container-order", h.handleUpdateContainerOrder)
 }
 func (h *Handler) handleGetTaskContainers(w http.ResponseWriter, r *http.Request) {
-	containers, err := h.containerRepo.AllTaskContainers()
+	containers, err := h.containerRepo.AllTaskContainers(h.currentUserId(r))
 	if err != nil {
 		h.logger.Error().Err(err).Str("ErrorCode", TaskContainerGetError).Msg(err.Error())
 		response.InternalServerError(w, "Error occurred during getting all task containers.")
@@ -70,7 +98,14 @@ func (h *Handler) handleGetTaskContainersByGroupId(w http.ResponseWriter, r *htt
 		return
 	}
 
-	containers, err := h.containerRepo.GetContainersByGroupId(groupId)
+	filter := container.ContainerFilter{
+		IncludeArchived: r.URL.Query().Get("include_archived") == "true",
+		SortByActivity:  r.URL.Query().Get("sort") == "activity",
+	}
+	if caller := h.currentUser(r); caller != nil {
+		filter.UserId = caller.Id
+	}
+	containers, err := h.containerRepo.GetContainersByGroupId(groupId, filter)
 	if err != nil {
 		h.logger.Error().Err(err).Str("ErrorCode", TaskContainerGetNotFound).Msg(err.Error())
 		response.NotFound(w, TaskContainerGetNotFound, "Error occurred during retrieving containers by group id")
@@ -88,19 +123,470 @@ func (h *Handler) handleCreateTaskContainer(w http.ResponseWriter, r *http.Reque
 
 	container := model.TaskContainer{
 		Id:             uuid.New().String(),
-		Name:           createDto.Name,
-		Description:    createDto.Description,
-		Type:           createDto.Type,
 		IsActive:       true,
 		Activity_level: 0,
 		UsergroupId:    createDto.UserGroupId,
 	}
-	_ = h.containerRepo.CreateContainer(container)
+	if err := container.Rename(createDto.Name); err != nil {
+		h.domainError(w, err)
+		return
+	}
+	if err := container.Describe(createDto.Description); err != nil {
+		h.domainError(w, err)
+		return
+	}
+	if err := container.ChangeType(createDto.Type); err != nil {
+		h.domainError(w, err)
+		return
+	}
+	if err := container.SetVisibility(createDto.Visibility); err != nil {
+		h.domainError(w, err)
+		return
+	}
+
+	group, err := h.groupRepo.GetById(createDto.UserGroupId)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskContainerServerError).Msg("Error occurred during retrieving user group")
+		response.InternalServerError(w, "Failed to get user group")
+		return
+	}
+	if group == nil || group.GroupId == 0 {
+		h.logger.Error().Str("ErrorCode", TaskContainerGroupNotFound).Msg(fmt.Sprintf("group does not exist. group Id: %d", createDto.UserGroupId))
+		response.NotFound(w, TaskContainerGroupNotFound, "group does not exist")
+		return
+	}
+	caller := h.currentUser(r)
+	if caller == nil {
+		h.logger.Error().Str("ErrorCode", TaskContainerUserNotFound).Msg("Not able to find user from token")
+		response.NotFound(w, TaskContainerUserNotFound, "Not able to find a user")
+		return
+	}
+	if !h.isGroupMember(w, group.GroupId, caller.Id) {
+		return
+	}
+
+	chat, err := chatModel.NewContainerChat(group.GroupId, container.Id)
+	if err != nil {
+		h.domainError(w, err)
+		return
+	}
+	chat.Id = uuid.New().String()
+	participants, err := h.containerChatParticipants(container, chat.Id, caller.Id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskContainerServerError).Msg("Error occurred during retrieving container chat participants")
+		response.InternalServerError(w, "Failed to get group members")
+		return
+	}
+	if err = h.containerRepo.CreateContainer(container, caller.Id, *chat, participants); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskContainerCreateError).Msg("Error occurred during CreateContainer")
+		response.InternalServerError(w, "Failed to create container")
+		return
+	}
 	response.WriteJsonWithEncode(w, http.StatusCreated, container.Id)
 }
+
+// containerChatParticipants lists the group's members for the chat of a new
+// container, with the creator as admin. A restricted container starts with its
+// creator only, so its chat does too.
+func (h *Handler) containerChatParticipants(container model.TaskContainer, chatId string, creatorId int) ([]chatModel.ChatParticipant, error) {
+	userIds := []int{creatorId}
+	if !container.IsRestricted() {
+		users, err := h.userRepo.GetUsersByGroupId(container.UsergroupId)
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range users {
+			if u.Id != creatorId {
+				userIds = append(userIds, u.Id)
+			}
+		}
+	}
+	participants := make([]chatModel.ChatParticipant, 0, len(userIds))
+	for _, userId := range userIds {
+		role := chatModel.RoleMember
+		if userId == creatorId {
+			role = chatModel.RoleAdmin
+		}
+		participant, err := chatModel.NewChatParticipant(chatId, userId, role)
+		if err != nil {
+			return nil, err
+		}
+		participants = append(participants, *participant)
+	}
+	return participants, nil
+}
+func (h *Handler) handleUpdateTaskContainer(w http.ResponseWriter, r *http.Request) {
+	var updateDto UpdateContainerDto
+	if err := response.ParseJson(r, &updateDto); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.RequestBodyError).Msg("Error occurred during parsing json of UpdateContainerDto")
+		response.InvalidJsonBody(w, "Error occurred during parsing json of UpdateContainerDto")
+		return
+	}
+	changes := PatchContainerDto{Name: &updateDto.Name, Description: &updateDto.Description, Type: &updateDto.Type}
+	// Clients unaware of visibility must not share a restricted container by accident.
+	if updateDto.Visibility != "" {
+		changes.Visibility = &updateDto.Visibility
+	}
+	h.updateTaskContainer(w, r, changes)
+}
+
+// handlePatchTaskContainer changes only the fields present in the body.
+func (h *Handler) handlePatchTaskContainer(w http.ResponseWriter, r *http.Request) {
+	var patchDto PatchContainerDto
+	if err := response.ParseJson(r, &patchDto); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.RequestBodyError).Msg("Error occurred during parsing json of PatchContainerDto")
+		response.InvalidJsonBody(w, "Error occurred during parsing json of PatchContainerDto")
+		return
+	}
+	h.updateTaskContainer(w, r, patchDto)
+}
+
+func (h *Handler) updateTaskContainer(w http.ResponseWriter, r *http.Request, changes PatchContainerDto) {
+	container, caller, ok := h.findContainerForMember(w, r)
+	if !ok {
+		return
+	}
+	// Restricting a container hides it from the rest of the group, so only
+	// admins may change who can see it.
+	if changes.Visibility != nil && *changes.Visibility != container.Visibility &&
+		!h.requireGroupAdmin(w, container.UsergroupId, caller.Id, "only group admins can change container visibility") {
+		return
+	}
+	if changes.Name != nil {
+		if err := container.Rename(*changes.Name); err != nil {
+			h.domainError(w, err)
+			return
+		}
+	}
+	if changes.Description != nil {
+		if err := container.Describe(*changes.Description); err != nil {
+			h.domainError(w, err)
+			return
+		}
+	}
+	if changes.Type != nil {
+		if err := container.ChangeType(*changes.Type); err != nil {
+			h.domainError(w, err)
+			return
+		}
+	}
+	if changes.Visibility != nil {
+		wasRestricted := container.IsRestricted()
+		if err := container.SetVisibility(*changes.Visibility); err != nil {
+			h.domainError(w, err)
+			return
+		}
+		// Restricting a container keeps it visible to the user restricting it.
+		if container.IsRestricted() && !wasRestricted && !h.addCallerAsMember(w, container.Id, caller.Id) {
+			return
+		}
+	}
+	if err := h.containerRepo.UpdateContainer(*container); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskContainerUpdateError).Msg("Error occurred during UpdateContainer")
+		response.InternalServerError(w, "Error occurred during update container")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusOK, container)
+}
+
+func (h *Handler) handleArchiveTaskContainer(w http.ResponseWriter, r *http.Request) {
+	h.setContainerArchived(w, r, true)
+}
+
+func (h *Handler) handleUnarchiveTaskContainer(w http.ResponseWriter, r *http.Request) {
+	h.setContainerArchived(w, r, false)
+}
+
+// setContainerArchived is limited to admins and owners of the group.
+func (h *Handler) setContainerArchived(w http.ResponseWriter, r *http.Request, archived bool) {
+	container, ok := h.findContainer(w, r)
+	if !ok {
+		return
+	}
+	caller := h.currentUser(r)
+	if caller == nil {
+		h.logger.Error().Str("ErrorCode", TaskContainerUserNotFound).Msg("Not able to find user from token")
+		response.NotFound(w, TaskContainerUserNotFound, "Not able to find a user")
+		return
+	}
+	if !h.requireGroupAdmin(w, container.UsergroupId, caller.Id, "only group admins can archive containers") {
+		return
+	}
+	if archived {
+		container.Archive()
+	} else {
+		container.Unarchive()
+	}
+	if err := h.containerRepo.SetContainerActive(container.Id, container.IsActive); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskContainerUpdateError).Msg("Error occurred during SetContainerActive")
+		response.InternalServerError(w, "Error occurred during archiving container")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusOK, container)
+}
+
+// handleDuplicateTaskContainer copies the container with its sections and tasks
+// into the same or another user group. The caller must belong to both groups.
+func (h *Handler) handleDuplicateTaskContainer(w http.ResponseWriter, r *http.Request) {
+	var duplicateDto DuplicateContainerDto
+	if err := response.ParseJson(r, &duplicateDto); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.RequestBodyError).Msg("Error occurred during parsing json of DuplicateContainerDto")
+		response.InvalidJsonBody(w, "Error occurred during parsing json of DuplicateContainerDto")
+		return
+	}
+	source, ok := h.findContainer(w, r)
+	if !ok {
+		return
+	}
+	caller := h.currentUser(r)
+	if caller == nil {
+		h.logger.Error().Str("ErrorCode", TaskContainerUserNotFound).Msg("Not able to find user from token")
+		response.NotFound(w, TaskContainerUserNotFound, "Not able to find a user")
+		return
+	}
+	groupId := source.UsergroupId
+	if duplicateDto.UserGroupId != nil {
+		groupId = *duplicateDto.UserGroupId
+	}
+	if !h.isGroupMember(w, source.UsergroupId, caller.Id) || (groupId != source.UsergroupId && !h.isGroupMember(w, groupId, caller.Id)) {
+		return
+	}
+
+	options, err := model.NewDuplicateOptions(duplicateDto.ResetCompletion, duplicateDto.DateOffsetDays)
+	if err != nil {
+		h.domainError(w, err)
+		return
+	}
+	duplicate, err := source.Duplicate(uuid.New().String(), groupId, duplicateDto.Name)
+	if err != nil {
+		h.domainError(w, err)
+		return
+	}
+	chat, err := chatModel.NewContainerChat(groupId, duplicate.Id)
+	if err != nil {
+		h.domainError(w, err)
+		return
+	}
+	chat.Id = uuid.New().String()
+	participants, err := h.containerChatParticipants(*duplicate, chat.Id, caller.Id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskContainerServerError).Msg("Error occurred during retrieving container chat participants")
+		response.InternalServerError(w, "Failed to get group members")
+		return
+	}
+	if _, err = h.containerRepo.DuplicateContainer(source.Id, *duplicate, *options, caller.Id, time.Now().UTC(), *chat, participants); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskContainerDuplicateError).Msg("Error occurred during DuplicateContainer")
+		response.InternalServerError(w, "Failed to duplicate container")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusCreated, duplicate)
+}
+
+// handleUpdateContainerOrder replaces the caller's own pinning and order of the
+// group's containers. Containers left out of the body go back to the default order.
+func (h *Handler) handleUpdateContainerOrder(w http.ResponseWriter, r *http.Request) {
+	var orderDto ContainerOrderDto
+	if err := response.ParseJson(r, &orderDto); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.RequestBodyError).Msg("Error occurred during parsing json of ContainerOrderDto")
+		response.InvalidJsonBody(w, "Error occurred during parsing json of ContainerOrderDto")
+		return
+	}
+	groupId, err := strconv.Atoi(chi.URLParam(r, "groupID"))
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.InvalidParameter).Msg(err.Error())
+		response.ErrorResponse(w, http.StatusBadRequest, *(response.New(constants.InvalidParameter, "Invalid Parameter", "Invalid Group ID")))
+		return
+	}
+	caller := h.currentUser(r)
+	if caller == nil {
+		h.logger.Error().Str("ErrorCode", TaskContainerUserNotFound).Msg("Not able to find user from token")
+		response.NotFound(w, TaskContainerUserNotFound, "Not able to find a user")
+		return
+	}
+	if caller.UserId != chi.URLParam(r, "userID") {
+		h.logger.Error().Str("ErrorCode", TaskContainerOrderForbidden).Msg("user cannot change the container order of another user")
+		response.ErrorResponse(w, http.StatusForbidden, *response.New(TaskContainerOrderForbidden, errors.PermissionDenied, "users can only change their own container order"))
+		return
+	}
+	if !h.isGroupMember(w, groupId, caller.Id) {
+		return
+	}
+
+	containers, err := h.containerRepo.GetContainersByGroupId(groupId, container.ContainerFilter{IncludeArchived: true})
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskContainerGetError).Msg("Error occurred during GetContainersByGroupId")
+		response.InternalServerError(w, "Failed to get containers")
+		return
+	}
+	orders := make([]model.ContainerOrder, 0, len(orderDto.Containers))
+	for _, c := range orderDto.Containers {
+		orders = append(orders, model.ContainerOrder{ContainerId: c.Id, IsPinned: c.IsPinned})
+	}
+	orders, err = model.NumberContainerOrders(orders, containers)
+	if err != nil {
+		h.domainError(w, err)
+		return
+	}
+	if err = h.containerRepo.ReplaceContainerOrders(caller.Id, groupId, orders); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskContainerUpdateError).Msg("Error occurred during ReplaceContainerOrders")
+		response.InternalServerError(w, "Failed to update container order")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusOK, orders)
+}
+
+func (h *Handler) handleGetContainerMembers(w http.ResponseWriter, r *http.Request) {
+	container, caller, ok := h.findContainerForMember(w, r)
+	if !ok {
+		return
+	}
+	memberIds, ok := h.containerMembers(w, container)
+	if !ok || !h.requireContainerMember(w, container, memberIds, caller.Id) {
+		return
+	}
+	users, err := h.userRepo.GetUsersByGroupId(container.UsergroupId)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskContainerMemberError).Msg("Error occurred during GetUsersByGroupId")
+		response.InternalServerError(w, "Failed to get container members")
+		return
+	}
+	isMember := make(map[int]bool, len(memberIds))
+	for _, id := range memberIds {
+		isMember[id] = true
+	}
+	members := []*userModel.User{}
+	for _, u := range users {
+		if isMember[u.Id] {
+			members = append(members, u)
+		}
+	}
+	response.WriteJsonWithEncode(w, http.StatusOK, members)
+}
+
+// handleAddContainerMember adds a member of the container's user group to the
+// users who can see the container while it is restricted.
+func (h *Handler) handleAddContainerMember(w http.ResponseWriter, r *http.Request) {
+	var memberDto ContainerMemberDto
+	if err := response.ParseJson(r, &memberDto); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.RequestBodyError).Msg("Error occurred during parsing json of ContainerMemberDto")
+		response.InvalidJsonBody(w, "Error occurred during parsing json of ContainerMemberDto")
+		return
+	}
+	container, caller, ok := h.findContainerForMember(w, r)
+	if !ok {
+		return
+	}
+	memberIds, ok := h.containerMembers(w, container)
+	if !ok || !h.requireContainerMember(w, container, memberIds, caller.Id) {
+		return
+	}
+	member, err := h.userRepo.GetUserByUserId(memberDto.UserId)
+	if err != nil || member == nil || member.Id == 0 {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskContainerUserNotFound).Msg("Cannot find user to add to container")
+		response.NotFound(w, TaskContainerUserNotFound, "Not able to find a user")
+		return
+	}
+	inGroup, err := h.groupRepo.IsUserInGroup(container.UsergroupId, member.Id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskContainerServerError).Msg("Error occurred during IsUserInGroup")
+		response.InternalServerError(w, "Failed to check group membership")
+		return
+	}
+	if !inGroup {
+		h.domainError(w, model.ErrMemberNotInGroup)
+		return
+	}
+	if err = h.containerRepo.AddContainerMember(container.Id, member.Id); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskContainerMemberError).Msg("Error occurred during AddContainerMember")
+		response.InternalServerError(w, "Failed to add container member")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusCreated, member)
+}
+
+// handleRemoveContainerMember lets members leave the container themselves,
+// while removing someone else is limited to admins and owners of the group.
+func (h *Handler) handleRemoveContainerMember(w http.ResponseWriter, r *http.Request) {
+	container, caller, ok := h.findContainerForMember(w, r)
+	if !ok {
+		return
+	}
+	member, err := h.userRepo.GetUserByUserId(chi.URLParam(r, "userID"))
+	if err != nil || member == nil || member.Id == 0 {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskContainerUserNotFound).Msg("Cannot find user to remove from container")
+		response.NotFound(w, TaskContainerUserNotFound, "Not able to find a user")
+		return
+	}
+	if member.Id != caller.Id && !h.requireGroupAdmin(w, container.UsergroupId, caller.Id, "only group admins can remove other members") {
+		return
+	}
+	memberIds, ok := h.containerMembers(w, container)
+	if !ok {
+		return
+	}
+	if err = container.CanRemoveMember(memberIds, member.Id); err != nil {
+		h.domainError(w, err)
+		return
+	}
+	err = h.containerRepo.RemoveContainerMember(container.Id, member.Id)
+	if err == sql.ErrNoRows {
+		h.logger.Error().Str("ErrorCode", TaskContainerMemberNotFound).Msg("user is not a member of the container")
+		response.NotFound(w, TaskContainerMemberNotFound, "User is not a member of the container")
+		return
+	}
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskContainerMemberError).Msg("Error occurred during RemoveContainerMember")
+		response.InternalServerError(w, "Failed to remove container member")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusNoContent, "container member is removed.")
+}
+
+func (h *Handler) containerMembers(w http.ResponseWriter, container *model.TaskContainer) ([]int, bool) {
+	memberIds, err := h.containerRepo.GetContainerMembers(container.Id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskContainerMemberError).Msg("Error occurred during GetContainerMembers")
+		response.InternalServerError(w, "Failed to get container members")
+		return nil, false
+	}
+	return memberIds, true
+}
+
+// requireContainerMember limits a restricted container to its members and the
+// admins of the group. Containers shared with the whole group let every group
+// member through.
+func (h *Handler) requireContainerMember(w http.ResponseWriter, container *model.TaskContainer, memberIds []int, userId int) bool {
+	if !container.IsRestricted() || slices.Contains(memberIds, userId) {
+		return true
+	}
+	return h.requireGroupAdmin(w, container.UsergroupId, userId, "only container members and group admins can manage the members of a restricted container")
+}
+
+func (h *Handler) addCallerAsMember(w http.ResponseWriter, containerId string, callerId int) bool {
+	if err := h.containerRepo.AddContainerMember(containerId, callerId); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskContainerMemberError).Msg("Error occurred during AddContainerMember")
+		response.InternalServerError(w, "Failed to add container member")
+		return false
+	}
+	return true
+}
+
+// handleDeleteTaskContainer is limited to admins and owners of the group.
 func (h *Handler) handleDeleteTaskContainer(w http.ResponseWriter, r *http.Request) {
-	containerId := chi.URLParam(r, "containerID")
-	err := h.containerRepo.DeleteContainer(containerId)
+	container, ok := h.findContainer(w, r)
+	if !ok {
+		return
+	}
+	caller := h.currentUser(r)
+	if caller == nil {
+		h.logger.Error().Str("ErrorCode", TaskContainerUserNotFound).Msg("Not able to find user from token")
+		response.NotFound(w, TaskContainerUserNotFound, "Not able to find a user")
+		return
+	}
+	if !h.requireGroupAdmin(w, container.UsergroupId, caller.Id, "only group admins can delete containers") {
+		return
+	}
+	err := h.containerRepo.DeleteContainer(container.Id)
 	if err != nil {
 		h.logger.Error().Err(err).Str("ErrorCode", DeleteTaskContainerError).Msg(err.Error())
 		response.NotFound(w, DeleteTaskContainerError, "Error occurred during delete container")
@@ -108,3 +594,232 @@ func (h *Handler) handleDeleteTaskContainer(w http.ResponseWriter, r *http.Reque
 	}
 	response.WriteJsonWithEncode(w, http.StatusNoContent, "task container is removed.")
 }
+
+// requireGroupAdmin answers forbidden with the detail when the user is not an
+// admin or owner of the group.
+func (h *Handler) requireGroupAdmin(w http.ResponseWriter, groupId int, userId int, detail string) bool {
+	isAdmin, err := h.groupRepo.IsGroupAdmin(groupId, userId)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskContainerServerError).Msg("Error occurred during IsGroupAdmin")
+		response.InternalServerError(w, "Failed to check group role")
+		return false
+	}
+	if !isAdmin {
+		h.logger.Error().Str("ErrorCode", TaskContainerNotGroupAdmin).Msg("user is not an admin of the user group")
+		response.ErrorResponse(w, http.StatusForbidden, *response.New(TaskContainerNotGroupAdmin, errors.PermissionDenied, detail))
+		return false
+	}
+	return true
+}
+
+func (h *Handler) handleGetSections(w http.ResponseWriter, r *http.Request) {
+	container, _, ok := h.findContainerForMember(w, r)
+	if !ok {
+		return
+	}
+	sections, err := h.sectionRepo.GetSections(container.Id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskContainerSectionError).Msg("Error occurred during GetSections")
+		response.InternalServerError(w, "Failed to get sections")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusOK, sections)
+}
+
+func (h *Handler) handleCreateSection(w http.ResponseWriter, r *http.Request) {
+	var sectionDto SectionDto
+	if err := response.ParseJson(r, &sectionDto); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.RequestBodyError).Msg("Error occurred during parsing json of SectionDto")
+		response.InvalidJsonBody(w, "Error occurred during parsing json of SectionDto")
+		return
+	}
+	container, _, ok := h.findContainerForMember(w, r)
+	if !ok {
+		return
+	}
+	section, err := model.NewSection(uuid.New().String(), container.Id, sectionDto.Name, sectionDto.Status)
+	if err != nil {
+		h.domainError(w, err)
+		return
+	}
+	created, err := h.sectionRepo.CreateSection(*section)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskContainerSectionError).Msg("Error occurred during CreateSection")
+		response.InternalServerError(w, "Failed to create section")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusCreated, created)
+}
+
+func (h *Handler) handleUpdateSection(w http.ResponseWriter, r *http.Request) {
+	var sectionDto SectionDto
+	if err := response.ParseJson(r, &sectionDto); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.RequestBodyError).Msg("Error occurred during parsing json of SectionDto")
+		response.InvalidJsonBody(w, "Error occurred during parsing json of SectionDto")
+		return
+	}
+	container, _, ok := h.findContainerForMember(w, r)
+	if !ok {
+		return
+	}
+	section, err := h.sectionRepo.GetSection(container.Id, chi.URLParam(r, "sectionID"))
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskContainerSectionError).Msg("Error occurred during GetSection")
+		response.InternalServerError(w, "Failed to get section")
+		return
+	}
+	if section == nil {
+		h.logger.Error().Str("ErrorCode", TaskContainerSectionNotFound).Msg("Cannot find section")
+		response.NotFound(w, TaskContainerSectionNotFound, "Section does not exist")
+		return
+	}
+	if err = section.Rename(sectionDto.Name); err != nil {
+		h.domainError(w, err)
+		return
+	}
+	if err = section.SetStatus(sectionDto.Status); err != nil {
+		h.domainError(w, err)
+		return
+	}
+	if err = h.sectionRepo.UpdateSection(*section); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskContainerSectionError).Msg("Error occurred during UpdateSection")
+		response.InternalServerError(w, "Failed to update section")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusOK, section)
+}
+
+func (h *Handler) handleReorderSections(w http.ResponseWriter, r *http.Request) {
+	var orderDto SectionOrderDto
+	if err := response.ParseJson(r, &orderDto); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.RequestBodyError).Msg("Error occurred during parsing json of SectionOrderDto")
+		response.InvalidJsonBody(w, "Error occurred during parsing json of SectionOrderDto")
+		return
+	}
+	container, _, ok := h.findContainerForMember(w, r)
+	if !ok {
+		return
+	}
+	sections, err := h.sectionRepo.GetSections(container.Id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskContainerSectionError).Msg("Error occurred during GetSections")
+		response.InternalServerError(w, "Failed to get sections")
+		return
+	}
+	ordered, err := model.ReorderSections(sections, orderDto.SectionIds)
+	if err != nil {
+		h.domainError(w, err)
+		return
+	}
+	if err = h.sectionRepo.UpdateSectionPositions(container.Id, ordered); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskContainerSectionError).Msg("Error occurred during UpdateSectionPositions")
+		response.InternalServerError(w, "Failed to reorder sections")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusOK, ordered)
+}
+
+func (h *Handler) handleDeleteSection(w http.ResponseWriter, r *http.Request) {
+	container, _, ok := h.findContainerForMember(w, r)
+	if !ok {
+		return
+	}
+	err := h.sectionRepo.DeleteSection(container.Id, chi.URLParam(r, "sectionID"))
+	if err == sql.ErrNoRows {
+		h.logger.Error().Str("ErrorCode", TaskContainerSectionNotFound).Msg("Cannot find section to delete")
+		response.NotFound(w, TaskContainerSectionNotFound, "Section does not exist")
+		return
+	}
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskContainerSectionError).Msg("Error occurred during DeleteSection")
+		response.InternalServerError(w, "Failed to delete section")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusNoContent, "section is removed.")
+}
+
+func (h *Handler) findContainer(w http.ResponseWriter, r *http.Request) (*model.TaskContainer, bool) {
+	container, err := h.containerRepo.GetById(chi.URLParam(r, "containerID"))
+	if err != nil || container == nil || container.Id == "" {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskContainerGetNotFound).Msg("Container does not exist")
+		response.NotFound(w, TaskContainerGetNotFound, "Container does not exist")
+		return nil, false
+	}
+	return container, true
+}
+
+// findContainerForMember resolves the container of the request together with
+// the caller, who must belong to the container's user group.
+func (h *Handler) findContainerForMember(w http.ResponseWriter, r *http.Request) (*model.TaskContainer, *userModel.User, bool) {
+	container, ok := h.findContainer(w, r)
+	if !ok {
+		return nil, nil, false
+	}
+	caller := h.currentUser(r)
+	if caller == nil {
+		h.logger.Error().Str("ErrorCode", TaskContainerUserNotFound).Msg("Not able to find user from token")
+		response.NotFound(w, TaskContainerUserNotFound, "Not able to find a user")
+		return nil, nil, false
+	}
+	if !h.isGroupMember(w, container.UsergroupId, caller.Id) {
+		return nil, nil, false
+	}
+	return container, caller, true
+}
+
+// requireContainerAccess answers not found for containers restricted to other
+// users, so that they do not reveal that they exist.
+func (h *Handler) requireContainerAccess(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ok, err := h.containerRepo.CanAccessContainer(chi.URLParam(r, "containerID"), h.currentUserId(r))
+		if err != nil {
+			h.logger.Error().Err(err).Str("ErrorCode", TaskContainerServerError).Msg("Error occurred during CanAccessContainer")
+			response.InternalServerError(w, "Failed to check container access")
+			return
+		}
+		if !ok {
+			h.logger.Error().Str("ErrorCode", TaskContainerGetNotFound).Msg("Container is restricted to other members")
+			response.NotFound(w, TaskContainerGetNotFound, "Container does not exist")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// currentUserId is 0 when the caller is unknown, which only grants access to
+// containers shared with the whole group.
+func (h *Handler) currentUserId(r *http.Request) int {
+	if caller := h.currentUser(r); caller != nil {
+		return caller.Id
+	}
+	return 0
+}
+
+func (h *Handler) currentUser(r *http.Request) *userModel.User {
+	_, claims, _ := jwtauth.FromContext(r.Context())
+	user, err := h.userRepo.GetUserByUserId(fmt.Sprintf("%v", claims["nameid"]))
+	if err != nil {
+		return nil
+	}
+	return user
+}
+
+func (h *Handler) isGroupMember(w http.ResponseWriter, groupId int, userId int) bool {
+	isMember, err := h.groupRepo.IsUserInGroup(groupId, userId)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", TaskContainerServerError).Msg("Error occurred during IsUserInGroup")
+		response.InternalServerError(w, "Failed to check group membership")
+		return false
+	}
+	if !isMember {
+		h.logger.Error().Str("ErrorCode", TaskContainerNotGroupMember).Msg("user is not a member of the user group")
+		response.ErrorResponse(w, http.StatusForbidden, *response.New(TaskContainerNotGroupMember, errors.PermissionDenied, "user is not a member of the user group"))
+		return false
+	}
+	return true
+}
+
+func (h *Handler) domainError(w http.ResponseWriter, err error) {
+	h.logger.Error().Err(err).Str("ErrorCode", TaskContainerDomainError).Msg(err.Error())
+	response.ErrorResponse(w, http.StatusUnprocessableEntity, *response.New(TaskContainerDomainError, "Domain Validation Error", err.Error()))
+}
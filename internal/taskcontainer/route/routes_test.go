@@ -5,14 +5,22 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os/user"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth"
+	chatModel "github.com/happYness-Project/taskManagementGolang/internal/chat/model"
 	"github.com/happYness-Project/taskManagementGolang/internal/mocks"
 	"github.com/happYness-Project/taskManagementGolang/internal/taskcontainer/model"
+	"github.com/happYness-Project/taskManagementGolang/internal/taskcontainer/repository"
+	userModel "github.com/happYness-Project/taskManagementGolang/internal/user/model"
+	groupModel "github.com/happYness-Project/taskManagementGolang/internal/usergroup/model"
 	"github.com/happYness-Project/taskManagementGolang/pkg/configs"
 	"github.com/happYness-Project/taskManagementGolang/pkg/loggers"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -21,15 +29,17 @@ func TestTaskContainerHandler(t *testing.T) {
 	logger := loggers.Setup(env)
 	mockContainerRepo := new(mocks.MockContainerRepo)
 	mockUserRepo := new(mocks.MockUserRepo)
-	handler := NewHandler(logger, mockContainerRepo, mockUserRepo)
+	mockSectionRepo := new(mocks.MockSectionRepo)
+	handler := NewHandler(logger, mockContainerRepo, mockUserRepo, mockSectionRepo, new(mocks.MockUserGroupRepo))
 
-	t.Run("when get all task containers, Then return status code 200 and containers array", func(t *testing.T) {
+	t.Run("when get all task containers, Then return status code 200 and containers visible to the caller", func(t *testing.T) {
 		// Arrange
 		expectedContainers := []*model.TaskContainer{
 			{Id: "1", Name: "Container1", Description: "Desc1", Type: "typeA", IsActive: true, Activity_level: 0, UsergroupId: 2},
 		}
-		mockContainerRepo.On("AllTaskContainers").Return(expectedContainers, nil)
-		req := httptest.NewRequest(http.MethodGet, "/api/task-containers", nil)
+		mockUserRepo.On("GetUserByUserId", "user-a").Return(&userModel.User{Id: 1, UserId: "user-a"}, nil)
+		mockContainerRepo.On("AllTaskContainers", 1).Return(expectedContainers, nil)
+		req := withCaller(t, httptest.NewRequest(http.MethodGet, "/api/task-containers", nil), "user-a")
 		rr := httptest.NewRecorder()
 		router := chi.NewRouter()
 		router.Get("/api/task-containers", handler.handleGetTaskContainers)
@@ -81,20 +91,518 @@ func TestTaskContainerHandler(t *testing.T) {
 	})
 }
 
+func TestTaskContainerHandler_Sections(t *testing.T) {
+	logger := loggers.Setup(configs.Env{})
+	mockContainerRepo := new(mocks.MockContainerRepo)
+	mockSectionRepo := new(mocks.MockSectionRepo)
+	mockUserRepo := new(mocks.MockUserRepo)
+	mockGroupRepo := new(mocks.MockUserGroupRepo)
+	handler := NewHandler(logger, mockContainerRepo, mockUserRepo, mockSectionRepo, mockGroupRepo)
+	mockUserRepo.On("GetUserByUserId", "user-a").Return(&userModel.User{Id: 1, UserId: "user-a"}, nil)
+	mockUserRepo.On("GetUserByUserId", "outsider").Return(&userModel.User{Id: 9, UserId: "outsider"}, nil)
+	mockGroupRepo.On("IsUserInGroup", 1, 1).Return(true, nil)
+	mockGroupRepo.On("IsUserInGroup", 1, 9).Return(false, nil)
+	containerId := "board"
+	mockContainerRepo.On("GetById", containerId).Return(&model.TaskContainer{Id: containerId, Name: "Board", UsergroupId: 1}, nil)
+	router := chi.NewRouter()
+	router.Post("/api/task-containers/{containerID}/sections", handler.handleCreateSection)
+	router.Put("/api/task-containers/{containerID}/sections/order", handler.handleReorderSections)
+	router.Delete("/api/task-containers/{containerID}/sections/{sectionID}", handler.handleDeleteSection)
+
+	t.Run("when create section, Then return status code 201 and the section", func(t *testing.T) {
+		// Arrange
+		mockSectionRepo.On("CreateSection", mock.MatchedBy(func(s model.ContainerSection) bool {
+			return s.ContainerId == containerId && s.Name == "Done" && s.Status == model.SectionStatusDone
+		})).Return(model.ContainerSection{Id: "s-1", ContainerId: containerId, Name: "Done", Status: model.SectionStatusDone, Position: 2}, nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodPost, "/api/task-containers/"+containerId+"/sections", strings.NewReader(`{"name":" Done ","status":"DONE"}`)), "user-a")
+		rr := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		var section model.ContainerSection
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &section))
+		assert.Equal(t, 2, section.Position)
+		mockSectionRepo.AssertExpectations(t)
+	})
+
+	t.Run("when create section with unknown status, Then return status code 422", func(t *testing.T) {
+		req := withCaller(t, httptest.NewRequest(http.MethodPost, "/api/task-containers/"+containerId+"/sections", strings.NewReader(`{"name":"Review","status":"blocked"}`)), "user-a")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+
+	t.Run("when reorder misses a section, Then return status code 422", func(t *testing.T) {
+		mockSectionRepo.On("GetSections", containerId).Return([]model.ContainerSection{{Id: "a"}, {Id: "b"}}, nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodPut, "/api/task-containers/"+containerId+"/sections/order", strings.NewReader(`{"section_ids":["b"]}`)), "user-a")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		mockSectionRepo.AssertNotCalled(t, "UpdateSectionPositions", mock.Anything, mock.Anything)
+	})
+
+	t.Run("when caller is not a member of the group, Then return status code 403", func(t *testing.T) {
+		req := withCaller(t, httptest.NewRequest(http.MethodDelete, "/api/task-containers/"+containerId+"/sections/a", nil), "outsider")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockSectionRepo.AssertNotCalled(t, "DeleteSection", containerId, "a")
+	})
+}
+
+func TestTaskContainerHandler_Update(t *testing.T) {
+	logger := loggers.Setup(configs.Env{})
+	mockUserRepo := new(mocks.MockUserRepo)
+	mockGroupRepo := new(mocks.MockUserGroupRepo)
+	mockUserRepo.On("GetUserByUserId", "user-a").Return(&userModel.User{Id: 1, UserId: "user-a"}, nil)
+	mockUserRepo.On("GetUserByUserId", "outsider").Return(&userModel.User{Id: 9, UserId: "outsider"}, nil)
+	mockGroupRepo.On("IsUserInGroup", 7, 1).Return(true, nil)
+	mockGroupRepo.On("IsUserInGroup", 7, 9).Return(false, nil)
+	router := func(mockContainerRepo *mocks.MockContainerRepo) *chi.Mux {
+		handler := NewHandler(logger, mockContainerRepo, mockUserRepo, new(mocks.MockSectionRepo), mockGroupRepo)
+		router := chi.NewRouter()
+		router.Put("/api/task-containers/{containerID}", handler.handleUpdateTaskContainer)
+		router.Patch("/api/task-containers/{containerID}", handler.handlePatchTaskContainer)
+		return router
+	}
+
+	t.Run("when patch only the name, Then other fields are kept", func(t *testing.T) {
+		// Arrange
+		mockContainerRepo := new(mocks.MockContainerRepo)
+		existing := &model.TaskContainer{Id: "c-1", Name: "Old", Description: "desc", Type: model.TypeGrocery, IsActive: true, UsergroupId: 7}
+		mockContainerRepo.On("GetById", "c-1").Return(existing, nil)
+		mockContainerRepo.On("UpdateContainer", model.TaskContainer{Id: "c-1", Name: "New", Description: "desc", Type: model.TypeGrocery, IsActive: true, UsergroupId: 7}).Return(nil)
+		req := withCaller(t, httptest.NewRequest(http.MethodPatch, "/api/task-containers/c-1", strings.NewReader(`{"name":"New"}`)), "user-a")
+		rr := httptest.NewRecorder()
+
+		// Act
+		router(mockContainerRepo).ServeHTTP(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockContainerRepo.AssertExpectations(t)
+	})
+
+	t.Run("when put an unknown type, Then return status code 422", func(t *testing.T) {
+		mockContainerRepo := new(mocks.MockContainerRepo)
+		mockContainerRepo.On("GetById", "c-1").Return(&model.TaskContainer{Id: "c-1", Name: "Old", IsActive: true, UsergroupId: 7}, nil)
+		req := withCaller(t, httptest.NewRequest(http.MethodPut, "/api/task-containers/c-1", strings.NewReader(`{"name":"Old","type":"kanban"}`)), "user-a")
+		rr := httptest.NewRecorder()
+
+		router(mockContainerRepo).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		mockContainerRepo.AssertNotCalled(t, "UpdateContainer", mock.Anything)
+	})
+
+	t.Run("when caller is not a member of the group, Then return status code 403", func(t *testing.T) {
+		mockContainerRepo := new(mocks.MockContainerRepo)
+		mockContainerRepo.On("GetById", "c-1").Return(&model.TaskContainer{Id: "c-1", Name: "Old", IsActive: true, UsergroupId: 7}, nil)
+		req := withCaller(t, httptest.NewRequest(http.MethodPatch, "/api/task-containers/c-1", strings.NewReader(`{"name":"Mine"}`)), "outsider")
+		rr := httptest.NewRecorder()
+
+		router(mockContainerRepo).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockContainerRepo.AssertNotCalled(t, "UpdateContainer", mock.Anything)
+	})
+}
+
+func TestTaskContainerHandler_Create(t *testing.T) {
+	logger := loggers.Setup(configs.Env{})
+	mockContainerRepo := new(mocks.MockContainerRepo)
+	mockUserRepo := new(mocks.MockUserRepo)
+	mockGroupRepo := new(mocks.MockUserGroupRepo)
+	handler := NewHandler(logger, mockContainerRepo, mockUserRepo, new(mocks.MockSectionRepo), mockGroupRepo)
+	router := chi.NewRouter()
+	router.Post("/api/task-containers", handler.handleCreateTaskContainer)
+	mockUserRepo.On("GetUserByUserId", "user-a").Return(&userModel.User{Id: 1, UserId: "user-a"}, nil)
+	mockGroupRepo.On("GetById", 7).Return(&groupModel.UserGroup{GroupId: 7}, nil)
+
+	t.Run("when caller belongs to the group, Then the container is created with a chat for the group's members", func(t *testing.T) {
+		// Arrange
+		mockGroupRepo.On("IsUserInGroup", 7, 1).Return(true, nil).Once()
+		mockUserRepo.On("GetUsersByGroupId", 7).Return([]*userModel.User{{Id: 1}, {Id: 2}}, nil).Once()
+		var participants []chatModel.ChatParticipant
+		mockContainerRepo.On("CreateContainer", mock.MatchedBy(func(c model.TaskContainer) bool {
+			return c.Name == "Groceries" && c.Type == model.TypeGrocery && c.UsergroupId == 7
+		}), 1, mock.MatchedBy(func(chat chatModel.Chat) bool {
+			return chat.Id != "" && chat.IsContainerChat() && *chat.UserGroupId == 7
+		}), mock.Anything).Run(func(args mock.Arguments) {
+			participants = args.Get(3).([]chatModel.ChatParticipant)
+		}).Return(nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodPost, "/api/task-containers", strings.NewReader(`{"name":"Groceries","type":"grocery","usergroup_id":7}`)), "user-a")
+		rr := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		require.Len(t, participants, 2)
+		assert.Equal(t, chatModel.RoleAdmin, participants[0].Role)
+		assert.Equal(t, 2, participants[1].UserId)
+		assert.Equal(t, chatModel.RoleMember, participants[1].Role)
+		mockContainerRepo.AssertExpectations(t)
+	})
+
+	t.Run("when group does not exist, Then return status code 404", func(t *testing.T) {
+		mockGroupRepo.On("GetById", 8).Return(&groupModel.UserGroup{}, nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodPost, "/api/task-containers", strings.NewReader(`{"name":"Groceries","usergroup_id":8}`)), "user-a")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("when caller is not a member of the group, Then return status code 403", func(t *testing.T) {
+		mockGroupRepo.On("IsUserInGroup", 7, 1).Return(false, nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodPost, "/api/task-containers", strings.NewReader(`{"name":"Groceries","usergroup_id":7}`)), "user-a")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("when name is empty, Then return status code 422", func(t *testing.T) {
+		req := withCaller(t, httptest.NewRequest(http.MethodPost, "/api/task-containers", strings.NewReader(`{"name":" ","usergroup_id":7}`)), "user-a")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+
+	t.Run("when creating a restricted container, Then only the creator joins its chat", func(t *testing.T) {
+		mockGroupRepo.On("IsUserInGroup", 7, 1).Return(true, nil).Once()
+		mockContainerRepo.On("CreateContainer", mock.Anything, 1, mock.Anything, mock.MatchedBy(func(participants []chatModel.ChatParticipant) bool {
+			return len(participants) == 1 && participants[0].UserId == 1
+		})).Return(nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodPost, "/api/task-containers", strings.NewReader(`{"name":"Party","usergroup_id":7,"visibility":"members"}`)), "user-a")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		mockContainerRepo.AssertExpectations(t)
+	})
+}
+
+func TestTaskContainerHandler_Delete(t *testing.T) {
+	logger := loggers.Setup(configs.Env{})
+	mockContainerRepo := new(mocks.MockContainerRepo)
+	mockUserRepo := new(mocks.MockUserRepo)
+	mockGroupRepo := new(mocks.MockUserGroupRepo)
+	handler := NewHandler(logger, mockContainerRepo, mockUserRepo, new(mocks.MockSectionRepo), mockGroupRepo)
+	router := chi.NewRouter()
+	handler.RegisterRoutes(router)
+	mockUserRepo.On("GetUserByUserId", "user-a").Return(&userModel.User{Id: 1, UserId: "user-a"}, nil)
+	mockContainerRepo.On("CanAccessContainer", "c-1", 1).Return(true, nil)
+	mockContainerRepo.On("GetById", "c-1").Return(&model.TaskContainer{Id: "c-1", UsergroupId: 7}, nil)
+
+	t.Run("when caller is a group admin, Then the container is deleted", func(t *testing.T) {
+		// Arrange
+		mockGroupRepo.On("IsGroupAdmin", 7, 1).Return(true, nil).Once()
+		mockContainerRepo.On("DeleteContainer", "c-1").Return(nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodDelete, "/api/task-containers/c-1", nil), "user-a")
+		rr := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		mockContainerRepo.AssertExpectations(t)
+	})
+
+	t.Run("when caller is a plain member, Then return status code 403", func(t *testing.T) {
+		mockGroupRepo.On("IsGroupAdmin", 7, 1).Return(false, nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodDelete, "/api/task-containers/c-1", nil), "user-a")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockContainerRepo.AssertNumberOfCalls(t, "DeleteContainer", 1)
+	})
+}
+
+func TestTaskContainerHandler_Archive(t *testing.T) {
+	logger := loggers.Setup(configs.Env{})
+	mockContainerRepo := new(mocks.MockContainerRepo)
+	mockUserRepo := new(mocks.MockUserRepo)
+	mockGroupRepo := new(mocks.MockUserGroupRepo)
+	handler := NewHandler(logger, mockContainerRepo, mockUserRepo, new(mocks.MockSectionRepo), mockGroupRepo)
+	router := chi.NewRouter()
+	handler.RegisterRoutes(router)
+	mockUserRepo.On("GetUserByUserId", "user-a").Return(&userModel.User{Id: 1, UserId: "user-a"}, nil)
+	mockContainerRepo.On("CanAccessContainer", "c-1", 1).Return(true, nil)
+
+	t.Run("when caller is a group admin, Then container is deactivated", func(t *testing.T) {
+		// Arrange
+		mockContainerRepo.On("GetById", "c-1").Return(&model.TaskContainer{Id: "c-1", Name: "Old", IsActive: true, UsergroupId: 7}, nil).Once()
+		mockGroupRepo.On("IsGroupAdmin", 7, 1).Return(true, nil).Once()
+		mockContainerRepo.On("SetContainerActive", "c-1", false).Return(nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodPost, "/api/task-containers/c-1/archive", nil), "user-a")
+		rr := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var container model.TaskContainer
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &container))
+		assert.False(t, container.IsActive)
+		mockContainerRepo.AssertExpectations(t)
+	})
+
+	t.Run("when caller is a plain member, Then return status code 403", func(t *testing.T) {
+		mockContainerRepo.On("GetById", "c-1").Return(&model.TaskContainer{Id: "c-1", Name: "Old", IsActive: true, UsergroupId: 7}, nil).Once()
+		mockGroupRepo.On("IsGroupAdmin", 7, 1).Return(false, nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodPost, "/api/task-containers/c-1/archive", nil), "user-a")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockContainerRepo.AssertNumberOfCalls(t, "SetContainerActive", 1)
+	})
+}
+
+func withCaller(t *testing.T, req *http.Request, userId string) *http.Request {
+	token, _, err := jwtauth.New("HS256", []byte("secret"), nil).Encode(map[string]interface{}{"nameid": userId})
+	require.NoError(t, err)
+	return req.WithContext(jwtauth.NewContext(req.Context(), token, nil))
+}
+
+func TestTaskContainerHandler_ContainerOrder(t *testing.T) {
+	logger := loggers.Setup(configs.Env{})
+	mockContainerRepo := new(mocks.MockContainerRepo)
+	mockUserRepo := new(mocks.MockUserRepo)
+	mockGroupRepo := new(mocks.MockUserGroupRepo)
+	handler := NewHandler(logger, mockContainerRepo, mockUserRepo, new(mocks.MockSectionRepo), mockGroupRepo)
+	router := chi.NewRouter()
+	handler.RegisterRoutes(router)
+	mockUserRepo.On("GetUserByUserId", "user-a").Return(&userModel.User{Id: 1, UserId: "user-a"}, nil)
+	mockGroupRepo.On("IsUserInGroup", 7, 1).Return(true, nil)
+
+	t.Run("when user orders own containers, Then pinned flags and positions are stored", func(t *testing.T) {
+		// Arrange
+		mockContainerRepo.On("GetContainersByGroupId", 7, repository.ContainerFilter{IncludeArchived: true}).
+			Return([]model.TaskContainer{{Id: "grocery"}, {Id: "chores"}}, nil).Once()
+		mockContainerRepo.On("ReplaceContainerOrders", 1, 7, []model.ContainerOrder{
+			{ContainerId: "chores", Position: 0, IsPinned: true},
+			{ContainerId: "grocery", Position: 1},
+		}).Return(nil).Once()
+		body := `{"containers":[{"id":"chores","is_pinned":true},{"id":"grocery"}]}`
+		req := withCaller(t, httptest.NewRequest(http.MethodPut, "/api/users/user-a/user-groups/7/container-order", strings.NewReader(body)), "user-a")
+		rr := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockContainerRepo.AssertExpectations(t)
+	})
+
+	t.Run("when container is not in the group, Then return status code 422", func(t *testing.T) {
+		mockContainerRepo.On("GetContainersByGroupId", 7, repository.ContainerFilter{IncludeArchived: true}).
+			Return([]model.TaskContainer{{Id: "grocery"}}, nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodPut, "/api/users/user-a/user-groups/7/container-order", strings.NewReader(`{"containers":[{"id":"other"}]}`)), "user-a")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+
+	t.Run("when user orders containers of another user, Then return status code 403", func(t *testing.T) {
+		req := withCaller(t, httptest.NewRequest(http.MethodPut, "/api/users/user-b/user-groups/7/container-order", strings.NewReader(`{"containers":[]}`)), "user-a")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("when listing containers, Then the caller's order is applied", func(t *testing.T) {
+		mockContainerRepo.On("GetContainersByGroupId", 7, repository.ContainerFilter{UserId: 1}).
+			Return([]model.TaskContainer{{Id: "chores", IsPinned: true}, {Id: "grocery"}}, nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodGet, "/api/user-groups/7/task-containers", nil), "user-a")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockContainerRepo.AssertExpectations(t)
+	})
+}
+
+func TestTaskContainerHandler_RestrictedContainers(t *testing.T) {
+	logger := loggers.Setup(configs.Env{})
+	mockContainerRepo := new(mocks.MockContainerRepo)
+	mockUserRepo := new(mocks.MockUserRepo)
+	mockGroupRepo := new(mocks.MockUserGroupRepo)
+	handler := NewHandler(logger, mockContainerRepo, mockUserRepo, new(mocks.MockSectionRepo), mockGroupRepo)
+	router := chi.NewRouter()
+	handler.RegisterRoutes(router)
+	mockUserRepo.On("GetUserByUserId", "user-a").Return(&userModel.User{Id: 1, UserId: "user-a"}, nil)
+	mockGroupRepo.On("IsUserInGroup", 7, 1).Return(true, nil)
+
+	t.Run("when container is restricted to other members, Then return status code 404", func(t *testing.T) {
+		mockContainerRepo.On("CanAccessContainer", "party", 1).Return(false, nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodGet, "/api/task-containers/party", nil), "user-a")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockContainerRepo.AssertNotCalled(t, "GetById", "party")
+	})
+
+	t.Run("when removing the last member of a restricted container, Then return status code 422", func(t *testing.T) {
+		party := &model.TaskContainer{Id: "party", UsergroupId: 7, Visibility: model.VisibilityMembers}
+		mockContainerRepo.On("CanAccessContainer", "party", 1).Return(true, nil).Once()
+		mockContainerRepo.On("GetById", "party").Return(party, nil).Once()
+		mockContainerRepo.On("GetContainerMembers", "party").Return([]int{1}, nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodDelete, "/api/task-containers/party/members/user-a", nil), "user-a")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		mockContainerRepo.AssertNotCalled(t, "RemoveContainerMember", "party", 1)
+	})
+
+	t.Run("when a plain member removes another member, Then return status code 403", func(t *testing.T) {
+		party := &model.TaskContainer{Id: "party", UsergroupId: 7, Visibility: model.VisibilityMembers}
+		mockContainerRepo.On("CanAccessContainer", "party", 1).Return(true, nil).Once()
+		mockContainerRepo.On("GetById", "party").Return(party, nil).Once()
+		mockUserRepo.On("GetUserByUserId", "user-b").Return(&userModel.User{Id: 2, UserId: "user-b"}, nil)
+		mockGroupRepo.On("IsGroupAdmin", 7, 1).Return(false, nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodDelete, "/api/task-containers/party/members/user-b", nil), "user-a")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockContainerRepo.AssertNotCalled(t, "RemoveContainerMember", "party", 2)
+	})
+
+	t.Run("when adding a user outside the group as member, Then return status code 422", func(t *testing.T) {
+		party := &model.TaskContainer{Id: "party", UsergroupId: 7, Visibility: model.VisibilityMembers}
+		mockContainerRepo.On("CanAccessContainer", "party", 1).Return(true, nil).Once()
+		mockContainerRepo.On("GetById", "party").Return(party, nil).Once()
+		mockContainerRepo.On("GetContainerMembers", "party").Return([]int{1}, nil).Once()
+		mockUserRepo.On("GetUserByUserId", "user-c").Return(&userModel.User{Id: 3, UserId: "user-c"}, nil)
+		mockGroupRepo.On("IsUserInGroup", 7, 3).Return(false, nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodPost, "/api/task-containers/party/members", strings.NewReader(`{"user_id":"user-c"}`)), "user-a")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		mockContainerRepo.AssertNotCalled(t, "AddContainerMember", "party", 3)
+	})
+
+	t.Run("when a user outside the group lists the members, Then return status code 403", func(t *testing.T) {
+		mockUserRepo.On("GetUserByUserId", "outsider").Return(&userModel.User{Id: 9, UserId: "outsider"}, nil)
+		mockContainerRepo.On("CanAccessContainer", "chores", 9).Return(true, nil).Once()
+		mockContainerRepo.On("GetById", "chores").Return(&model.TaskContainer{Id: "chores", UsergroupId: 7, Visibility: model.VisibilityGroup}, nil).Once()
+		mockGroupRepo.On("IsUserInGroup", 7, 9).Return(false, nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodGet, "/api/task-containers/chores/members", nil), "outsider")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockContainerRepo.AssertNotCalled(t, "GetContainerMembers", "chores")
+	})
+
+	t.Run("when a group member who is not a container member adds a member, Then return status code 403", func(t *testing.T) {
+		party := &model.TaskContainer{Id: "party", UsergroupId: 7, Visibility: model.VisibilityMembers}
+		mockContainerRepo.On("CanAccessContainer", "party", 1).Return(true, nil).Once()
+		mockContainerRepo.On("GetById", "party").Return(party, nil).Once()
+		mockContainerRepo.On("GetContainerMembers", "party").Return([]int{2}, nil).Once()
+		mockGroupRepo.On("IsGroupAdmin", 7, 1).Return(false, nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodPost, "/api/task-containers/party/members", strings.NewReader(`{"user_id":"user-a"}`)), "user-a")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockContainerRepo.AssertNotCalled(t, "AddContainerMember", "party", 1)
+	})
+
+	t.Run("when a plain member restricts a container, Then return status code 403", func(t *testing.T) {
+		mockContainerRepo.On("CanAccessContainer", "chores", 1).Return(true, nil).Once()
+		mockContainerRepo.On("GetById", "chores").Return(&model.TaskContainer{Id: "chores", UsergroupId: 7, Visibility: model.VisibilityGroup}, nil).Once()
+		mockGroupRepo.On("IsGroupAdmin", 7, 1).Return(false, nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodPatch, "/api/task-containers/chores", strings.NewReader(`{"visibility":"members"}`)), "user-a")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockContainerRepo.AssertNotCalled(t, "AddContainerMember", "chores", 1)
+		mockContainerRepo.AssertNotCalled(t, "UpdateContainer", mock.Anything)
+	})
+}
+
 type mockContainerRepo struct{}
 
-func (m *mockContainerRepo) AllTaskContainers() ([]*model.TaskContainer, error) {
+func (m *mockContainerRepo) AllTaskContainers(userId int) ([]*model.TaskContainer, error) {
 	return []*model.TaskContainer{}, nil
 }
 func (m *mockContainerRepo) GetById(id string) (*model.TaskContainer, error) {
 	return &model.TaskContainer{}, nil
 }
-func (m *mockContainerRepo) GetContainersByGroupId(groupId int) ([]model.TaskContainer, error) {
+func (m *mockContainerRepo) GetContainersByGroupId(groupId int, filter repository.ContainerFilter) ([]model.TaskContainer, error) {
 	return []model.TaskContainer{}, nil
 }
-func (m *mockContainerRepo) CreateContainer(c model.TaskContainer) error {
+func (m *mockContainerRepo) CreateContainer(c model.TaskContainer, createdBy int, chat chatModel.Chat, participants []chatModel.ChatParticipant) error {
 	return nil
 }
+func (m *mockContainerRepo) UpdateContainer(c model.TaskContainer) error {
+	return nil
+}
+func (m *mockContainerRepo) SetContainerActive(id string, active bool) error {
+	return nil
+}
+func (m *mockContainerRepo) CanAccessContainer(id string, userId int) (bool, error) {
+	return true, nil
+}
+func (m *mockContainerRepo) GetContainerMembers(id string) ([]int, error) {
+	return []int{}, nil
+}
+func (m *mockContainerRepo) AddContainerMember(id string, userId int) error {
+	return nil
+}
+func (m *mockContainerRepo) RemoveContainerMember(id string, userId int) error {
+	return nil
+}
+func (m *mockContainerRepo) ReplaceContainerOrders(userId int, groupId int, orders []model.ContainerOrder) error {
+	return nil
+}
+func (m *mockContainerRepo) DuplicateContainer(sourceId string, duplicate model.TaskContainer, options model.DuplicateOptions, createdBy int, now time.Time, chat chatModel.Chat, participants []chatModel.ChatParticipant) (int, error) {
+	return 0, nil
+}
+func (m *mockContainerRepo) RefreshActivityLevels(since time.Time) (int64, error) {
+	return 0, nil
+}
 func (m *mockContainerRepo) DeleteContainer(id string) error {
 	return nil
 }
@@ -5,4 +5,51 @@ type CreateContainerDto struct {
 	Description string `json:"description"`
 	Type        string `json:"type"`
 	UserGroupId int    `json:"usergroup_id"`
+	Visibility  string `json:"visibility"`
+}
+
+type UpdateContainerDto struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Type        string `json:"type"`
+	Visibility  string `json:"visibility"`
+}
+
+type PatchContainerDto struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	Type        *string `json:"type"`
+	Visibility  *string `json:"visibility"`
+}
+
+type ContainerMemberDto struct {
+	UserId string `json:"user_id"`
+}
+
+// DuplicateContainerDto copies into the source container's group when no user
+// group is given.
+type DuplicateContainerDto struct {
+	Name            string `json:"name"`
+	UserGroupId     *int   `json:"usergroup_id"`
+	ResetCompletion bool   `json:"reset_completion"`
+	DateOffsetDays  int    `json:"date_offset_days"`
+}
+
+// ContainerOrderDto lists the containers in the order the user wants them.
+type ContainerOrderDto struct {
+	Containers []ContainerOrderItemDto `json:"containers"`
+}
+
+type ContainerOrderItemDto struct {
+	Id       string `json:"id"`
+	IsPinned bool   `json:"is_pinned"`
+}
+
+type SectionDto struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+type SectionOrderDto struct {
+	SectionIds []string `json:"section_ids"`
 }
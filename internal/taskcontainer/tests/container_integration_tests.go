@@ -20,7 +20,7 @@ func TestTaskContainerHandlerChecking(t *testing.T) {
 	containerRepo := repository.NewContainerRepository(database)
 
 	t.Run("Container Exists", func(t *testing.T) {
-		container, err := containerRepo.AllTaskContainers()
+		container, err := containerRepo.AllTaskContainers(0)
 
 		require.Nil(t, err)
 		require.NotNil(t, container)
@@ -16,8 +16,14 @@ type User struct {
 	CreatedAt      time.Time `json:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at"`
 	DefaultGroupId int       `json:"default_group_id"`
+	Timezone       string    `json:"timezone"`
 }
 
+// DefaultTimezone is the profile time zone of users who have not chosen one.
+const DefaultTimezone = "UTC"
+
+var ErrInvalidTimezone = errors.New("timezone must be a valid IANA time zone name")
+
 func NewUser(userId string, userName string, firstName string, lastName string, email string) *User {
 	user := User{
 		UserId:         userId,
@@ -29,6 +35,7 @@ func NewUser(userId string, userName string, firstName string, lastName string,
 		CreatedAt:      time.Now(),
 		UpdatedAt:      time.Now(),
 		DefaultGroupId: 0,
+		Timezone:       DefaultTimezone,
 	}
 
 	return &user
@@ -60,3 +67,17 @@ func (u *User) UpdateUser(firstName, lastName, email string) {
 	u.Email = email
 	u.UpdatedAt = time.Now()
 }
+
+// SetTimezone records the IANA time zone of the user. New tasks are planned in
+// this zone unless they name their own.
+func (u *User) SetTimezone(name string) error {
+	if name == "" || name == "Local" {
+		return ErrInvalidTimezone
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return ErrInvalidTimezone
+	}
+	u.Timezone = name
+	u.UpdatedAt = time.Now()
+	return nil
+}
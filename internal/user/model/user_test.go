@@ -301,3 +301,29 @@ func TestUpdateUser(t *testing.T) {
 		assert.True(t, user.UpdatedAt.After(originalUpdatedAt))
 	})
 }
+
+func TestSetTimezone(t *testing.T) {
+	t.Run("when creating a user, Then the timezone defaults to UTC", func(t *testing.T) {
+		user := NewUser("test-user", "testuser", "Test", "User", "test@example.com")
+
+		assert.Equal(t, DefaultTimezone, user.Timezone)
+	})
+
+	t.Run("when setting a valid IANA zone, Then the timezone is updated", func(t *testing.T) {
+		user := NewUser("test-user", "testuser", "Test", "User", "test@example.com")
+
+		err := user.SetTimezone("America/Vancouver")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "America/Vancouver", user.Timezone)
+	})
+
+	t.Run("when setting an unknown zone, Then ErrInvalidTimezone is returned", func(t *testing.T) {
+		user := NewUser("test-user", "testuser", "Test", "User", "test@example.com")
+
+		err := user.SetTimezone("Mars/Olympus")
+
+		assert.ErrorIs(t, err, ErrInvalidTimezone)
+		assert.Equal(t, DefaultTimezone, user.Timezone)
+	})
+}
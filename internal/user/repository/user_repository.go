@@ -116,7 +116,7 @@ func (m *UserRepo) CreateUser(user model.User) error {
 		return err
 	}
 
-	_, err = tx.Exec(sqlCreateUser, user.UserId, user.UserName, user.FirstName, user.LastName, user.Email, user.IsActive, user.CreatedAt, user.UpdatedAt, user.DefaultGroupId)
+	_, err = tx.Exec(sqlCreateUser, user.UserId, user.UserName, user.FirstName, user.LastName, user.Email, user.IsActive, user.CreatedAt, user.UpdatedAt, user.DefaultGroupId, user.Timezone)
 	if err != nil {
 		return fmt.Errorf("unable to insert into user table : %w", err)
 	}
@@ -128,7 +128,7 @@ func (m *UserRepo) CreateUser(user model.User) error {
 	return nil
 }
 func (m *UserRepo) UpdateUser(user model.User) error {
-	_, err := m.DB.Exec(sqlUpdateUser, user.Id, user.FirstName, user.LastName, user.Email, user.DefaultGroupId, user.UpdatedAt)
+	_, err := m.DB.Exec(sqlUpdateUser, user.Id, user.FirstName, user.LastName, user.Email, user.DefaultGroupId, user.UpdatedAt, user.Timezone)
 	if err != nil {
 		return err
 	}
@@ -149,6 +149,7 @@ func scanRowsIntoUser(rows *sql.Rows) (*model.User, error) {
 		&user.CreatedAt,
 		&user.UpdatedAt,
 		&user.DefaultGroupId,
+		&user.Timezone,
 	)
 	if err != nil {
 		return nil, err
@@ -86,6 +86,7 @@ func (h *Handler) handleGetUser(w http.ResponseWriter, r *http.Request) {
 	userDetailDto.UpdatedAt = user.UpdatedAt
 	userDetailDto.Email = user.Email
 	userDetailDto.IsActive = user.IsActive
+	userDetailDto.Timezone = user.Timezone
 	userDetailDto.UserGroup = ugs
 	userDetailDto.DefaultGroupId = user.DefaultGroupId
 
@@ -148,6 +149,7 @@ func (h *Handler) responseUser(w http.ResponseWriter, findField string, findVar
 	userDetailDto.UpdatedAt = user.UpdatedAt
 	userDetailDto.Email = user.Email
 	userDetailDto.IsActive = user.IsActive
+	userDetailDto.Timezone = user.Timezone
 	userDetailDto.UserGroup = ugs
 	userDetailDto.DefaultGroupId = user.DefaultGroupId
 
@@ -191,6 +193,13 @@ func (h *Handler) handleUpdateUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	user.UpdateUser(updateDto.FirstName, updateDto.LastName, updateDto.Email) // Todo : Domain Validation error code.
+	if updateDto.Timezone != "" && updateDto.Timezone != user.Timezone {
+		if err = user.SetTimezone(updateDto.Timezone); err != nil {
+			h.logger.Error().Err(err).Str("ErrorCode", UserDomainError).Msg(err.Error())
+			response.BadRequestDomainError(w, UserDomainError, err.Error())
+			return
+		}
+	}
 
 	err = h.userRepo.UpdateUser(*user)
 	if err != nil {
@@ -16,6 +16,7 @@ type UpdateUserDto struct {
 	FirstName string `json:"first_name"`
 	LastName  string `json:"last_name"`
 	Email     string `json:"email"`
+	Timezone  string `json:"timezone"`
 }
 type UserDetailDto struct {
 	Id             int                `json:"id"`
@@ -28,5 +29,6 @@ type UserDetailDto struct {
 	Email          string             `json:"email"`
 	IsActive       bool               `json:"is_active"`
 	DefaultGroupId int                `json:"default_group_id"`
+	Timezone       string             `json:"timezone"`
 	UserGroup      []*model.UserGroup `json:"user_groups"`
 }
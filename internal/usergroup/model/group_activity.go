@@ -0,0 +1,36 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+type GroupActivity struct {
+	Id        string    `json:"id"`
+	GroupId   int       `json:"usergroup_id"`
+	UserId    *int      `json:"user_id,omitempty"`
+	Action    string    `json:"action"`
+	Detail    string    `json:"detail"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+const (
+	ActivityUpdated     = "updated"
+	ActivityRoleChanged = "role_changed"
+)
+
+func NewGroupActivity(groupId int, userId *int, action string, detail string) (*GroupActivity, error) {
+	if groupId == 0 {
+		return nil, errors.New("group id cannot be empty")
+	}
+	if action == "" {
+		return nil, errors.New("activity action cannot be empty")
+	}
+	return &GroupActivity{
+		GroupId:   groupId,
+		UserId:    userId,
+		Action:    action,
+		Detail:    detail,
+		CreatedAt: time.Now(),
+	}, nil
+}
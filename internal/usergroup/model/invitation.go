@@ -0,0 +1,88 @@
+package model
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"time"
+)
+
+const (
+	// DefaultInvitationHours is used when no expiry is given, one week.
+	DefaultInvitationHours = 7 * 24
+	MaxInvitationHours     = 30 * 24
+	MaxInvitationUses      = 100
+)
+
+var (
+	ErrInvalidInvitationExpiry = errors.New("invitation must expire within 1 to 720 hours")
+	ErrInvalidInvitationUses   = errors.New("invitation max uses must be between 1 and 100")
+	ErrInvitationExpired       = errors.New("invitation has expired")
+	ErrInvitationRevoked       = errors.New("invitation has been revoked")
+	ErrInvitationUsedUp        = errors.New("invitation has no uses left")
+)
+
+// Invitation lets users join a group with a token instead of being added by
+// user id. It can be used MaxUses times until it expires or is revoked.
+type Invitation struct {
+	Id        string     `json:"id"`
+	GroupId   int        `json:"usergroup_id"`
+	Token     string     `json:"token"`
+	CreatedBy *int       `json:"created_by,omitempty"`
+	MaxUses   int        `json:"max_uses"`
+	Uses      int        `json:"uses"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// NewInvitation creates an invitation with a random token. Zero max uses means
+// a single-use invitation and zero hours the default expiry.
+func NewInvitation(groupId int, createdBy int, maxUses int, expiresInHours int, now time.Time) (*Invitation, error) {
+	if maxUses == 0 {
+		maxUses = 1
+	}
+	if maxUses < 1 || maxUses > MaxInvitationUses {
+		return nil, ErrInvalidInvitationUses
+	}
+	if expiresInHours == 0 {
+		expiresInHours = DefaultInvitationHours
+	}
+	if expiresInHours < 1 || expiresInHours > MaxInvitationHours {
+		return nil, ErrInvalidInvitationExpiry
+	}
+	token, err := newInvitationToken()
+	if err != nil {
+		return nil, err
+	}
+	return &Invitation{
+		GroupId:   groupId,
+		Token:     token,
+		CreatedBy: &createdBy,
+		MaxUses:   maxUses,
+		ExpiresAt: now.Add(time.Duration(expiresInHours) * time.Hour),
+		CreatedAt: now,
+	}, nil
+}
+
+// CanAccept returns why the invitation can no longer be used, if it cannot.
+func (i Invitation) CanAccept(now time.Time) error {
+	if i.RevokedAt != nil {
+		return ErrInvitationRevoked
+	}
+	if !now.Before(i.ExpiresAt) {
+		return ErrInvitationExpired
+	}
+	if i.Uses >= i.MaxUses {
+		return ErrInvitationUsedUp
+	}
+	return nil
+}
+
+func newInvitationToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,63 @@
+package model
+
+import "errors"
+
+// Roles of a user within a group. Owners can do everything admins can, and
+// only they can delete the group or hand out the owner role. Admins manage the
+// group's details and members.
+const (
+	RoleOwner  = "owner"
+	RoleAdmin  = "admin"
+	RoleMember = "member"
+)
+
+var Roles = []string{RoleOwner, RoleAdmin, RoleMember}
+
+var (
+	ErrInvalidRole = errors.New("role must be one of owner, admin, member")
+	ErrLastOwner   = errors.New("a group must keep at least one owner")
+)
+
+func ValidateRole(role string) error {
+	for _, r := range Roles {
+		if r == role {
+			return nil
+		}
+	}
+	return ErrInvalidRole
+}
+
+// CanManage reports whether the role may change the group and its members.
+func CanManage(role string) bool {
+	return role == RoleOwner || role == RoleAdmin
+}
+
+// CanAssignRole reports whether a member with callerRole may change a member
+// with currentRole to newRole. Only owners can grant or take away ownership.
+func CanAssignRole(callerRole, currentRole, newRole string) bool {
+	if currentRole == RoleOwner || newRole == RoleOwner {
+		return callerRole == RoleOwner
+	}
+	return CanManage(callerRole)
+}
+
+// CanRemoveMember reports whether a member with callerRole may remove a member
+// with targetRole. Everyone may leave a group on their own.
+func CanRemoveMember(callerRole, targetRole string, self bool) bool {
+	if self {
+		return true
+	}
+	if targetRole == RoleOwner {
+		return callerRole == RoleOwner
+	}
+	return CanManage(callerRole)
+}
+
+// CheckOwnerRemains returns ErrLastOwner when a member with currentRole is the
+// last of the group's owners and is about to lose the role.
+func CheckOwnerRemains(currentRole string, owners int) error {
+	if currentRole == RoleOwner && owners <= 1 {
+		return ErrLastOwner
+	}
+	return nil
+}
@@ -2,6 +2,11 @@ package model
 
 import "errors"
 
+// MaxArchiveAfterDays bounds the auto-archive setting to one year.
+const MaxArchiveAfterDays = 365
+
+var ErrInvalidArchiveAfterDays = errors.New("archive completed tasks after days must be between 1 and 365")
+
 type UserGroup struct {
 	GroupId   int    `json:"id"`
 	GroupName string `json:"name"`
@@ -9,6 +14,9 @@ type UserGroup struct {
 	Type      string `json:"type"`
 	Thumbnail string `json:"thumbnailurl"`
 	IsActive  bool   `json:"is_active"`
+
+	// ArchiveCompletedAfterDays is nil when completed tasks are never archived.
+	ArchiveCompletedAfterDays *int `json:"archive_completed_after_days"`
 }
 
 func NewUserGroup(name, desc, groupType string) (*UserGroup, error) {
@@ -26,3 +34,38 @@ func NewUserGroup(name, desc, groupType string) (*UserGroup, error) {
 		Thumbnail: "",
 	}, nil
 }
+
+// Update changes the details of the group with the same validation as
+// NewUserGroup. It returns the names of the fields that changed.
+func (ug *UserGroup) Update(name, desc, groupType, thumbnail string) ([]string, error) {
+	updated, err := NewUserGroup(name, desc, groupType)
+	if err != nil {
+		return nil, err
+	}
+	var changed []string
+	if ug.GroupName != updated.GroupName {
+		changed = append(changed, "name")
+	}
+	if ug.GroupDesc != updated.GroupDesc {
+		changed = append(changed, "description")
+	}
+	if ug.Type != updated.Type {
+		changed = append(changed, "type")
+	}
+	if ug.Thumbnail != thumbnail {
+		changed = append(changed, "thumbnailurl")
+	}
+	ug.GroupName = updated.GroupName
+	ug.GroupDesc = updated.GroupDesc
+	ug.Type = updated.Type
+	ug.Thumbnail = thumbnail
+	return changed, nil
+}
+
+func (ug *UserGroup) SetArchiveCompletedAfterDays(days *int) error {
+	if days != nil && (*days < 1 || *days > MaxArchiveAfterDays) {
+		return ErrInvalidArchiveAfterDays
+	}
+	ug.ArchiveCompletedAfterDays = days
+	return nil
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -111,3 +112,214 @@ func TestNewUserGroup(t *testing.T) {
 		assert.Equal(t, groupType, userGroup.Type)
 	})
 }
+
+func TestSetArchiveCompletedAfterDays(t *testing.T) {
+	t.Run("when setting valid days, Then setting is stored", func(t *testing.T) {
+		// Given
+		userGroup, _ := NewUserGroup("Family", "", "family")
+		days := 7
+
+		// When
+		err := userGroup.SetArchiveCompletedAfterDays(&days)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, &days, userGroup.ArchiveCompletedAfterDays)
+	})
+
+	t.Run("when setting nil, Then auto archive is disabled", func(t *testing.T) {
+		// Given
+		days := 7
+		userGroup := UserGroup{ArchiveCompletedAfterDays: &days}
+
+		// When
+		err := userGroup.SetArchiveCompletedAfterDays(nil)
+
+		// Then
+		require.NoError(t, err)
+		assert.Nil(t, userGroup.ArchiveCompletedAfterDays)
+	})
+
+	t.Run("when setting zero days, Then return error", func(t *testing.T) {
+		// Given
+		userGroup := UserGroup{}
+		days := 0
+
+		// When
+		err := userGroup.SetArchiveCompletedAfterDays(&days)
+
+		// Then
+		assert.ErrorIs(t, err, ErrInvalidArchiveAfterDays)
+		assert.Nil(t, userGroup.ArchiveCompletedAfterDays)
+	})
+}
+
+func TestUpdateUserGroup(t *testing.T) {
+	t.Run("when updating name and thumbnail, Then return only the changed fields", func(t *testing.T) {
+		// Given
+		userGroup := UserGroup{GroupId: 1, GroupName: "Family", GroupDesc: "Our home", Type: "normal", IsActive: true}
+
+		// When
+		changed, err := userGroup.Update("Kim family", "Our home", "normal", "https://example.com/family.png")
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"name", "thumbnailurl"}, changed)
+		assert.Equal(t, "Kim family", userGroup.GroupName)
+		assert.Equal(t, "https://example.com/family.png", userGroup.Thumbnail)
+		assert.Equal(t, 1, userGroup.GroupId)
+		assert.True(t, userGroup.IsActive)
+	})
+
+	t.Run("when updating with empty name, Then return error and keep the group unchanged", func(t *testing.T) {
+		// Given
+		userGroup := UserGroup{GroupName: "Family", Type: "normal"}
+
+		// When
+		changed, err := userGroup.Update("", "desc", "normal", "")
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, changed)
+		assert.Equal(t, "Family", userGroup.GroupName)
+		assert.Equal(t, "", userGroup.GroupDesc)
+	})
+
+	t.Run("when nothing changes, Then return no changed fields", func(t *testing.T) {
+		// Given
+		userGroup := UserGroup{GroupName: "Family", GroupDesc: "Our home", Type: "normal"}
+
+		// When
+		changed, err := userGroup.Update("Family", "Our home", "normal", "")
+
+		// Then
+		require.NoError(t, err)
+		assert.Empty(t, changed)
+	})
+}
+
+func TestNewGroupActivity(t *testing.T) {
+	t.Run("when creating group activity with valid data, Then return activity", func(t *testing.T) {
+		// Given
+		userId := 2
+
+		// When
+		activity, err := NewGroupActivity(1, &userId, ActivityUpdated, "changed name")
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, 1, activity.GroupId)
+		assert.Equal(t, &userId, activity.UserId)
+		assert.Equal(t, ActivityUpdated, activity.Action)
+		assert.False(t, activity.CreatedAt.IsZero())
+	})
+
+	t.Run("when creating group activity without action, Then return error", func(t *testing.T) {
+		// When
+		activity, err := NewGroupActivity(1, nil, "", "")
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, activity)
+	})
+}
+
+func TestMemberRoles(t *testing.T) {
+	t.Run("when validating an unknown role, Then return error", func(t *testing.T) {
+		assert.NoError(t, ValidateRole(RoleOwner))
+		assert.ErrorIs(t, ValidateRole("moderator"), ErrInvalidRole)
+	})
+
+	t.Run("when admin changes a member to admin, Then it is allowed", func(t *testing.T) {
+		assert.True(t, CanAssignRole(RoleAdmin, RoleMember, RoleAdmin))
+	})
+
+	t.Run("when admin grants or takes away ownership, Then it is not allowed", func(t *testing.T) {
+		assert.False(t, CanAssignRole(RoleAdmin, RoleMember, RoleOwner))
+		assert.False(t, CanAssignRole(RoleAdmin, RoleOwner, RoleMember))
+		assert.True(t, CanAssignRole(RoleOwner, RoleOwner, RoleAdmin))
+	})
+
+	t.Run("when member changes roles, Then it is not allowed", func(t *testing.T) {
+		assert.False(t, CanAssignRole(RoleMember, RoleMember, RoleAdmin))
+	})
+
+	t.Run("when removing members, Then admins remove members and only owners remove owners", func(t *testing.T) {
+		assert.True(t, CanRemoveMember(RoleAdmin, RoleMember, false))
+		assert.False(t, CanRemoveMember(RoleAdmin, RoleOwner, false))
+		assert.False(t, CanRemoveMember(RoleMember, RoleMember, false))
+		assert.True(t, CanRemoveMember(RoleMember, RoleMember, true))
+	})
+
+	t.Run("when the last owner loses the role, Then return error", func(t *testing.T) {
+		assert.ErrorIs(t, CheckOwnerRemains(RoleOwner, 1), ErrLastOwner)
+		assert.NoError(t, CheckOwnerRemains(RoleOwner, 2))
+		assert.NoError(t, CheckOwnerRemains(RoleAdmin, 1))
+	})
+}
+
+func TestNewInvitation(t *testing.T) {
+	now := time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)
+
+	t.Run("when no uses and expiry are given, Then return a single-use invitation valid for a week", func(t *testing.T) {
+		// When
+		invitation, err := NewInvitation(1, 2, 0, 0, now)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, 1, invitation.MaxUses)
+		assert.Equal(t, now.Add(7*24*time.Hour), invitation.ExpiresAt)
+		assert.NotEmpty(t, invitation.Token)
+		assert.Equal(t, 2, *invitation.CreatedBy)
+	})
+
+	t.Run("when creating two invitations, Then their tokens differ", func(t *testing.T) {
+		// When
+		first, err := NewInvitation(1, 2, 5, 24, now)
+		require.NoError(t, err)
+		second, err := NewInvitation(1, 2, 5, 24, now)
+		require.NoError(t, err)
+
+		// Then
+		assert.NotEqual(t, first.Token, second.Token)
+	})
+
+	t.Run("when max uses is out of range, Then return error", func(t *testing.T) {
+		_, err := NewInvitation(1, 2, MaxInvitationUses+1, 24, now)
+
+		assert.ErrorIs(t, err, ErrInvalidInvitationUses)
+	})
+
+	t.Run("when expiry is out of range, Then return error", func(t *testing.T) {
+		_, err := NewInvitation(1, 2, 1, -1, now)
+
+		assert.ErrorIs(t, err, ErrInvalidInvitationExpiry)
+	})
+}
+
+func TestInvitationCanAccept(t *testing.T) {
+	now := time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)
+	invitation := Invitation{MaxUses: 2, Uses: 1, ExpiresAt: now.Add(time.Hour)}
+
+	t.Run("when invitation is outstanding, Then it can be accepted", func(t *testing.T) {
+		assert.NoError(t, invitation.CanAccept(now))
+	})
+
+	t.Run("when invitation has expired, Then return error", func(t *testing.T) {
+		assert.ErrorIs(t, invitation.CanAccept(now.Add(time.Hour)), ErrInvitationExpired)
+	})
+
+	t.Run("when invitation is used up, Then return error", func(t *testing.T) {
+		usedUp := invitation
+		usedUp.Uses = 2
+
+		assert.ErrorIs(t, usedUp.CanAccept(now), ErrInvitationUsedUp)
+	})
+
+	t.Run("when invitation is revoked, Then return error", func(t *testing.T) {
+		revoked := invitation
+		revoked.RevokedAt = &now
+
+		assert.ErrorIs(t, revoked.CanAccept(now), ErrInvitationRevoked)
+	})
+}
@@ -18,7 +18,20 @@ type UserGroupRepository interface {
 	CreateGroupWithUsers(ug model.UserGroup, userId int) (int, error)
 	InsertUserGroupUserTable(groupId int, userId int) error
 	RemoveUserFromUserGroup(groupId int, userId int) error
+	IsUserInGroup(groupId int, userId int) (bool, error)
+	IsGroupAdmin(groupId int, userId int) (bool, error)
+	GetMemberRole(groupId int, userId int) (string, error)
+	CountOwners(groupId int) (int, error)
+	UpdateMemberRole(groupId int, userId int, role string, activity model.GroupActivity) error
+	UpdateGroup(ug model.UserGroup, activity model.GroupActivity) error
+	GetGroupActivities(groupId int) ([]model.GroupActivity, error)
+	UpdateArchiveSetting(groupId int, days *int) error
 	DeleteUserGroup(id int) error
+	CreateInvitation(invitation model.Invitation) (string, error)
+	GetInvitationByToken(token string) (*model.Invitation, error)
+	GetOutstandingInvitations(groupId int, now time.Time) ([]model.Invitation, error)
+	RevokeInvitation(groupId int, id string, now time.Time) error
+	AcceptInvitation(id string, groupId int, userId int, now time.Time) (bool, error)
 }
 type UserGroupRepo struct {
 	DB *sql.DB
@@ -113,7 +126,8 @@ func (m *UserGroupRepo) CreateGroupWithUsers(ug model.UserGroup, userId int) (in
 		return 0, fmt.Errorf("unable to insert into usergroup table : %w", err)
 	}
 
-	_, err = tx.Exec(sqlAddUserToUserGroup, lastInsertedId, userId)
+	// The creator owns the group.
+	_, err = tx.Exec(sqlAddUserToUserGroup, lastInsertedId, userId, model.RoleOwner)
 	if err != nil {
 		return 0, err
 	}
@@ -124,15 +138,27 @@ func (m *UserGroupRepo) CreateGroupWithUsers(ug model.UserGroup, userId int) (in
 }
 
 func (m *UserGroupRepo) InsertUserGroupUserTable(groupId int, userId int) error {
-	_, err := m.DB.Exec(sqlAddUserToUserGroup, groupId, userId)
+	_, err := m.DB.Exec(sqlAddUserToUserGroup, groupId, userId, model.RoleMember)
 	if err != nil {
 		return fmt.Errorf("unable to insert into usergroup_user table : %w", err)
 	}
 	return nil
 }
 
+// RemoveUserFromUserGroup drops the membership together with everything that
+// only makes sense for members, such as watching the group's tasks.
 func (m *UserGroupRepo) RemoveUserFromUserGroup(groupId int, userId int) error {
-	result, err := m.DB.Exec(sqlRemoveUserFromUserGroup, groupId, userId)
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	result, err := tx.Exec(sqlRemoveUserFromUserGroup, groupId, userId)
 	if err != nil {
 		return fmt.Errorf("unable to delete user from usergroup_user table : %w", err)
 	}
@@ -140,6 +166,124 @@ func (m *UserGroupRepo) RemoveUserFromUserGroup(groupId int, userId int) error {
 	if id == 0 {
 		fmt.Printf("none of the data has been removed")
 	}
+	_, err = tx.Exec(sqlRemoveUserFromTaskWatchers, groupId, userId)
+	if err != nil {
+		return fmt.Errorf("unable to delete user from task_watcher table : %w", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *UserGroupRepo) IsUserInGroup(groupId int, userId int) (bool, error) {
+	var exists bool
+	err := m.DB.QueryRow(sqlIsUserInUserGroup, groupId, userId).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+func (m *UserGroupRepo) IsGroupAdmin(groupId int, userId int) (bool, error) {
+	var isAdmin bool
+	err := m.DB.QueryRow(sqlIsUserGroupAdmin, groupId, userId).Scan(&isAdmin)
+	if err != nil {
+		return false, err
+	}
+	return isAdmin, nil
+}
+
+// GetMemberRole returns an empty role when the user is not a member of the group.
+func (m *UserGroupRepo) GetMemberRole(groupId int, userId int) (string, error) {
+	var role string
+	err := m.DB.QueryRow(sqlGetMemberRole, groupId, userId).Scan(&role)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return role, nil
+}
+
+func (m *UserGroupRepo) CountOwners(groupId int) (int, error) {
+	var owners int
+	err := m.DB.QueryRow(sqlCountGroupOwners, groupId).Scan(&owners)
+	if err != nil {
+		return 0, err
+	}
+	return owners, nil
+}
+
+// UpdateMemberRole changes the role of the member and records the change in
+// the group's activity history.
+func (m *UserGroupRepo) UpdateMemberRole(groupId int, userId int, role string, activity model.GroupActivity) error {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.Exec(sqlUpdateMemberRole, groupId, userId, role); err != nil {
+		return fmt.Errorf("unable to update usergroup_user role : %w", err)
+	}
+	if _, err = tx.Exec(sqlCreateGroupActivity, activity.GroupId, activity.UserId, activity.Action, activity.Detail, activity.CreatedAt); err != nil {
+		return fmt.Errorf("unable to insert into usergroup_activity table : %w", err)
+	}
+	return tx.Commit()
+}
+
+// UpdateGroup saves the details of the group and records the change in the
+// group's activity history.
+func (m *UserGroupRepo) UpdateGroup(ug model.UserGroup, activity model.GroupActivity) error {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.Exec(sqlUpdateUserGroup, ug.GroupId, ug.GroupName, ug.GroupDesc, ug.Type, ug.Thumbnail); err != nil {
+		return fmt.Errorf("unable to update usergroup table : %w", err)
+	}
+	if _, err = tx.Exec(sqlCreateGroupActivity, activity.GroupId, activity.UserId, activity.Action, activity.Detail, activity.CreatedAt); err != nil {
+		return fmt.Errorf("unable to insert into usergroup_activity table : %w", err)
+	}
+	return tx.Commit()
+}
+
+func (m *UserGroupRepo) GetGroupActivities(groupId int) ([]model.GroupActivity, error) {
+	rows, err := m.DB.Query(sqlGetGroupActivities, groupId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	activities := []model.GroupActivity{}
+	for rows.Next() {
+		var activity model.GroupActivity
+		err := rows.Scan(&activity.Id, &activity.GroupId, &activity.UserId, &activity.Action, &activity.Detail, &activity.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		activities = append(activities, activity)
+	}
+	return activities, nil
+}
+
+func (m *UserGroupRepo) UpdateArchiveSetting(groupId int, days *int) error {
+	_, err := m.DB.Exec(sqlUpdateArchiveSetting, groupId, days)
+	if err != nil {
+		return fmt.Errorf("unable to update usergroup archive setting : %w", err)
+	}
 	return nil
 }
 
@@ -155,6 +299,114 @@ func (m *UserGroupRepo) DeleteUserGroup(groupId int) error {
 	return nil
 }
 
+func (m *UserGroupRepo) CreateInvitation(i model.Invitation) (string, error) {
+	var id string
+	err := m.DB.QueryRow(sqlCreateInvitation, i.GroupId, i.Token, i.CreatedBy, i.MaxUses, i.ExpiresAt, i.CreatedAt).Scan(&id)
+	if err != nil {
+		return "", fmt.Errorf("unable to insert into usergroup_invitation table : %w", err)
+	}
+	return id, nil
+}
+
+// GetInvitationByToken returns sql.ErrNoRows when no invitation has the token.
+func (m *UserGroupRepo) GetInvitationByToken(token string) (*model.Invitation, error) {
+	rows, err := m.DB.Query(sqlGetInvitationByToken, token)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, sql.ErrNoRows
+	}
+	return scanRowsIntoInvitation(rows)
+}
+
+// GetOutstandingInvitations lists the invitations of the group that can still be accepted.
+func (m *UserGroupRepo) GetOutstandingInvitations(groupId int, now time.Time) ([]model.Invitation, error) {
+	rows, err := m.DB.Query(sqlGetOutstandingInvitations, groupId, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invitations := []model.Invitation{}
+	for rows.Next() {
+		invitation, err := scanRowsIntoInvitation(rows)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, *invitation)
+	}
+	return invitations, nil
+}
+
+// RevokeInvitation returns sql.ErrNoRows when the group has no such invitation
+// or it was already revoked.
+func (m *UserGroupRepo) RevokeInvitation(groupId int, id string, now time.Time) error {
+	result, err := m.DB.Exec(sqlRevokeInvitation, groupId, id, now)
+	if err != nil {
+		return fmt.Errorf("unable to revoke usergroup_invitation : %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// AcceptInvitation takes one use of the invitation and adds the user to the
+// group in the same transaction. It returns false without adding the user when
+// the invitation was revoked, expired or used up in the meantime.
+func (m *UserGroupRepo) AcceptInvitation(id string, groupId int, userId int, now time.Time) (bool, error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	result, err := tx.Exec(sqlClaimInvitation, id, now)
+	if err != nil {
+		return false, fmt.Errorf("unable to claim usergroup_invitation : %w", err)
+	}
+	claimed, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if claimed != 1 {
+		return false, tx.Rollback()
+	}
+	if _, err = tx.Exec(sqlAddUserToUserGroup, groupId, userId, model.RoleMember); err != nil {
+		return false, fmt.Errorf("unable to insert into usergroup_user table : %w", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func scanRowsIntoInvitation(rows *sql.Rows) (*model.Invitation, error) {
+	invitation := new(model.Invitation)
+	err := rows.Scan(
+		&invitation.Id,
+		&invitation.GroupId,
+		&invitation.Token,
+		&invitation.CreatedBy,
+		&invitation.MaxUses,
+		&invitation.Uses,
+		&invitation.ExpiresAt,
+		&invitation.RevokedAt,
+		&invitation.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
 func scanRowsIntoUsergroup(rows *sql.Rows) (*model.UserGroup, error) {
 	usergroup := new(model.UserGroup)
 	err := rows.Scan(
@@ -164,6 +416,7 @@ func scanRowsIntoUsergroup(rows *sql.Rows) (*model.UserGroup, error) {
 		&usergroup.Type,
 		&usergroup.Thumbnail,
 		&usergroup.IsActive,
+		&usergroup.ArchiveCompletedAfterDays,
 	)
 	if err != nil {
 		return nil, err
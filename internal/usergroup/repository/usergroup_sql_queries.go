@@ -1,9 +1,11 @@
 package repository
 
+const usergroupColumns = `ug.id, ug.name, ug.description, ug.type, ug.thumbnailurl, ug.is_active, ug.archive_completed_after_days`
+
 const (
-	sqlGetAllUsergroups      = `SELECT * FROM public.usergroup`
-	sqlGetById               = `SELECT * FROM public.usergroup WHERE id = $1`
-	sqlGetUserGroupsByUserId = `SELECT ug.id, ug.name, ug.description, ug.type, ug.thumbnailurl, ug.is_active
+	sqlGetAllUsergroups      = `SELECT ` + usergroupColumns + ` FROM public.usergroup ug`
+	sqlGetById               = `SELECT ` + usergroupColumns + ` FROM public.usergroup ug WHERE ug.id = $1`
+	sqlGetUserGroupsByUserId = `SELECT ` + usergroupColumns + `
 								FROM public.usergroup ug
 								INNER JOIN public.usergroup_user ugu
 								ON ug.id = ugu.usergroup_id
@@ -12,8 +14,41 @@ const (
 	sqlCreateUserGroup = `INSERT INTO public.usergroup(name, description, type, thumbnailurl, is_active)
 							VALUES ($1, $2, $3, $4, $5) RETURNING id;`
 
-	sqlAddUserToUserGroup      = `INSERT INTO public.usergroup_user(usergroup_id, user_id) VALUES ($1, $2)`
-	sqlRemoveUserFromUserGroup = `DELETE FROM public.usergroup_user WHERE usergroup_id = $1 AND user_id = $2`
+	sqlAddUserToUserGroup         = `INSERT INTO public.usergroup_user(usergroup_id, user_id, role) VALUES ($1, $2, $3)`
+	sqlRemoveUserFromUserGroup    = `DELETE FROM public.usergroup_user WHERE usergroup_id = $1 AND user_id = $2`
+	sqlIsUserInUserGroup          = `SELECT EXISTS(SELECT 1 FROM public.usergroup_user WHERE usergroup_id = $1 AND user_id = $2)`
+	sqlIsUserGroupAdmin           = `SELECT EXISTS(SELECT 1 FROM public.usergroup_user WHERE usergroup_id = $1 AND user_id = $2 AND role IN ('owner', 'admin'))`
+	sqlGetMemberRole              = `SELECT role FROM public.usergroup_user WHERE usergroup_id = $1 AND user_id = $2`
+	sqlCountGroupOwners           = `SELECT COUNT(*) FROM public.usergroup_user WHERE usergroup_id = $1 AND role = 'owner'`
+	sqlUpdateMemberRole           = `UPDATE public.usergroup_user SET role = $3 WHERE usergroup_id = $1 AND user_id = $2`
+	sqlRemoveUserFromTaskWatchers = `DELETE FROM public.task_watcher tw
+									USING public.taskcontainer_task tct, public.taskcontainer tc
+									WHERE tw.task_id = tct.task_id AND tct.taskcontainer_id = tc.id
+									AND tc.usergroup_id = $1 AND tw.user_id = $2`
+
+	sqlUpdateUserGroup      = `UPDATE public.usergroup SET name = $2, description = $3, type = $4, thumbnailurl = $5 WHERE id = $1`
+	sqlUpdateArchiveSetting = `UPDATE public.usergroup SET archive_completed_after_days = $2 WHERE id = $1`
+
+	sqlCreateGroupActivity = `INSERT INTO public.usergroup_activity(usergroup_id, user_id, action, detail, created_at)
+								VALUES ($1, $2, $3, $4, $5)`
+	sqlGetGroupActivities = `SELECT id, usergroup_id, user_id, action, detail, created_at
+								FROM public.usergroup_activity
+								WHERE usergroup_id = $1
+								ORDER BY created_at DESC`
 
 	sqlDeleteUserGroup = `DELETE FROM public.usergroup WHERE id = $1`
+
+	invitationColumns   = `id, usergroup_id, token, created_by, max_uses, uses, expires_at, revoked_at, created_at`
+	sqlCreateInvitation = `INSERT INTO public.usergroup_invitation(usergroup_id, token, created_by, max_uses, expires_at, created_at)
+								VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	sqlGetInvitationByToken = `SELECT ` + invitationColumns + ` FROM public.usergroup_invitation WHERE token = $1`
+	// $2 is the current time. Revoked, expired and used up invitations are left out.
+	sqlGetOutstandingInvitations = `SELECT ` + invitationColumns + ` FROM public.usergroup_invitation
+								WHERE usergroup_id = $1 AND revoked_at IS NULL AND expires_at > $2 AND uses < max_uses
+								ORDER BY created_at DESC`
+	sqlRevokeInvitation = `UPDATE public.usergroup_invitation SET revoked_at = $3
+								WHERE id = $2 AND usergroup_id = $1 AND revoked_at IS NULL`
+	// The conditions make concurrent accepts of the last use fail instead of exceeding max_uses.
+	sqlClaimInvitation = `UPDATE public.usergroup_invitation SET uses = uses + 1
+								WHERE id = $1 AND revoked_at IS NULL AND expires_at > $2 AND uses < max_uses`
 )
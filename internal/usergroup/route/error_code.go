@@ -14,6 +14,19 @@ const (
 	UserGroupCreationFailure = prefix + "create_error"
 
 	UserGroupAddUserError = prefix + "add_user_error"
+
+	UserGroupSettingsError  = prefix + "settings_server_error"
+	UserGroupNotMember      = prefix + "not_group_member"
+	UserGroupNotAdmin       = prefix + "not_group_admin"
+	UserGroupForbidden      = prefix + "forbidden"
+	UserGroupRoleError      = prefix + "role_server_error"
+	UserGroupMemberNotFound = prefix + "member_not_found"
+	UserGroupAlreadyMember  = prefix + "already_member"
+
+	UserGroupInvitationError    = prefix + "invitation_server_error"
+	UserGroupInvitationNotFound = prefix + "invitation_not_found"
+	UserGroupUpdateError        = prefix + "update_server_error"
+	UserGroupActivityError      = prefix + "activity_server_error"
 	// UserCreateInvalidInput = prefix + "create_invalid_input"
 	// UserCreateUnauthorized = prefix + "create_unauthorized"
 	// UserCreateServerError  = prefix + "create_server_error"
@@ -1,13 +1,17 @@
 package route
 
 import (
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/jwtauth"
+	userModel "github.com/happYness-Project/taskManagementGolang/internal/user/model"
 	userRepo "github.com/happYness-Project/taskManagementGolang/internal/user/repository"
 	userGroupRepo "github.com/happYness-Project/taskManagementGolang/internal/usergroup/repository"
 
@@ -19,25 +23,42 @@ import (
 	"github.com/happYness-Project/taskManagementGolang/pkg/response"
 )
 
+// MembershipListener is told when a member is added to or removed from a group
+// through these routes, so other modules can follow the membership.
+type MembershipListener interface {
+	MemberJoined(groupId int, userId int)
+	MemberLeft(groupId int, userId int)
+}
+
 type Handler struct {
 	logger    *loggers.AppLogger
 	groupRepo userGroupRepo.UserGroupRepository
 	userRepo  userRepo.UserRepository
+	members   MembershipListener
 }
 
-func NewHandler(logger *loggers.AppLogger, repo repository.UserGroupRepository, userRepo userRepo.UserRepository) *Handler {
-	return &Handler{logger: logger, groupRepo: repo, userRepo: userRepo}
+func NewHandler(logger *loggers.AppLogger, repo repository.UserGroupRepository, userRepo userRepo.UserRepository, members MembershipListener) *Handler {
+	return &Handler{logger: logger, groupRepo: repo, userRepo: userRepo, members: members}
 }
 func (h *Handler) RegisterRoutes(router chi.Router) {
 	router.Route("/api/user-groups", func(r chi.Router) {
 		r.Get("/", h.handleGetUserGroups)
 		r.Get("/{groupID}", h.handleGetUserGroupById)
+		r.Put("/{groupID}", h.handleUpdateUserGroup)
+		r.Patch("/{groupID}", h.handlePatchUserGroup)
+		r.Get("/{groupID}/activities", h.handleGetUserGroupActivities)
 		r.Delete("/{groupID}", h.handleDeleteUserGroup)
+		r.Put("/{groupID}/settings", h.handleUpdateUserGroupSettings)
 		r.Post("/{groupID}/users", h.handleAddUserToGroup)
 		r.Put("/{groupID}/users/{userID}", h.handleRemoveUserFromGroup)
+		r.Patch("/{groupID}/users/{userID}/role", h.handleChangeMemberRole)
+		r.Post("/{groupID}/invitations", h.handleCreateInvitation)
+		r.Get("/{groupID}/invitations", h.handleGetInvitations)
+		r.Delete("/{groupID}/invitations/{invitationID}", h.handleRevokeInvitation)
 	})
 	router.Post("/api/user-groups", h.handleCreateUserGroup)
 	router.Get("/api/users/{userID}/user-groups", h.handleGetUserGroupByUserId)
+	router.Post("/api/invitations/{token}/accept", h.handleAcceptInvitation)
 
 }
 
@@ -150,6 +171,9 @@ func (h *Handler) handleAddUserToGroup(w http.ResponseWriter, r *http.Request) {
 		response.InvalidJsonBody(w, err.Error())
 		return
 	}
+	if _, ok := h.requireManager(w, r, groupId); !ok {
+		return
+	}
 	user, err := h.userRepo.GetUserByUserId(jsonBody.UserId)
 	if err != nil || user == nil {
 		h.logger.Error().Err(err).Str("ErrorCode", UserNotFound)
@@ -163,6 +187,7 @@ func (h *Handler) handleAddUserToGroup(w http.ResponseWriter, r *http.Request) {
 		response.ErrorResponse(w, http.StatusBadRequest, *response.New(UserGroupAddUserError, "Bad Request", "Inserting usergroup failed"))
 		return
 	}
+	h.members.MemberJoined(groupId, user.Id)
 
 	response.WriteJsonWithEncode(w, http.StatusCreated, fmt.Sprintf("User is added to the user group ID: %d", groupId))
 }
@@ -174,6 +199,15 @@ func (h *Handler) handleDeleteUserGroup(w http.ResponseWriter, r *http.Request)
 		response.BadRequestMissingParameters(w, "invalid groupId")
 		return
 	}
+	_, role, ok := h.callerRole(w, r, groupId)
+	if !ok {
+		return
+	}
+	if role != model.RoleOwner {
+		h.logger.Error().Str("ErrorCode", UserGroupForbidden).Msg("user is not an owner of the group")
+		response.ErrorResponse(w, http.StatusForbidden, *response.New(UserGroupForbidden, errors.PermissionDenied, "only group owners can delete the group"))
+		return
+	}
 	err = h.groupRepo.DeleteUserGroup(groupId)
 	if err != nil {
 		h.logger.Error().Err(err).Str("ErrorCode", DeleteUserGroupError).Msg(err.Error())
@@ -197,11 +231,20 @@ func (h *Handler) handleRemoveUserFromGroup(w http.ResponseWriter, r *http.Reque
 		response.ErrorResponse(w, http.StatusBadRequest, *(response.New(constants.InvalidParameter, "Invalid Parameter", "Invalid Group ID")))
 		return
 	}
-	userId := chi.URLParam(r, "userID")
-	user, err := h.userRepo.GetUserByUserId(userId)
-	if err != nil || user == nil {
-		h.logger.Error().Err(err).Str("ErrorCode", UserNotFound).Msg(err.Error())
-		response.NotFound(w, UserNotFound, "Provided user id cannot be found")
+	caller, callerRole, ok := h.callerRole(w, r, groupId)
+	if !ok {
+		return
+	}
+	user, targetRole, ok := h.memberRole(w, groupId, chi.URLParam(r, "userID"))
+	if !ok {
+		return
+	}
+	if !model.CanRemoveMember(callerRole, targetRole, caller.Id == user.Id) {
+		h.logger.Error().Str("ErrorCode", UserGroupForbidden).Msg("user cannot remove this member from the group")
+		response.ErrorResponse(w, http.StatusForbidden, *response.New(UserGroupForbidden, errors.PermissionDenied, "only group admins can remove members and only owners can remove owners"))
+		return
+	}
+	if !h.checkOwnerRemains(w, groupId, targetRole) {
 		return
 	}
 
@@ -211,5 +254,457 @@ func (h *Handler) handleRemoveUserFromGroup(w http.ResponseWriter, r *http.Reque
 		response.ErrorResponse(w, http.StatusBadRequest, *(response.New(RemoveUserFromUserGroupError, "Bad Request", "Failed to remove a user from usergroup.")))
 		return
 	}
+	h.members.MemberLeft(groupId, user.Id)
 	response.SuccessJson(w, nil, fmt.Sprintf("User is removed from user group ID: %d", groupId), 204)
 }
+
+func (h *Handler) handleChangeMemberRole(w http.ResponseWriter, r *http.Request) {
+	groupId, err := strconv.Atoi(chi.URLParam(r, "groupID"))
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.InvalidParameter).Msg("invalid Group Id")
+		response.BadRequestMissingParameters(w)
+		return
+	}
+	var roleDto MemberRoleDto
+	if err := response.ParseJson(r, &roleDto); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.RequestBodyError).Msg("Invalid JSON body for MemberRoleDto")
+		response.InvalidJsonBody(w, err.Error())
+		return
+	}
+	if err = model.ValidateRole(roleDto.Role); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", UserGroupDomainError).Msg(err.Error())
+		response.ErrorResponse(w, http.StatusUnprocessableEntity, *response.New(UserGroupDomainError, "Domain Validation Error", err.Error()))
+		return
+	}
+	caller, callerRole, ok := h.callerRole(w, r, groupId)
+	if !ok {
+		return
+	}
+	user, currentRole, ok := h.memberRole(w, groupId, chi.URLParam(r, "userID"))
+	if !ok {
+		return
+	}
+	roleDto.UserId = user.UserId
+	if currentRole == roleDto.Role {
+		response.WriteJsonWithEncode(w, http.StatusOK, roleDto)
+		return
+	}
+	if !model.CanAssignRole(callerRole, currentRole, roleDto.Role) {
+		h.logger.Error().Str("ErrorCode", UserGroupForbidden).Msg("user cannot change the role of this member")
+		response.ErrorResponse(w, http.StatusForbidden, *response.New(UserGroupForbidden, errors.PermissionDenied, "only group admins can change roles and only owners can grant or take away ownership"))
+		return
+	}
+	if !h.checkOwnerRemains(w, groupId, currentRole) {
+		return
+	}
+
+	activity, err := model.NewGroupActivity(groupId, &caller.Id, model.ActivityRoleChanged, fmt.Sprintf("changed role of %s from %s to %s", user.UserId, currentRole, roleDto.Role))
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", UserGroupDomainError).Msg(err.Error())
+		response.ErrorResponse(w, http.StatusUnprocessableEntity, *response.New(UserGroupDomainError, "Domain Validation Error", err.Error()))
+		return
+	}
+	if err = h.groupRepo.UpdateMemberRole(groupId, user.Id, roleDto.Role, *activity); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", UserGroupRoleError).Msg("Error occurred during UpdateMemberRole")
+		response.InternalServerError(w, "Failed to change member role")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusOK, roleDto)
+}
+
+// handleCreateInvitation lets group admins create an invitation token that
+// users can accept to join the group.
+func (h *Handler) handleCreateInvitation(w http.ResponseWriter, r *http.Request) {
+	groupId, err := strconv.Atoi(chi.URLParam(r, "groupID"))
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.InvalidParameter).Msg("invalid Group Id")
+		response.BadRequestMissingParameters(w)
+		return
+	}
+	var invitationDto CreateInvitationDto
+	if err := response.ParseJson(r, &invitationDto); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.RequestBodyError).Msg("Invalid JSON body for CreateInvitationDto")
+		response.InvalidJsonBody(w, err.Error())
+		return
+	}
+	caller, ok := h.requireManager(w, r, groupId)
+	if !ok {
+		return
+	}
+
+	invitation, err := model.NewInvitation(groupId, caller.Id, invitationDto.MaxUses, invitationDto.ExpiresInHours, time.Now().UTC())
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", UserGroupDomainError).Msg(err.Error())
+		response.ErrorResponse(w, http.StatusUnprocessableEntity, *response.New(UserGroupDomainError, "Domain Validation Error", err.Error()))
+		return
+	}
+	if invitation.Id, err = h.groupRepo.CreateInvitation(*invitation); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", UserGroupInvitationError).Msg("Error occurred during CreateInvitation")
+		response.InternalServerError(w, "Failed to create invitation")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusCreated, invitation)
+}
+
+func (h *Handler) handleGetInvitations(w http.ResponseWriter, r *http.Request) {
+	groupId, err := strconv.Atoi(chi.URLParam(r, "groupID"))
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.InvalidParameter).Msg("invalid Group Id")
+		response.BadRequestMissingParameters(w)
+		return
+	}
+	if _, ok := h.requireManager(w, r, groupId); !ok {
+		return
+	}
+	invitations, err := h.groupRepo.GetOutstandingInvitations(groupId, time.Now().UTC())
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", UserGroupInvitationError).Msg("Error occurred during GetOutstandingInvitations")
+		response.InternalServerError(w, "Failed to get invitations")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusOK, invitations)
+}
+
+func (h *Handler) handleRevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	groupId, err := strconv.Atoi(chi.URLParam(r, "groupID"))
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.InvalidParameter).Msg("invalid Group Id")
+		response.BadRequestMissingParameters(w)
+		return
+	}
+	if _, ok := h.requireManager(w, r, groupId); !ok {
+		return
+	}
+	err = h.groupRepo.RevokeInvitation(groupId, chi.URLParam(r, "invitationID"), time.Now().UTC())
+	if err == sql.ErrNoRows {
+		h.logger.Error().Str("ErrorCode", UserGroupInvitationNotFound).Msg("invitation cannot be found")
+		response.NotFound(w, UserGroupInvitationNotFound, "invitation cannot be found")
+		return
+	}
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", UserGroupInvitationError).Msg("Error occurred during RevokeInvitation")
+		response.InternalServerError(w, "Failed to revoke invitation")
+		return
+	}
+	response.SuccessJson(w, nil, "Invitation is revoked.", http.StatusNoContent)
+}
+
+// handleAcceptInvitation adds the caller to the group of the invitation.
+func (h *Handler) handleAcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	invitation, err := h.groupRepo.GetInvitationByToken(chi.URLParam(r, "token"))
+	if err == sql.ErrNoRows {
+		h.logger.Error().Str("ErrorCode", UserGroupInvitationNotFound).Msg("invitation cannot be found")
+		response.NotFound(w, UserGroupInvitationNotFound, "invitation cannot be found")
+		return
+	}
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", UserGroupInvitationError).Msg("Error occurred during GetInvitationByToken")
+		response.InternalServerError(w, "Failed to get invitation")
+		return
+	}
+	user, err := h.currentUser(r)
+	if err != nil || user == nil {
+		h.logger.Error().Err(err).Str("ErrorCode", UserNotFound).Msg("Not able to find user from token")
+		response.NotFound(w, UserNotFound, "cannot find an user")
+		return
+	}
+	isMember, err := h.groupRepo.IsUserInGroup(invitation.GroupId, user.Id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", UserGroupServerError).Msg("Error occurred during IsUserInGroup")
+		response.InternalServerError(w, "Failed to check group membership")
+		return
+	}
+	if isMember {
+		h.logger.Error().Str("ErrorCode", UserGroupAlreadyMember).Msg("user is already a member of the group")
+		response.ErrorResponse(w, http.StatusConflict, *response.New(UserGroupAlreadyMember, "Conflict", "user is already a member of the group"))
+		return
+	}
+
+	now := time.Now().UTC()
+	if err = invitation.CanAccept(now); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", UserGroupDomainError).Msg(err.Error())
+		response.ErrorResponse(w, http.StatusUnprocessableEntity, *response.New(UserGroupDomainError, "Domain Validation Error", err.Error()))
+		return
+	}
+	accepted, err := h.groupRepo.AcceptInvitation(invitation.Id, invitation.GroupId, user.Id, now)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", UserGroupInvitationError).Msg("Error occurred during AcceptInvitation")
+		response.InternalServerError(w, "Failed to accept invitation")
+		return
+	}
+	// Another user took the last use, or the invitation was revoked, since it was read.
+	if !accepted {
+		h.logger.Error().Str("ErrorCode", UserGroupDomainError).Msg(model.ErrInvitationUsedUp.Error())
+		response.ErrorResponse(w, http.StatusUnprocessableEntity, *response.New(UserGroupDomainError, "Domain Validation Error", model.ErrInvitationUsedUp.Error()))
+		return
+	}
+
+	response.SuccessJson(w, map[string]int{"group_id": invitation.GroupId}, "User joined the user group.", http.StatusOK)
+}
+
+func (h *Handler) handleUpdateUserGroupSettings(w http.ResponseWriter, r *http.Request) {
+	groupId, err := strconv.Atoi(chi.URLParam(r, "groupID"))
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.InvalidParameter).Msg("invalid Group Id")
+		response.BadRequestMissingParameters(w)
+		return
+	}
+	var settingsDto UpdateUserGroupSettingsDto
+	if err := response.ParseJson(r, &settingsDto); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.RequestBodyError).Msg("Invalid JSON body for UpdateUserGroupSettingsDto")
+		response.InvalidJsonBody(w, err.Error())
+		return
+	}
+	usergroup, err := h.groupRepo.GetById(groupId)
+	if err != nil || usergroup == nil || usergroup.GroupId == 0 {
+		h.logger.Error().Err(err).Str("ErrorCode", UserGroupGetNotFound).Msg("usergroup cannot be found")
+		response.NotFound(w, UserGroupGetNotFound, "usergroup cannot be found")
+		return
+	}
+	_, claims, _ := jwtauth.FromContext(r.Context())
+	user, err := h.userRepo.GetUserByUserId(fmt.Sprintf("%v", claims["nameid"]))
+	if err != nil || user == nil {
+		h.logger.Error().Err(err).Str("ErrorCode", UserNotFound).Msg("Not able to find user from token")
+		response.NotFound(w, UserNotFound, "cannot find an user")
+		return
+	}
+	isMember, err := h.groupRepo.IsUserInGroup(groupId, user.Id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", UserGroupServerError).Msg("Error occurred during IsUserInGroup")
+		response.InternalServerError(w, "Failed to check group membership")
+		return
+	}
+	if !isMember {
+		h.logger.Error().Str("ErrorCode", UserGroupNotMember).Msg("user is not a member of the group")
+		response.ErrorResponse(w, http.StatusForbidden, *response.New(UserGroupNotMember, errors.PermissionDenied, "only group members can change group settings"))
+		return
+	}
+
+	if err = usergroup.SetArchiveCompletedAfterDays(settingsDto.ArchiveCompletedAfterDays); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", UserGroupDomainError).Msg(err.Error())
+		response.ErrorResponse(w, http.StatusUnprocessableEntity, *response.New(UserGroupDomainError, "Domain Validation Error", err.Error()))
+		return
+	}
+	if err = h.groupRepo.UpdateArchiveSetting(usergroup.GroupId, usergroup.ArchiveCompletedAfterDays); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", UserGroupSettingsError).Msg("Error occurred during UpdateArchiveSetting")
+		response.InternalServerError(w, "Failed to update usergroup settings")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusOK, usergroup)
+}
+
+func (h *Handler) handleUpdateUserGroup(w http.ResponseWriter, r *http.Request) {
+	var updateDto UpdateUserGroupDto
+	if err := response.ParseJson(r, &updateDto); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.RequestBodyError).Msg("Invalid JSON body for UpdateUserGroupDto")
+		response.InvalidJsonBody(w, err.Error())
+		return
+	}
+	h.updateUserGroup(w, r, PatchUserGroupDto{
+		GroupName: &updateDto.GroupName,
+		GroupDesc: &updateDto.GroupDesc,
+		GroupType: &updateDto.GroupType,
+		Thumbnail: &updateDto.Thumbnail,
+	})
+}
+
+// handlePatchUserGroup changes only the fields present in the body.
+func (h *Handler) handlePatchUserGroup(w http.ResponseWriter, r *http.Request) {
+	var patchDto PatchUserGroupDto
+	if err := response.ParseJson(r, &patchDto); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.RequestBodyError).Msg("Invalid JSON body for PatchUserGroupDto")
+		response.InvalidJsonBody(w, err.Error())
+		return
+	}
+	h.updateUserGroup(w, r, patchDto)
+}
+
+func (h *Handler) updateUserGroup(w http.ResponseWriter, r *http.Request, changes PatchUserGroupDto) {
+	groupId, err := strconv.Atoi(chi.URLParam(r, "groupID"))
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.InvalidParameter).Msg("invalid Group Id")
+		response.BadRequestMissingParameters(w)
+		return
+	}
+	usergroup, err := h.groupRepo.GetById(groupId)
+	if err != nil || usergroup == nil || usergroup.GroupId == 0 {
+		h.logger.Error().Err(err).Str("ErrorCode", UserGroupGetNotFound).Msg("usergroup cannot be found")
+		response.NotFound(w, UserGroupGetNotFound, "usergroup cannot be found")
+		return
+	}
+	user, err := h.currentUser(r)
+	if err != nil || user == nil {
+		h.logger.Error().Err(err).Str("ErrorCode", UserNotFound).Msg("Not able to find user from token")
+		response.NotFound(w, UserNotFound, "cannot find an user")
+		return
+	}
+	isAdmin, err := h.groupRepo.IsGroupAdmin(groupId, user.Id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", UserGroupServerError).Msg("Error occurred during IsGroupAdmin")
+		response.InternalServerError(w, "Failed to check group role")
+		return
+	}
+	if !isAdmin {
+		h.logger.Error().Str("ErrorCode", UserGroupNotAdmin).Msg("user is not an admin of the group")
+		response.ErrorResponse(w, http.StatusForbidden, *response.New(UserGroupNotAdmin, errors.PermissionDenied, "only group admins can change group details"))
+		return
+	}
+
+	name, desc, groupType, thumbnail := usergroup.GroupName, usergroup.GroupDesc, usergroup.Type, usergroup.Thumbnail
+	if changes.GroupName != nil {
+		name = *changes.GroupName
+	}
+	if changes.GroupDesc != nil {
+		desc = *changes.GroupDesc
+	}
+	if changes.GroupType != nil {
+		groupType = *changes.GroupType
+	}
+	if changes.Thumbnail != nil {
+		thumbnail = *changes.Thumbnail
+	}
+	changed, err := usergroup.Update(name, desc, groupType, thumbnail)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", UserGroupDomainError).Msg(err.Error())
+		response.ErrorResponse(w, http.StatusUnprocessableEntity, *response.New(UserGroupDomainError, "Domain Validation Error", err.Error()))
+		return
+	}
+	// Nothing to save or to record in the history.
+	if len(changed) == 0 {
+		response.WriteJsonWithEncode(w, http.StatusOK, usergroup)
+		return
+	}
+
+	activity, err := model.NewGroupActivity(usergroup.GroupId, &user.Id, model.ActivityUpdated, "changed "+strings.Join(changed, ", "))
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", UserGroupDomainError).Msg(err.Error())
+		response.ErrorResponse(w, http.StatusUnprocessableEntity, *response.New(UserGroupDomainError, "Domain Validation Error", err.Error()))
+		return
+	}
+	if err = h.groupRepo.UpdateGroup(*usergroup, *activity); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", UserGroupUpdateError).Msg("Error occurred during UpdateGroup")
+		response.InternalServerError(w, "Failed to update usergroup")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusOK, usergroup)
+}
+
+func (h *Handler) handleGetUserGroupActivities(w http.ResponseWriter, r *http.Request) {
+	groupId, err := strconv.Atoi(chi.URLParam(r, "groupID"))
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", constants.InvalidParameter).Msg("invalid Group Id")
+		response.BadRequestMissingParameters(w)
+		return
+	}
+	user, err := h.currentUser(r)
+	if err != nil || user == nil {
+		h.logger.Error().Err(err).Str("ErrorCode", UserNotFound).Msg("Not able to find user from token")
+		response.NotFound(w, UserNotFound, "cannot find an user")
+		return
+	}
+	isMember, err := h.groupRepo.IsUserInGroup(groupId, user.Id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", UserGroupServerError).Msg("Error occurred during IsUserInGroup")
+		response.InternalServerError(w, "Failed to check group membership")
+		return
+	}
+	if !isMember {
+		h.logger.Error().Str("ErrorCode", UserGroupNotMember).Msg("user is not a member of the group")
+		response.ErrorResponse(w, http.StatusForbidden, *response.New(UserGroupNotMember, errors.PermissionDenied, "only group members can see the group history"))
+		return
+	}
+
+	activities, err := h.groupRepo.GetGroupActivities(groupId)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", UserGroupActivityError).Msg("Error occurred during GetGroupActivities")
+		response.InternalServerError(w, "Failed to get usergroup activities")
+		return
+	}
+	response.WriteJsonWithEncode(w, http.StatusOK, activities)
+}
+
+// callerRole resolves the caller and their role in the group. It writes the
+// error response and returns false when the caller is not a member.
+func (h *Handler) callerRole(w http.ResponseWriter, r *http.Request, groupId int) (*userModel.User, string, bool) {
+	caller, err := h.currentUser(r)
+	if err != nil || caller == nil {
+		h.logger.Error().Err(err).Str("ErrorCode", UserNotFound).Msg("Not able to find user from token")
+		response.NotFound(w, UserNotFound, "cannot find an user")
+		return nil, "", false
+	}
+	role, err := h.groupRepo.GetMemberRole(groupId, caller.Id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", UserGroupServerError).Msg("Error occurred during GetMemberRole")
+		response.InternalServerError(w, "Failed to check group role")
+		return nil, "", false
+	}
+	if role == "" {
+		h.logger.Error().Str("ErrorCode", UserGroupNotMember).Msg("user is not a member of the group")
+		response.ErrorResponse(w, http.StatusForbidden, *response.New(UserGroupNotMember, errors.PermissionDenied, "only group members can change the group"))
+		return nil, "", false
+	}
+	return caller, role, true
+}
+
+// requireManager resolves the caller and checks that they are an admin or an
+// owner of the group.
+func (h *Handler) requireManager(w http.ResponseWriter, r *http.Request, groupId int) (*userModel.User, bool) {
+	caller, role, ok := h.callerRole(w, r, groupId)
+	if !ok {
+		return nil, false
+	}
+	if !model.CanManage(role) {
+		h.logger.Error().Str("ErrorCode", UserGroupNotAdmin).Msg("user is not an admin of the group")
+		response.ErrorResponse(w, http.StatusForbidden, *response.New(UserGroupNotAdmin, errors.PermissionDenied, "only group admins can manage the group's members"))
+		return nil, false
+	}
+	return caller, true
+}
+
+// memberRole resolves a member of the group by user id together with their role.
+func (h *Handler) memberRole(w http.ResponseWriter, groupId int, userId string) (*userModel.User, string, bool) {
+	user, err := h.userRepo.GetUserByUserId(userId)
+	if err != nil || user == nil {
+		h.logger.Error().Err(err).Str("ErrorCode", UserNotFound).Msg("Provided user id cannot be found")
+		response.NotFound(w, UserNotFound, "Provided user id cannot be found")
+		return nil, "", false
+	}
+	role, err := h.groupRepo.GetMemberRole(groupId, user.Id)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", UserGroupServerError).Msg("Error occurred during GetMemberRole")
+		response.InternalServerError(w, "Failed to check group role")
+		return nil, "", false
+	}
+	if role == "" {
+		h.logger.Error().Str("ErrorCode", UserGroupMemberNotFound).Msg("user is not a member of the group")
+		response.NotFound(w, UserGroupMemberNotFound, "user is not a member of the group")
+		return nil, "", false
+	}
+	return user, role, true
+}
+
+// checkOwnerRemains keeps at least one owner in the group when a member with
+// currentRole loses the role or leaves.
+func (h *Handler) checkOwnerRemains(w http.ResponseWriter, groupId int, currentRole string) bool {
+	if currentRole != model.RoleOwner {
+		return true
+	}
+	owners, err := h.groupRepo.CountOwners(groupId)
+	if err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", UserGroupServerError).Msg("Error occurred during CountOwners")
+		response.InternalServerError(w, "Failed to count group owners")
+		return false
+	}
+	if err = model.CheckOwnerRemains(currentRole, owners); err != nil {
+		h.logger.Error().Err(err).Str("ErrorCode", UserGroupDomainError).Msg(err.Error())
+		response.ErrorResponse(w, http.StatusUnprocessableEntity, *response.New(UserGroupDomainError, "Domain Validation Error", err.Error()))
+		return false
+	}
+	return true
+}
+
+// currentUser resolves the caller from the nameid claim of the token.
+func (h *Handler) currentUser(r *http.Request) (*userModel.User, error) {
+	_, claims, _ := jwtauth.FromContext(r.Context())
+	return h.userRepo.GetUserByUserId(fmt.Sprintf("%v", claims["nameid"]))
+}
@@ -0,0 +1,159 @@
+package route
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth"
+	"github.com/happYness-Project/taskManagementGolang/internal/mocks"
+	userModel "github.com/happYness-Project/taskManagementGolang/internal/user/model"
+	"github.com/happYness-Project/taskManagementGolang/internal/usergroup/model"
+	"github.com/happYness-Project/taskManagementGolang/pkg/configs"
+	"github.com/happYness-Project/taskManagementGolang/pkg/loggers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type membershipRecorder struct{ mock.Mock }
+
+func (m *membershipRecorder) MemberJoined(groupId int, userId int) { m.Called(groupId, userId) }
+func (m *membershipRecorder) MemberLeft(groupId int, userId int)   { m.Called(groupId, userId) }
+
+func TestUserGroupHandler_Roles(t *testing.T) {
+	logger := loggers.Setup(configs.Env{})
+	mockGroupRepo := new(mocks.MockUserGroupRepo)
+	mockUserRepo := new(mocks.MockUserRepo)
+	handler := NewHandler(logger, mockGroupRepo, mockUserRepo, new(membershipRecorder))
+	router := chi.NewRouter()
+	handler.RegisterRoutes(router)
+	mockUserRepo.On("GetUserByUserId", "owner").Return(&userModel.User{Id: 1, UserId: "owner"}, nil)
+	mockUserRepo.On("GetUserByUserId", "admin").Return(&userModel.User{Id: 2, UserId: "admin"}, nil)
+	mockUserRepo.On("GetUserByUserId", "member").Return(&userModel.User{Id: 3, UserId: "member"}, nil)
+	mockGroupRepo.On("GetMemberRole", 7, 1).Return(model.RoleOwner, nil)
+	mockGroupRepo.On("GetMemberRole", 7, 2).Return(model.RoleAdmin, nil)
+	mockGroupRepo.On("GetMemberRole", 7, 3).Return(model.RoleMember, nil)
+
+	t.Run("when an admin promotes a member to admin, Then the role is changed", func(t *testing.T) {
+		// Arrange
+		mockGroupRepo.On("UpdateMemberRole", 7, 3, model.RoleAdmin, mock.AnythingOfType("model.GroupActivity")).Return(nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodPatch, "/api/user-groups/7/users/member/role", strings.NewReader(`{"role":"admin"}`)), "admin")
+		rr := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockGroupRepo.AssertCalled(t, "UpdateMemberRole", 7, 3, model.RoleAdmin, mock.AnythingOfType("model.GroupActivity"))
+	})
+
+	t.Run("when an admin grants ownership, Then return status code 403", func(t *testing.T) {
+		req := withCaller(t, httptest.NewRequest(http.MethodPatch, "/api/user-groups/7/users/member/role", strings.NewReader(`{"role":"owner"}`)), "admin")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockGroupRepo.AssertNotCalled(t, "UpdateMemberRole", 7, 3, model.RoleOwner, mock.Anything)
+	})
+
+	t.Run("when a member changes a role, Then return status code 403", func(t *testing.T) {
+		req := withCaller(t, httptest.NewRequest(http.MethodPatch, "/api/user-groups/7/users/admin/role", strings.NewReader(`{"role":"member"}`)), "member")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockGroupRepo.AssertNotCalled(t, "UpdateMemberRole", 7, 2, model.RoleMember, mock.Anything)
+	})
+
+	t.Run("when the last owner steps down, Then return status code 422", func(t *testing.T) {
+		mockGroupRepo.On("CountOwners", 7).Return(1, nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodPatch, "/api/user-groups/7/users/owner/role", strings.NewReader(`{"role":"admin"}`)), "owner")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		mockGroupRepo.AssertNotCalled(t, "UpdateMemberRole", 7, 1, model.RoleAdmin, mock.Anything)
+	})
+
+	t.Run("when a member adds a user to the group, Then return status code 403", func(t *testing.T) {
+		req := withCaller(t, httptest.NewRequest(http.MethodPost, "/api/user-groups/7/users", strings.NewReader(`{"user_id":"outsider"}`)), "member")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockGroupRepo.AssertNotCalled(t, "InsertUserGroupUserTable", 7, mock.Anything)
+	})
+
+	t.Run("when an admin deletes the group, Then return status code 403", func(t *testing.T) {
+		req := withCaller(t, httptest.NewRequest(http.MethodDelete, "/api/user-groups/7", nil), "admin")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockGroupRepo.AssertNotCalled(t, "DeleteUserGroup", 7)
+	})
+
+	t.Run("when the owner deletes the group, Then the group is deleted", func(t *testing.T) {
+		mockGroupRepo.On("DeleteUserGroup", 7).Return(nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodDelete, "/api/user-groups/7", nil), "owner")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		mockGroupRepo.AssertCalled(t, "DeleteUserGroup", 7)
+	})
+}
+
+func TestUserGroupHandler_AcceptInvitation(t *testing.T) {
+	logger := loggers.Setup(configs.Env{})
+	mockGroupRepo := new(mocks.MockUserGroupRepo)
+	mockUserRepo := new(mocks.MockUserRepo)
+	handler := NewHandler(logger, mockGroupRepo, mockUserRepo, new(membershipRecorder))
+	router := chi.NewRouter()
+	handler.RegisterRoutes(router)
+	mockUserRepo.On("GetUserByUserId", "guest").Return(&userModel.User{Id: 5, UserId: "guest"}, nil)
+	mockGroupRepo.On("IsUserInGroup", 7, 5).Return(false, nil)
+	invitation := &model.Invitation{Id: "inv-1", GroupId: 7, Token: "token", MaxUses: 1, ExpiresAt: time.Now().Add(time.Hour)}
+	mockGroupRepo.On("GetInvitationByToken", "token").Return(invitation, nil)
+
+	t.Run("when invitation is accepted, Then the use and the membership are stored together", func(t *testing.T) {
+		// Arrange
+		mockGroupRepo.On("AcceptInvitation", "inv-1", 7, 5, mock.AnythingOfType("time.Time")).Return(true, nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodPost, "/api/invitations/token/accept", nil), "guest")
+		rr := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockGroupRepo.AssertNotCalled(t, "InsertUserGroupUserTable", 7, 5)
+	})
+
+	t.Run("when another user took the last use, Then return status code 422", func(t *testing.T) {
+		mockGroupRepo.On("AcceptInvitation", "inv-1", 7, 5, mock.AnythingOfType("time.Time")).Return(false, nil).Once()
+		req := withCaller(t, httptest.NewRequest(http.MethodPost, "/api/invitations/token/accept", nil), "guest")
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+}
+
+func withCaller(t *testing.T, req *http.Request, userId string) *http.Request {
+	token, _, err := jwtauth.New("HS256", []byte("secret"), nil).Encode(map[string]interface{}{"nameid": userId})
+	require.NoError(t, err)
+	return req.WithContext(jwtauth.NewContext(req.Context(), token, nil))
+}
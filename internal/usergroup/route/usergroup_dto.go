@@ -5,3 +5,34 @@ type CreateUserGroupDto struct {
 	GroupDesc string `json:"description"`
 	GroupType string `json:"type"`
 }
+
+type UpdateUserGroupDto struct {
+	GroupName string `json:"name"`
+	GroupDesc string `json:"description"`
+	GroupType string `json:"type"`
+	Thumbnail string `json:"thumbnailurl"`
+}
+
+// PatchUserGroupDto holds the fields of a partial update. Nil fields are kept.
+type PatchUserGroupDto struct {
+	GroupName *string `json:"name"`
+	GroupDesc *string `json:"description"`
+	GroupType *string `json:"type"`
+	Thumbnail *string `json:"thumbnailurl"`
+}
+
+type UpdateUserGroupSettingsDto struct {
+	ArchiveCompletedAfterDays *int `json:"archive_completed_after_days"`
+}
+
+type MemberRoleDto struct {
+	UserId string `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+// CreateInvitationDto creates a single-use invitation valid for a week when
+// the fields are left out.
+type CreateInvitationDto struct {
+	MaxUses        int `json:"max_uses"`
+	ExpiresInHours int `json:"expires_in_hours"`
+}
@@ -25,6 +25,12 @@ type Env struct {
 	RefreshTokenExpiryHour int    `mapstructure:"REFRESH_TOKEN_EXPIRY_HOUR"`
 	AccessTokenSecret      string `mapstructure:"ACCESS_TOKEN_SECRET"`
 	RefreshTokenSecret     string `mapstructure:"REFRESH_TOKEN_SECRET"`
+
+	SMTPHost string `mapstructure:"SMTP_HOST"`
+	SMTPPort string `mapstructure:"SMTP_PORT"`
+	SMTPUser string `mapstructure:"SMTP_USER"`
+	SMTPPwd  string `mapstructure:"SMTP_PWD"`
+	SMTPFrom string `mapstructure:"SMTP_FROM"`
 }
 
 func InitConfig(envString string) Env {
@@ -45,6 +51,11 @@ func InitConfig(envString string) Env {
 		env.DBPwd = os.Getenv("DB_PWD")
 		env.AccessTokenSecret = os.Getenv("ACCESS_TOKEN_SECRET")
 		env.RefreshTokenSecret = os.Getenv("ACCESS_TOKEN_SECRET")
+		env.SMTPHost = os.Getenv("SMTP_HOST")
+		env.SMTPPort = os.Getenv("SMTP_PORT")
+		env.SMTPUser = os.Getenv("SMTP_USER")
+		env.SMTPPwd = os.Getenv("SMTP_PWD")
+		env.SMTPFrom = os.Getenv("SMTP_FROM")
 		return env
 	}
 	err := viper.ReadInConfig()
@@ -0,0 +1,12 @@
+package utils
+
+import "time"
+
+// Clock abstracts the current time so background jobs can be tested with a fake clock.
+type Clock interface {
+	Now() time.Time
+}
+
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time { return time.Now() }
@@ -32,7 +32,7 @@ func Test_TaskRepository_GetAllTasks_ReturnSuccess(t *testing.T) {
 
 	taskRepo := repository.NewTaskRepository(db)
 
-	tasks, err := taskRepo.GetAllTasks()
+	tasks, err := taskRepo.GetAllTasks(0)
 
 	require.Nil(t, err)
 	require.NotNil(t, tasks)
@@ -44,7 +44,7 @@ func Test_TaskRepository_GetTaskById_ReturnSuccess(t *testing.T) {
 	}
 
 	taskRepo := repository.NewTaskRepository(db)
-	tasks, err := taskRepo.GetAllTasks()
+	tasks, err := taskRepo.GetAllTasks(0)
 	if err != nil {
 		return
 	}